@@ -0,0 +1,127 @@
+package ggol
+
+import "sync"
+
+// Units might follow other rules after the game is changed, so all of them are generated in the next generation.
+func (g *gameInfo[T]) activateAllUnits() {
+	g.areAllUnitsActive = true
+}
+
+// Collect units within the radius of units changed in the last generation, units outside the border are resolved
+// with the boundary policy, so units reading changed units across the border are collected too.
+func (g *gameInfo[T]) collectActiveCoords() {
+	g.activeCoords = g.activeCoords[:0]
+	for _, changedCoord := range g.changedCoords {
+		for i := -g.activeRadius; i <= g.activeRadius; i++ {
+			for j := -g.activeRadius; j <= g.activeRadius; j++ {
+				x := changedCoord.X + i
+				y := changedCoord.Y + j
+				if x < 0 || x >= g.size.Width || y < 0 || y >= g.size.Height {
+					var isResolved bool
+					x, y, isResolved = resolveCoordinateWithBoundaryPolicy(g.boundaryPolicy, g.size, x, y)
+					if !isResolved {
+						continue
+					}
+				}
+				if !g.activeMarks[x][y] {
+					g.activeMarks[x][y] = true
+					g.activeCoords = append(g.activeCoords, Coordinate{X: x, Y: y})
+				}
+			}
+		}
+	}
+	for _, coord := range g.activeCoords {
+		g.activeMarks[coord.X][coord.Y] = false
+	}
+}
+
+// Generate next units of active coordinates from "from" to "to" (exclusive) with the coordinate of the given worker.
+func (g *gameInfo[T]) generateNextUnitsOfActiveCoords(worker int, from int, to int) {
+	for _, coord := range g.activeCoords[from:to] {
+		(*g.nextUnits)[coord.X][coord.Y] = *g.generateNextUnit(worker, coord.X, coord.Y, 0)
+	}
+}
+
+// Generate next units of active coordinates into the buffer, with goroutines as many as the concurrency.
+func (g *gameInfo[T]) generateNextUnitsOfActiveCoordsInParallel() {
+	workersCount := g.concurrency
+	if workersCount > len(g.activeCoords) {
+		workersCount = len(g.activeCoords)
+	}
+	if workersCount <= 1 {
+		g.generateNextUnitsOfActiveCoords(0, 0, len(g.activeCoords))
+		return
+	}
+
+	coordsCountPerWorker := (len(g.activeCoords) + workersCount - 1) / workersCount
+	wg := sync.WaitGroup{}
+	for worker := 0; worker*coordsCountPerWorker < len(g.activeCoords); worker++ {
+		from := worker * coordsCountPerWorker
+		to := from + coordsCountPerWorker
+		if to > len(g.activeCoords) {
+			to = len(g.activeCoords)
+		}
+		wg.Add(1)
+		go func(worker int, from int, to int) {
+			defer wg.Done()
+			g.generateNextUnitsOfActiveCoords(worker, from, to)
+		}(worker, from, to)
+	}
+	wg.Wait()
+}
+
+// Generate next units synchronously, but only units around units changed in the last generation.
+// Other units didn't change in the last generation, so they're the same in both buffers and we don't need to touch them.
+func (g *gameInfo[T]) generateNextUnitsOfActiveRegion() {
+	if g.areAllUnitsActive {
+		g.generateNextUnitsSynchronously()
+		g.changedCoords = g.changedCoords[:0]
+		for x := 0; x < g.size.Width; x++ {
+			for y := 0; y < g.size.Height; y++ {
+				if !g.areUnitsEqual(&(*g.units)[x][y], &(*g.nextUnits)[x][y]) {
+					g.changedCoords = append(g.changedCoords, Coordinate{X: x, Y: y})
+				}
+			}
+		}
+		g.areAllUnitsActive = false
+		return
+	}
+
+	g.collectActiveCoords()
+	g.generateNextUnitsOfActiveCoordsInParallel()
+	g.changedCoords = g.changedCoords[:0]
+	for _, coord := range g.activeCoords {
+		if !g.areUnitsEqual(&(*g.nextUnits)[coord.X][coord.Y], &(*g.units)[coord.X][coord.Y]) {
+			g.changedCoords = append(g.changedCoords, coord)
+		}
+	}
+	g.units, g.nextUnits = g.nextUnits, g.units
+}
+
+// Set active region tracking with the equality checker and the radius.
+func (g *gameInfo[T]) SetActiveRegionTracking(areUnitsEqual UnitsEqualityChecker[T], radius int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if areUnitsEqual != nil && radius < 1 {
+		return &ErrRadiusIsInvalid{radius}
+	}
+	// GlobalState and GenerationContext change between generations, so units can't be kept.
+	// Pipelines set NextUnitGeneratorWithContext too.
+	if areUnitsEqual != nil && (g.globalState != nil || g.nextUnitGeneratorWithContext != nil) {
+		return &ErrActiveRegionTrackingIsIncompatible{}
+	}
+	g.areUnitsEqual = areUnitsEqual
+	g.activeRadius = radius
+	g.activateAllUnits()
+	g.changedCoords = nil
+	g.activeCoords = nil
+	g.activeMarks = nil
+	if areUnitsEqual != nil {
+		g.activeMarks = make([][]bool, g.size.Width)
+		for x := 0; x < g.size.Width; x++ {
+			g.activeMarks[x] = make([]bool, g.size.Height)
+		}
+	}
+	return nil
+}
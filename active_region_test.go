@@ -0,0 +1,241 @@
+package ggol
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// A 1000x1000 map that is empty except for some small soups, like most Life-like worlds.
+func generateSparseSoupForTest(width int, height int, soupsCount int) *[][]unitForTest {
+	units := generateInitialUnitMatrixForTest(width, height, initialUnitForTest)
+	random := rand.New(rand.NewSource(0))
+	for i := 0; i < soupsCount; i++ {
+		fromX := random.Intn(width - 16)
+		fromY := random.Intn(height - 16)
+		for x := fromX; x < fromX+16; x++ {
+			for y := fromY; y < fromY+16; y++ {
+				(*units)[x][y].hasLiveCell = random.Intn(2) == 0
+			}
+		}
+	}
+	return units
+}
+
+// Same rules as nonAllocatingUnitForTestIterator, but units across the border are counted with the boundary policy.
+func boundaryLifeUnitForTestIterator(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+	var aliveAdjacentCellsCount int = 0
+	for i := 0; i < len(adjacentCoordsForTest); i += 1 {
+		adjUnit, _ := getAdjacentUnit(coord, &adjacentCoordsForTest[i])
+		if adjUnit.hasLiveCell {
+			aliveAdjacentCellsCount += 1
+		}
+	}
+	if aliveAdjacentCellsCount == 3 || (unit.hasLiveCell && aliveAdjacentCellsCount == 2) {
+		return &liveUnitForTest
+	}
+	return &deadUnitForTest
+}
+
+func generateSparseSoupUnitsForTest(policy BoundaryPolicy, concurrency int, isTracked bool) *[][]unitForTest {
+	g, _ := NewGame(generateSparseSoupForTest(60, 40, 4))
+	g.SetNextUnitGenerator(boundaryLifeUnitForTestIterator)
+	g.SetBoundaryPolicy(policy)
+	// Live units outside the border make units on the edge alive with BoundaryPolicyConstant.
+	g.SetBoundaryUnit(&liveUnitForTest)
+	g.SetConcurrency(concurrency)
+	if isTracked {
+		g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 1)
+	}
+	for i := 0; i < 30; i++ {
+		g.GenerateNextUnits()
+		if i == 10 {
+			g.SetUnit(&Coordinate{X: 0, Y: 0}, &liveUnitForTest)
+			g.SetUnit(&Coordinate{X: 1, Y: 0}, &liveUnitForTest)
+			g.SetUnit(&Coordinate{X: 59, Y: 0}, &liveUnitForTest)
+		}
+		if i == 20 {
+			g.SetUpdateOrder(UpdateOrderCheckerboard, 0)
+		}
+		if i == 22 {
+			g.SetUpdateOrder(UpdateOrderSynchronous, 0)
+		}
+	}
+	return g.GetUnits()
+}
+
+func testSetActiveRegionTrackingCaseOne(t *testing.T) {
+	policies := []BoundaryPolicy{
+		BoundaryPolicyWrap, BoundaryPolicyConstant, BoundaryPolicyClamp, BoundaryPolicyMirror,
+		BoundaryPolicyKleinBottle, BoundaryPolicyProjectivePlane,
+	}
+	for _, policy := range policies {
+		expectedUnits := generateSparseSoupUnitsForTest(policy, 1, false)
+		for _, concurrency := range []int{1, 4} {
+			units := generateSparseSoupUnitsForTest(policy, concurrency, true)
+			if !areTwoUnitsHavingLiveCellForTestEqual(
+				*convertUnitForTestMatrixToUnitsHavingLiveCellForTest(units),
+				*convertUnitForTestMatrixToUnitsHavingLiveCellForTest(expectedUnits),
+			) {
+				t.Fatalf("Units should be the same as generating all units with boundary policy %v and concurrency %v.", policy, concurrency)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetActiveRegionTrackingCaseTwo(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(50, 50, initialUnitForTest))
+	calledCount := 0
+	g.SetNextUnitGenerator(func(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+		calledCount += 1
+		return nonAllocatingUnitForTestIterator(coord, unit, getAdjacentUnit)
+	})
+	g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 1)
+	// A blinker.
+	g.SetUnit(&Coordinate{X: 10, Y: 9}, &liveUnitForTest)
+	g.SetUnit(&Coordinate{X: 10, Y: 10}, &liveUnitForTest)
+	g.SetUnit(&Coordinate{X: 10, Y: 11}, &liveUnitForTest)
+
+	// All units are generated in the first generation.
+	g.GenerateNextUnits()
+	if calledCount != 2500 {
+		t.Fatalf("All 2500 units should be generated in the first generation, but got %v.", calledCount)
+	}
+	// 4 units change in every generation of a blinker, and there are 21 units around them.
+	calledCount = 0
+	g.GenerateNextUnits()
+	if calledCount != 21 {
+		t.Fatalf("21 units should be generated in the second generation, but got %v.", calledCount)
+	}
+
+	// Units set with SetUnit are tracked too.
+	calledCount = 0
+	g.SetUnit(&Coordinate{X: 30, Y: 30}, &liveUnitForTest)
+	g.GenerateNextUnits()
+	if calledCount != 21+9 {
+		t.Fatalf("30 units should be generated after a unit is set, but got %v.", calledCount)
+	}
+
+	// All units are generated after the game is changed.
+	calledCount = 0
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	g.GenerateNextUnits()
+	if calledCount != 2500 {
+		t.Fatalf("All 2500 units should be generated after the boundary policy is set, but got %v.", calledCount)
+	}
+
+	// Stop tracking.
+	calledCount = 0
+	g.SetActiveRegionTracking(nil, 0)
+	g.GenerateNextUnits()
+	if calledCount != 2500 {
+		t.Fatalf("All 2500 units should be generated without tracking, but got %v.", calledCount)
+	}
+	t.Log("Passed")
+}
+
+func testSetActiveRegionTrackingCaseThree(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(3, 3, initialUnitForTest))
+	err := g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 0)
+	if _, ok := err.(*ErrRadiusIsInvalid); !ok {
+		t.Fatalf("Should get ErrRadiusIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func testSetActiveRegionTrackingCaseFour(t *testing.T) {
+	nextUnitGeneratorWithContext := func(context *GenerationContext, coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+		return unit
+	}
+	setters := map[string]func(g Game[unitForTest]) error{
+		"GlobalState": func(g Game[unitForTest]) error {
+			return g.SetGlobalState(NewGlobalState(0, func(state int, contributions []int) int { return state }))
+		},
+		"NextUnitGeneratorWithContext": func(g Game[unitForTest]) error {
+			return g.SetNextUnitGeneratorWithContext(nextUnitGeneratorWithContext)
+		},
+		"NextUnitGeneratorPipeline": func(g Game[unitForTest]) error {
+			return g.SetNextUnitGeneratorPipeline(nextUnitGeneratorWithContext, nextUnitGeneratorWithContext)
+		},
+	}
+	for name, set := range setters {
+		// Tracking is set first.
+		g, _ := NewGame(generateInitialUnitMatrixForTest(3, 3, initialUnitForTest))
+		g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 1)
+		if _, ok := set(g).(*ErrActiveRegionTrackingIsIncompatible); !ok {
+			t.Fatalf("Should get ErrActiveRegionTrackingIsIncompatible when setting %v with tracking.", name)
+		}
+		g.SetActiveRegionTracking(nil, 0)
+		if err := set(g); err != nil {
+			t.Fatalf("Should set %v without tracking, but got %v.", name, err)
+		}
+
+		// Tracking is set afterwards.
+		err := g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 1)
+		if _, ok := err.(*ErrActiveRegionTrackingIsIncompatible); !ok {
+			t.Fatalf("Should get ErrActiveRegionTrackingIsIncompatible when tracking with %v, but got %v.", name, err)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetActiveRegionTrackingWithoutAllocation(t *testing.T) {
+	g, _ := NewGame(generateSparseSoupForTest(64, 64, 2))
+	g.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+	g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 1)
+	// Buffers of coordinates grow in the first generations, and they're reused afterwards.
+	for i := 0; i < 100; i++ {
+		g.GenerateNextUnits()
+	}
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestSetActiveRegionTracking(t *testing.T) {
+	testSetActiveRegionTrackingCaseOne(t)
+	testSetActiveRegionTrackingCaseTwo(t)
+	testSetActiveRegionTrackingCaseThree(t)
+	testSetActiveRegionTrackingCaseFour(t)
+	testSetActiveRegionTrackingWithoutAllocation(t)
+}
+
+func benchmarkGenerateNextUnitsOfSparseSoup(b *testing.B, isTracked bool, concurrency int) {
+	g, _ := NewGame(generateSparseSoupForTest(1000, 1000, 20))
+	g.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+	g.SetConcurrency(concurrency)
+	if isTracked {
+		g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 1)
+	}
+	// Let soups settle down a little, the first generation generates all units anyway.
+	for i := 0; i < 10; i++ {
+		g.GenerateNextUnits()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.GenerateNextUnits()
+	}
+}
+
+func BenchmarkGenerateNextUnitsOfSparseSoup1000x1000(b *testing.B) {
+	benchmarkGenerateNextUnitsOfSparseSoup(b, false, 1)
+}
+
+func BenchmarkGenerateNextUnitsOfSparseSoup1000x1000WithActiveRegionTracking(b *testing.B) {
+	benchmarkGenerateNextUnitsOfSparseSoup(b, true, 1)
+}
+
+func BenchmarkGenerateNextUnitsOfSparseSoup1000x1000InParallel(b *testing.B) {
+	benchmarkGenerateNextUnitsOfSparseSoup(b, false, 8)
+}
+
+func BenchmarkGenerateNextUnitsOfSparseSoup1000x1000WithActiveRegionTrackingInParallel(b *testing.B) {
+	benchmarkGenerateNextUnitsOfSparseSoup(b, true, 8)
+}
@@ -0,0 +1,264 @@
+package ggol
+
+import (
+	"fmt"
+	"sync"
+)
+
+// This error will be thrown when units can't be divided into 2x2 blocks.
+type ErrBlockUnitsIsInvalid struct {
+	Size *Size
+}
+
+// Tell you that units can't be divided into blocks.
+func (e *ErrBlockUnitsIsInvalid) Error() string {
+	return fmt.Sprintf("Given units of size %vx%v is not valid, width and height should be even and greater than 0.", e.Size.Width, e.Size.Height)
+}
+
+// Block is a 2x2 block of units, block[x][y] is the unit at (x, y) relative to the top-left unit of the block.
+type Block[T any] [2][2]T
+
+// BlockGenerator tells the game how you're gonna map the given block into a new block,
+// "coord" is the coordinate of the top-left unit of the block.
+// The "coord" and "block" are reused by the game, so don't keep them after the generator returns.
+type BlockGenerator[T any] func(coord *Coordinate, block *Block[T]) (generatedBlock *Block[T])
+
+// "T" in the BlockGame interface represents the type of unit, it's defined by you.
+// BlockGame partitions units into 2x2 blocks with the Margolus neighborhood, blocks start at (0, 0) in even generations
+// and at (1, 1) in odd generations, and every block is mapped into a new block as a whole.
+// The map is a torus, so the blocks on the edges in odd generations wrap around.
+// When the BlockGenerator is a bijection, the game is reversible, and you can generate previous units with its inverse.
+type BlockGame[T any] interface {
+	// Generate next units by mapping every block with the BlockGenerator you passed in SetNextBlockGenerator.
+	GenerateNextUnits() (units *[][]T)
+	// Generate previous units by mapping every block with the BlockGenerator you passed in SetPreviousBlockGenerator,
+	// blocks are partitioned as they were in the last generation, so it undoes GenerateNextUnits for reversible rules.
+	GeneratePreviousUnits() (units *[][]T)
+	// Set the BlockGenerator that maps blocks into next blocks.
+	SetNextBlockGenerator(nextBlockGenerator BlockGenerator[T])
+	// Set the BlockGenerator that maps blocks into previous blocks, it should be the inverse of the next one.
+	SetPreviousBlockGenerator(previousBlockGenerator BlockGenerator[T])
+	// Set how many goroutines GenerateNextUnits can use at most, default is 1.
+	SetConcurrency(concurrency int) (err error)
+	// Get the count of generations, it's negative when you generate previous units from the initial units.
+	GetGenerationsCount() (generationsCount int)
+	// Set the status of the unit at the given coordinate.
+	SetUnit(coord *Coordinate, unit *T) (err error)
+	// Get the size of the game.
+	GetSize() (size *Size)
+	// Get the status of the unit at the given coordinate.
+	GetUnit(coord *Coordinate) (unit *T, err error)
+	// Get all units in the game.
+	GetUnits() (units *[][]T)
+	// Iterate through all units in the game
+	IterateUnits(callback UnitsIteratorCallback[T])
+}
+
+type blockGameInfo[T any] struct {
+	size                   *Size
+	units                  *[][]T
+	nextBlockGenerator     BlockGenerator[T]
+	previousBlockGenerator BlockGenerator[T]
+	// Blocks start at (0, 0) when it's even, otherwise they start at (1, 1).
+	generationsCount int
+	concurrency      int
+	// Every worker has its own coordinate and block to pass into BlockGenerator, so we don't allocate them for every block.
+	workerCoords []Coordinate
+	workerBlocks []Block[T]
+	locker       sync.RWMutex
+}
+
+func defaultBlockGenerator[T any](coord *Coordinate, block *Block[T]) (generatedBlock *Block[T]) {
+	return block
+}
+
+// Return a new BlockGame with the given units, width and height of units should be even.
+func NewBlockGame[T any](
+	units *[][]T,
+) (BlockGame[T], error) {
+	size, err := calculateSizeFromUnits(units)
+	if err != nil {
+		return nil, err
+	}
+	if size.Width == 0 || size.Height == 0 || size.Width%2 != 0 || size.Height%2 != 0 {
+		return nil, &ErrBlockUnitsIsInvalid{size}
+	}
+
+	newG := blockGameInfo[T]{
+		size:                   size,
+		units:                  units,
+		nextBlockGenerator:     defaultBlockGenerator[T],
+		previousBlockGenerator: defaultBlockGenerator[T],
+		concurrency:            1,
+		workerCoords:           make([]Coordinate, 1),
+		workerBlocks:           make([]Block[T], 1),
+		locker:                 sync.RWMutex{},
+	}
+
+	return &newG, nil
+}
+
+func (g *blockGameInfo[T]) isCoordinateInvalid(c *Coordinate) bool {
+	return c.X < 0 || c.X >= g.size.Width || c.Y < 0 || c.Y >= g.size.Height
+}
+
+// Generate blocks of the block columns from "fromColumn" to "toColumn" (exclusive) in place, blocks never overlap,
+// so workers don't need to wait for each other.
+func (g *blockGameInfo[T]) generateBlocksInColumns(worker int, fromColumn int, toColumn int, offset int, blockGenerator BlockGenerator[T]) {
+	coord := &g.workerCoords[worker]
+	block := &g.workerBlocks[worker]
+	for column := fromColumn; column < toColumn; column++ {
+		x := 2*column + offset
+		nextX := (x + 1) % g.size.Width
+		for y := offset; y < g.size.Height; y += 2 {
+			nextY := (y + 1) % g.size.Height
+			block[0][0] = (*g.units)[x][y]
+			block[1][0] = (*g.units)[nextX][y]
+			block[0][1] = (*g.units)[x][nextY]
+			block[1][1] = (*g.units)[nextX][nextY]
+			coord.X = x
+			coord.Y = y
+			generatedBlock := blockGenerator(coord, block)
+			(*g.units)[x][y] = generatedBlock[0][0]
+			(*g.units)[nextX][y] = generatedBlock[1][0]
+			(*g.units)[x][nextY] = generatedBlock[0][1]
+			(*g.units)[nextX][nextY] = generatedBlock[1][1]
+		}
+	}
+}
+
+func (g *blockGameInfo[T]) generateBlocks(offset int, blockGenerator BlockGenerator[T]) {
+	columnsCount := g.size.Width / 2
+	workersCount := g.concurrency
+	if workersCount > columnsCount {
+		workersCount = columnsCount
+	}
+	if workersCount <= 1 {
+		g.generateBlocksInColumns(0, 0, columnsCount, offset, blockGenerator)
+		return
+	}
+
+	// Split block columns into stripes, every worker takes care of one stripe.
+	stripeWidth := (columnsCount + workersCount - 1) / workersCount
+	wg := sync.WaitGroup{}
+	for worker := 0; worker*stripeWidth < columnsCount; worker++ {
+		fromColumn := worker * stripeWidth
+		toColumn := fromColumn + stripeWidth
+		if toColumn > columnsCount {
+			toColumn = columnsCount
+		}
+		wg.Add(1)
+		go func(worker int, fromColumn int, toColumn int) {
+			defer wg.Done()
+			g.generateBlocksInColumns(worker, fromColumn, toColumn, offset, blockGenerator)
+		}(worker, fromColumn, toColumn)
+	}
+	wg.Wait()
+}
+
+// Generate next units.
+func (g *blockGameInfo[T]) GenerateNextUnits() *[][]T {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.generateBlocks(floorModulo(g.generationsCount, 2), g.nextBlockGenerator)
+	g.generationsCount += 1
+	return g.units
+}
+
+// Generate previous units.
+func (g *blockGameInfo[T]) GeneratePreviousUnits() *[][]T {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.generationsCount -= 1
+	g.generateBlocks(floorModulo(g.generationsCount, 2), g.previousBlockGenerator)
+	return g.units
+}
+
+func (g *blockGameInfo[T]) SetNextBlockGenerator(nextBlockGenerator BlockGenerator[T]) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.nextBlockGenerator = nextBlockGenerator
+}
+
+func (g *blockGameInfo[T]) SetPreviousBlockGenerator(previousBlockGenerator BlockGenerator[T]) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.previousBlockGenerator = previousBlockGenerator
+}
+
+// Set the max count of goroutines used to generate next units.
+func (g *blockGameInfo[T]) SetConcurrency(concurrency int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if concurrency < 1 {
+		return &ErrConcurrencyIsInvalid{concurrency}
+	}
+	g.concurrency = concurrency
+	g.workerCoords = make([]Coordinate, concurrency)
+	g.workerBlocks = make([]Block[T], concurrency)
+
+	return nil
+}
+
+// Get the count of generations.
+func (g *blockGameInfo[T]) GetGenerationsCount() int {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.generationsCount
+}
+
+// Update the unit at the given coordinate.
+func (g *blockGameInfo[T]) SetUnit(c *Coordinate, unit *T) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if g.isCoordinateInvalid(c) {
+		return &ErrCoordinateIsInvalid{c}
+	}
+	(*g.units)[c.X][c.Y] = *unit
+
+	return nil
+}
+
+// Get the game size.
+func (g *blockGameInfo[T]) GetSize() *Size {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.size
+}
+
+// Get the unit at the coordinate.
+func (g *blockGameInfo[T]) GetUnit(c *Coordinate) (*T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	if g.isCoordinateInvalid(c) {
+		return nil, &ErrCoordinateIsInvalid{c}
+	}
+
+	return &(*g.units)[c.X][c.Y], nil
+}
+
+// Get all units in the game
+func (g *blockGameInfo[T]) GetUnits() *[][]T {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.units
+}
+
+// We will iterate all units in the game and call the callbacks with coordiante and unit.
+func (g *blockGameInfo[T]) IterateUnits(callback UnitsIteratorCallback[T]) {
+	for x := 0; x < g.size.Width; x++ {
+		for y := 0; y < g.size.Height; y++ {
+			callback(&Coordinate{X: x, Y: y}, &(*g.units)[x][y])
+		}
+	}
+}
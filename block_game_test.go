@@ -0,0 +1,149 @@
+package ggol
+
+import (
+	"testing"
+)
+
+// Rotate the block by 180 degrees in place.
+func rotatingBlockForTestGenerator(coord *Coordinate, block *Block[int]) *Block[int] {
+	block[0][0], block[1][1] = block[1][1], block[0][0]
+	block[1][0], block[0][1] = block[0][1], block[1][0]
+	return block
+}
+
+// Rotate the block clockwise in place, (0, 0) goes to (1, 0).
+func clockwiseBlockForTestGenerator(coord *Coordinate, block *Block[int]) *Block[int] {
+	block[0][0], block[1][0], block[1][1], block[0][1] = block[0][1], block[0][0], block[1][0], block[1][1]
+	return block
+}
+
+// Rotate the block counterclockwise in place, it's the inverse of clockwiseBlockForTestGenerator.
+func counterclockwiseBlockForTestGenerator(coord *Coordinate, block *Block[int]) *Block[int] {
+	block[0][0], block[1][0], block[1][1], block[0][1] = block[1][0], block[1][1], block[0][1], block[0][0]
+	return block
+}
+
+func testNewBlockGameCaseOne(t *testing.T) {
+	for _, size := range []Size{{Width: 3, Height: 4}, {Width: 4, Height: 5}, {Width: 0, Height: 0}} {
+		_, err := NewBlockGame(generateCoordinateUnitsForTest(size.Width, size.Height))
+		if _, ok := err.(*ErrBlockUnitsIsInvalid); !ok {
+			t.Fatalf("Should get ErrBlockUnitsIsInvalid with size %v, but got %v.", size, err)
+		}
+	}
+	if _, err := NewBlockGame(generateCoordinateUnitsForTest(4, 2)); err != nil {
+		t.Fatalf("Should not get error with size 4x2, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewBlockGame(t *testing.T) {
+	testNewBlockGameCaseOne(t)
+}
+
+func testBlockGameGenerateNextUnitsCaseOne(t *testing.T) {
+	g, _ := NewBlockGame(generateCoordinateUnitsForTest(4, 4))
+	g.SetNextBlockGenerator(rotatingBlockForTestGenerator)
+
+	units := g.GenerateNextUnits()
+	// Blocks start at (0, 0) in the first generation.
+	if (*units)[0][0] != 11 || (*units)[1][1] != 0 || (*units)[2][3] != 32 {
+		t.Fatalf("Blocks should start at (0, 0) in the first generation, but got %v.", *units)
+	}
+
+	units = g.GenerateNextUnits()
+	// Blocks start at (1, 1) in the second generation, and the block at (3, 3) wraps around.
+	if (*units)[2][2] != 0 || (*units)[1][1] != 33 || (*units)[0][0] != 22 || (*units)[3][3] != 11 {
+		t.Fatalf("Blocks should start at (1, 1) in the second generation, but got %v.", *units)
+	}
+	if g.GetGenerationsCount() != 2 {
+		t.Fatalf("Generations count should be 2, but got %v.", g.GetGenerationsCount())
+	}
+	t.Log("Passed")
+}
+
+func testBlockGameGenerateNextUnitsCaseTwo(t *testing.T) {
+	serialGame, _ := NewBlockGame(generateCoordinateUnitsForTest(10, 8))
+	serialGame.SetNextBlockGenerator(clockwiseBlockForTestGenerator)
+	parallelGame, _ := NewBlockGame(generateCoordinateUnitsForTest(10, 8))
+	parallelGame.SetNextBlockGenerator(clockwiseBlockForTestGenerator)
+	parallelGame.SetConcurrency(3)
+
+	for i := 0; i < 5; i++ {
+		serialUnits := serialGame.GenerateNextUnits()
+		parallelUnits := parallelGame.GenerateNextUnits()
+		if !areIntUnitsEqualForTest(serialUnits, parallelUnits) {
+			t.Fatalf("Generation %v in parallel should be %v, but got %v.", i+1, *serialUnits, *parallelUnits)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testBlockGameGenerateNextUnitsWithoutAllocation(t *testing.T) {
+	g, _ := NewBlockGame(generateCoordinateUnitsForTest(64, 64))
+	g.SetNextBlockGenerator(rotatingBlockForTestGenerator)
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestBlockGameGenerateNextUnits(t *testing.T) {
+	testBlockGameGenerateNextUnitsCaseOne(t)
+	testBlockGameGenerateNextUnitsCaseTwo(t)
+	testBlockGameGenerateNextUnitsWithoutAllocation(t)
+}
+
+func testBlockGameGeneratePreviousUnitsCaseOne(t *testing.T) {
+	g, _ := NewBlockGame(generateCoordinateUnitsForTest(6, 4))
+	g.SetNextBlockGenerator(clockwiseBlockForTestGenerator)
+	g.SetPreviousBlockGenerator(counterclockwiseBlockForTestGenerator)
+	g.SetConcurrency(2)
+
+	for i := 0; i < 5; i++ {
+		g.GenerateNextUnits()
+	}
+	if areIntUnitsEqualForTest(g.GetUnits(), generateCoordinateUnitsForTest(6, 4)) {
+		t.Fatalf("Units should be changed after 5 generations.")
+	}
+	for i := 0; i < 5; i++ {
+		g.GeneratePreviousUnits()
+	}
+	if !areIntUnitsEqualForTest(g.GetUnits(), generateCoordinateUnitsForTest(6, 4)) {
+		t.Fatalf("Units should be the initial units after going back 5 generations, but got %v.", *g.GetUnits())
+	}
+
+	// Going back from the initial units works too, and going forward undoes it.
+	g.GeneratePreviousUnits()
+	if g.GetGenerationsCount() != -1 {
+		t.Fatalf("Generations count should be -1, but got %v.", g.GetGenerationsCount())
+	}
+	g.GenerateNextUnits()
+	if !areIntUnitsEqualForTest(g.GetUnits(), generateCoordinateUnitsForTest(6, 4)) {
+		t.Fatalf("Units should be the initial units after going back and forth, but got %v.", *g.GetUnits())
+	}
+	t.Log("Passed")
+}
+
+func TestBlockGameGeneratePreviousUnits(t *testing.T) {
+	testBlockGameGeneratePreviousUnitsCaseOne(t)
+}
+
+func testBlockGameSetUnitCaseOne(t *testing.T) {
+	g, _ := NewBlockGame(generateCoordinateUnitsForTest(2, 2))
+	if err := g.SetUnit(&Coordinate{X: 2, Y: 0}, &[]int{1}[0]); err == nil {
+		t.Fatalf("Should get error when coordinate is outside the game map.")
+	}
+	g.SetUnit(&Coordinate{X: 1, Y: 0}, &[]int{99}[0])
+	if unit, _ := g.GetUnit(&Coordinate{X: 1, Y: 0}); *unit != 99 {
+		t.Fatalf("Unit at (1, 0) should be 99, but got %v.", *unit)
+	}
+	t.Log("Passed")
+}
+
+func TestBlockGameSetUnit(t *testing.T) {
+	testBlockGameSetUnitCaseOne(t)
+}
@@ -0,0 +1,69 @@
+package ggol
+
+// BoundaryPolicy tells the game which unit you get when the adjacent unit is outside the border.
+type BoundaryPolicy int
+
+const (
+	// The map is a torus, units on one side are adjacent to units on the other side, this is the default policy.
+	BoundaryPolicyWrap BoundaryPolicy = iota
+	// All units outside the border are the boundary unit you set with SetBoundaryUnit.
+	BoundaryPolicyConstant
+	// Units outside the border are the same as the nearest unit on the edge.
+	BoundaryPolicyClamp
+	// Units outside the border are mirrored from the inside, the edge is the mirror.
+	BoundaryPolicyMirror
+	// Like wrap, but when you cross the top or bottom border, X is flipped.
+	BoundaryPolicyKleinBottle
+	// Like wrap, but when you cross the top or bottom border, X is flipped, and when you cross the left or right border, Y is flipped.
+	BoundaryPolicyProjectivePlane
+)
+
+func (p BoundaryPolicy) isValid() bool {
+	return p >= BoundaryPolicyWrap && p <= BoundaryPolicyProjectivePlane
+}
+
+// Mirror "a" into [0, size), the pattern repeats every 2 * size.
+func mirrorIndex(a int, size int) int {
+	m := floorModulo(a, 2*size)
+	if m >= size {
+		m = 2*size - 1 - m
+	}
+	return m
+}
+
+func clampIndex(a int, size int) int {
+	if a < 0 {
+		return 0
+	}
+	if a >= size {
+		return size - 1
+	}
+	return a
+}
+
+// Resolve the coordinate outside the border into a coordinate inside the border with the given policy.
+// "isResolved" will be false when the policy is BoundaryPolicyConstant, which means you should use the boundary unit.
+func resolveCoordinateWithBoundaryPolicy(policy BoundaryPolicy, size *Size, x int, y int) (resolvedX int, resolvedY int, isResolved bool) {
+	switch policy {
+	case BoundaryPolicyConstant:
+		return x, y, false
+	case BoundaryPolicyClamp:
+		return clampIndex(x, size.Width), clampIndex(y, size.Height), true
+	case BoundaryPolicyMirror:
+		return mirrorIndex(x, size.Width), mirrorIndex(y, size.Height), true
+	case BoundaryPolicyKleinBottle, BoundaryPolicyProjectivePlane:
+		wrapsCountOfX := floorDivide(x, size.Width)
+		wrapsCountOfY := floorDivide(y, size.Height)
+		resolvedX = floorModulo(x, size.Width)
+		resolvedY = floorModulo(y, size.Height)
+		if wrapsCountOfY%2 != 0 {
+			resolvedX = size.Width - 1 - resolvedX
+		}
+		if policy == BoundaryPolicyProjectivePlane && wrapsCountOfX%2 != 0 {
+			resolvedY = size.Height - 1 - resolvedY
+		}
+		return resolvedX, resolvedY, true
+	default:
+		return floorModulo(x, size.Width), floorModulo(y, size.Height), true
+	}
+}
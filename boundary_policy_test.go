@@ -0,0 +1,168 @@
+package ggol
+
+import (
+	"testing"
+)
+
+// Generate a map whose unit tells you its coordinate, unit at (x, y) is x * 10 + y.
+func generateCoordinateUnitsForTest(width int, height int) *[][]int {
+	units := make([][]int, width)
+	for x := 0; x < width; x++ {
+		units[x] = make([]int, height)
+		for y := 0; y < height; y++ {
+			units[x][y] = x*10 + y
+		}
+	}
+	return &units
+}
+
+type boundaryPolicyCaseForTest struct {
+	origin              Coordinate
+	relative            Coordinate
+	expectedUnit        int
+	expectedCrossBorder bool
+}
+
+func testBoundaryPolicyCases(t *testing.T, policy BoundaryPolicy, cases []boundaryPolicyCaseForTest) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(4, 3))
+	g.SetBoundaryUnit(&[]int{-1}[0])
+	if err := g.SetBoundaryPolicy(policy); err != nil {
+		t.Fatalf("Should set boundary policy %v, but got %v.", policy, err)
+	}
+	getAdjacentUnit := g.(*gameInfo[int]).getAdjacentUnit
+
+	for _, c := range cases {
+		unit, isCrossBorder := getAdjacentUnit(&c.origin, &c.relative)
+		if *unit != c.expectedUnit || isCrossBorder != c.expectedCrossBorder {
+			t.Fatalf(
+				"Policy %v: adjacent unit of %v at %v should be %v (cross border: %v), but got %v (cross border: %v).",
+				policy, c.origin, c.relative, c.expectedUnit, c.expectedCrossBorder, *unit, isCrossBorder,
+			)
+		}
+	}
+}
+
+func testBoundaryPolicyWrap(t *testing.T) {
+	testBoundaryPolicyCases(t, BoundaryPolicyWrap, []boundaryPolicyCaseForTest{
+		{Coordinate{X: 1, Y: 1}, Coordinate{X: 1, Y: 1}, 22, false},
+		{Coordinate{X: 0, Y: 0}, Coordinate{X: -1, Y: -1}, 32, true},
+		{Coordinate{X: 3, Y: 2}, Coordinate{X: 1, Y: 1}, 0, true},
+		{Coordinate{X: 0, Y: 0}, Coordinate{X: -9, Y: 7}, 31, true},
+	})
+	t.Log("Passed")
+}
+
+func testBoundaryPolicyConstant(t *testing.T) {
+	testBoundaryPolicyCases(t, BoundaryPolicyConstant, []boundaryPolicyCaseForTest{
+		{Coordinate{X: 1, Y: 1}, Coordinate{X: 1, Y: 1}, 22, false},
+		{Coordinate{X: 0, Y: 0}, Coordinate{X: -1, Y: 0}, -1, true},
+		{Coordinate{X: 3, Y: 2}, Coordinate{X: 0, Y: 1}, -1, true},
+	})
+	t.Log("Passed")
+}
+
+func testBoundaryPolicyClamp(t *testing.T) {
+	testBoundaryPolicyCases(t, BoundaryPolicyClamp, []boundaryPolicyCaseForTest{
+		{Coordinate{X: 0, Y: 1}, Coordinate{X: -1, Y: 0}, 1, true},
+		{Coordinate{X: 3, Y: 2}, Coordinate{X: 5, Y: 5}, 32, true},
+		{Coordinate{X: 2, Y: 0}, Coordinate{X: 0, Y: -1}, 20, true},
+	})
+	t.Log("Passed")
+}
+
+func testBoundaryPolicyMirror(t *testing.T) {
+	testBoundaryPolicyCases(t, BoundaryPolicyMirror, []boundaryPolicyCaseForTest{
+		{Coordinate{X: 0, Y: 1}, Coordinate{X: -1, Y: 0}, 1, true},
+		{Coordinate{X: 0, Y: 1}, Coordinate{X: -2, Y: 0}, 11, true},
+		{Coordinate{X: 3, Y: 2}, Coordinate{X: 1, Y: 1}, 32, true},
+		{Coordinate{X: 3, Y: 2}, Coordinate{X: 2, Y: 2}, 21, true},
+	})
+	t.Log("Passed")
+}
+
+func testBoundaryPolicyKleinBottle(t *testing.T) {
+	testBoundaryPolicyCases(t, BoundaryPolicyKleinBottle, []boundaryPolicyCaseForTest{
+		// Crossing left or right border works like wrap.
+		{Coordinate{X: 0, Y: 1}, Coordinate{X: -1, Y: 0}, 31, true},
+		// Crossing top or bottom border flips X.
+		{Coordinate{X: 0, Y: 0}, Coordinate{X: 0, Y: -1}, 32, true},
+		{Coordinate{X: 1, Y: 2}, Coordinate{X: 0, Y: 1}, 20, true},
+	})
+	t.Log("Passed")
+}
+
+func testBoundaryPolicyProjectivePlane(t *testing.T) {
+	testBoundaryPolicyCases(t, BoundaryPolicyProjectivePlane, []boundaryPolicyCaseForTest{
+		// Crossing left or right border flips Y.
+		{Coordinate{X: 0, Y: 0}, Coordinate{X: -1, Y: 0}, 32, true},
+		// Crossing top or bottom border flips X.
+		{Coordinate{X: 1, Y: 2}, Coordinate{X: 0, Y: 1}, 20, true},
+		// Crossing both flips both.
+		{Coordinate{X: 0, Y: 0}, Coordinate{X: -1, Y: -1}, 0, true},
+	})
+	t.Log("Passed")
+}
+
+func testBoundaryPolicyWithBlinkerOnEdge(t *testing.T) {
+	uniMatrix := generateInitialUnitMatrixForTest(3, 3, initialUnitForTest)
+	g, _ := NewGame(uniMatrix)
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	g.SetBoundaryUnit(&unitForTest{hasLiveCell: false})
+	// The generator doesn't need to check "isCrossBorder" anymore, units outside the border are always dead.
+	g.SetNextUnitGenerator(func(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+		var aliveAdjacentCellsCount int = 0
+		for i := 0; i < len(adjacentCoordsForTest); i += 1 {
+			adjUnit, _ := getAdjacentUnit(coord, &adjacentCoordsForTest[i])
+			if adjUnit.hasLiveCell {
+				aliveAdjacentCellsCount += 1
+			}
+		}
+		if aliveAdjacentCellsCount == 3 || (unit.hasLiveCell && aliveAdjacentCellsCount == 2) {
+			return &liveUnitForTest
+		}
+		return &deadUnitForTest
+	})
+
+	// Make a blinker pattern on the edge
+	g.SetUnit(&Coordinate{X: 0, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 0, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 0, Y: 2}, &unitForTest{hasLiveCell: true})
+
+	unitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(g.GenerateNextUnits())
+	expectedUnitLiveMap := unitsHavingLiveCellForTest{
+		{false, true, false},
+		{false, true, false},
+		{false, false, false},
+	}
+	if !areTwoUnitsHavingLiveCellForTestEqual(unitLiveMap, expectedUnitLiveMap) {
+		t.Fatalf("Should generate next units of a blinker cut by the border, but got %v.", unitLiveMap)
+	}
+	t.Log("Passed")
+}
+
+func TestSetBoundaryPolicy(t *testing.T) {
+	testBoundaryPolicyWrap(t)
+	testBoundaryPolicyConstant(t)
+	testBoundaryPolicyClamp(t)
+	testBoundaryPolicyMirror(t)
+	testBoundaryPolicyKleinBottle(t)
+	testBoundaryPolicyProjectivePlane(t)
+	testBoundaryPolicyWithBlinkerOnEdge(t)
+}
+
+func testSetBoundaryPolicyWithInvalidPolicy(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(2, 2))
+	if err := g.SetBoundaryPolicy(BoundaryPolicy(100)); err == nil {
+		t.Fatalf("Should get error when boundary policy is invalid.")
+	}
+
+	infiniteGame := NewInfiniteGame(initialUnitForTest)
+	if err := infiniteGame.SetBoundaryPolicy(BoundaryPolicyClamp); err == nil {
+		t.Fatalf("Should get error when setting boundary policy to a game without border.")
+	}
+	t.Log("Passed")
+}
+
+func TestSetBoundaryPolicyWithInvalidPolicy(t *testing.T) {
+	testSetBoundaryPolicyWithInvalidPolicy(t)
+}
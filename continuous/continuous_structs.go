@@ -0,0 +1,65 @@
+package continuous
+
+import "fmt"
+
+// This error will be thrown when the kernel has no positive weights, or the weights are not a square of odd size.
+type ErrKernelIsInvalid struct {
+}
+
+// Tell you that the kernel is invalid.
+func (e *ErrKernelIsInvalid) Error() string {
+	return fmt.Sprintf("Kernel is not valid, it should be a square of odd size with positive weights.")
+}
+
+// This error will be thrown when delta time is not in (0, 1].
+type ErrDeltaTimeIsInvalid struct {
+	DeltaTime float64
+}
+
+// Tell you that delta time is invalid.
+func (e *ErrDeltaTimeIsInvalid) Error() string {
+	return fmt.Sprintf("Delta time %v is not valid, it should be greater than 0 and at most 1.", e.DeltaTime)
+}
+
+// This error will be thrown when the convolution method is not one of the methods we support.
+type ErrConvolutionMethodIsInvalid struct {
+	ConvolutionMethod ConvolutionMethod
+}
+
+// Tell you that the convolution method is invalid.
+func (e *ErrConvolutionMethodIsInvalid) Error() string {
+	return fmt.Sprintf("Convolution method %v is not valid.", e.ConvolutionMethod)
+}
+
+// This error will be thrown when the weights of the Laplacian stencil don't sum up to 0,
+// or they are not a square of odd size with a negative center.
+type ErrLaplacianStencilIsInvalid struct {
+}
+
+// Tell you that the Laplacian stencil is invalid.
+func (e *ErrLaplacianStencilIsInvalid) Error() string {
+	return fmt.Sprintf("Laplacian stencil is not valid, it should be a square of odd size with a negative center and weights summing up to 0.")
+}
+
+// This error will be thrown when a rate of the reaction or the diffusion is negative or not a finite number.
+type ErrRateIsInvalid struct {
+	Name string
+	Rate float64
+}
+
+// Tell you that the rate is invalid.
+func (e *ErrRateIsInvalid) Error() string {
+	return fmt.Sprintf("%v %v is not valid, it should be a finite number that is not negative.", e.Name, e.Rate)
+}
+
+// This error will be thrown when the diffusion rate is so high for delta time that the simulation blows up.
+type ErrDiffusionIsUnstable struct {
+	DiffusionRate float64
+	DeltaTime     float64
+	MaxDeltaTime  float64
+}
+
+// Tell you that the diffusion is unstable.
+func (e *ErrDiffusionIsUnstable) Error() string {
+	return fmt.Sprintf("Diffusion rate %v with delta time %v is not stable, delta time should be at most %v.", e.DiffusionRate, e.DeltaTime, e.MaxDeltaTime)
+}
@@ -0,0 +1,196 @@
+package continuous
+
+import (
+	"math"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// ConvolutionMethod tells how potentials of units are computed from the kernel.
+type ConvolutionMethod int
+
+const (
+	// Pick the faster method by the size of the kernel and the map, this is the default method.
+	ConvolutionMethodAuto ConvolutionMethod = iota
+	// Sum up weighted units in the kernel for every unit, it's faster for small kernels.
+	ConvolutionMethodDirect
+	// Convolve the whole map with fast Fourier transform once per generation, it's faster for large kernels.
+	ConvolutionMethodFFT
+)
+
+func (m ConvolutionMethod) isValid() bool {
+	return m >= ConvolutionMethodAuto && m <= ConvolutionMethodFFT
+}
+
+func floorModulo(a int, b int) int {
+	return ((a % b) + b) % b
+}
+
+// Potentials of units convolved with the kernel, they are prepared once per generation.
+type convolution struct {
+	kernel *Kernel
+	method ConvolutionMethod
+	// The prepared area and potentials of units in it, potentials[x * height + y] is the potential of (area.From.X + x, area.From.Y + y).
+	area       ggol.Area
+	width      int
+	height     int
+	potentials []float64
+	isPrepared bool
+	// Values of units in the area padded by the radius of the kernel, paddedValues[x * paddedHeight + y].
+	paddedValues []float64
+	paddedWidth  int
+	paddedHeight int
+	// Buffers of FFT, they are rebuilt only when the size of the area changes.
+	fft            *fft2D
+	kernelSpectrum []complex128
+	spectrum       []complex128
+	// Coordinates passed to AdjacentUnitGetter, they are kept here so preparing doesn't allocate.
+	origin ggol.Coordinate
+	coord  ggol.Coordinate
+}
+
+func newConvolution(kernel *Kernel, method ConvolutionMethod) *convolution {
+	return &convolution{kernel: kernel, method: method}
+}
+
+func resizeFloats(values []float64, size int) []float64 {
+	if cap(values) < size {
+		return make([]float64, size)
+	}
+	return values[:size]
+}
+
+func (c *convolution) prepare(area *ggol.Area, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) {
+	radius := c.kernel.radius
+	c.area = *area
+	c.width = area.To.X - area.From.X + 1
+	c.height = area.To.Y - area.From.Y + 1
+	c.paddedWidth = c.width + 2*radius
+	c.paddedHeight = c.height + 2*radius
+	c.potentials = resizeFloats(c.potentials, c.width*c.height)
+	c.paddedValues = resizeFloats(c.paddedValues, c.paddedWidth*c.paddedHeight)
+
+	for x := 0; x < c.paddedWidth; x++ {
+		for y := 0; y < c.paddedHeight; y++ {
+			c.coord.X = area.From.X - radius + x
+			c.coord.Y = area.From.Y - radius + y
+			value, _ := getAdjacentUnit(&c.origin, &c.coord)
+			c.paddedValues[x*c.paddedHeight+y] = *value
+		}
+	}
+
+	if c.isFFTUsed() {
+		c.convolveWithFFT()
+	} else {
+		c.convolveDirectly()
+	}
+	c.isPrepared = true
+}
+
+// Direct convolution costs every weight for every unit, FFT costs 2 transforms of the padded area,
+// every transform costs about "2 * n * log2(n)" weighted sums as measured by benchmarks.
+func (c *convolution) isFFTUsed() bool {
+	switch c.method {
+	case ConvolutionMethodDirect:
+		return false
+	case ConvolutionMethodFFT:
+		return true
+	}
+	fftSize := float64(getFFTSize(c.paddedWidth) * getFFTSize(c.paddedHeight))
+	directCost := float64(len(c.kernel.weights) * c.width * c.height)
+	fftCost := 2 * 2 * fftSize * math.Log2(fftSize)
+	return fftCost < directCost
+}
+
+func (c *convolution) convolveDirectly() {
+	radius := c.kernel.radius
+	for x := 0; x < c.width; x++ {
+		for y := 0; y < c.height; y++ {
+			potential := 0.0
+			for i, relativeCoord := range c.kernel.relativeCoords {
+				potential += c.kernel.weights[i] * c.paddedValues[(x+radius+relativeCoord.X)*c.paddedHeight+y+radius+relativeCoord.Y]
+			}
+			c.potentials[x*c.height+y] = potential
+		}
+	}
+}
+
+func (c *convolution) convolveWithFFT() {
+	fftWidth := getFFTSize(c.paddedWidth)
+	fftHeight := getFFTSize(c.paddedHeight)
+	if c.fft == nil || c.fft.width != fftWidth || c.fft.height != fftHeight {
+		c.fft = newFFT2D(fftWidth, fftHeight)
+		c.spectrum = make([]complex128, fftWidth*fftHeight)
+		// The kernel is flipped, so the convolution sums up "weight * value" at the same relative coordinate.
+		c.kernelSpectrum = make([]complex128, fftWidth*fftHeight)
+		for i, relativeCoord := range c.kernel.relativeCoords {
+			c.kernelSpectrum[floorModulo(-relativeCoord.X, fftWidth)*fftHeight+floorModulo(-relativeCoord.Y, fftHeight)] = complex(c.kernel.weights[i], 0)
+		}
+		c.fft.transform(c.kernelSpectrum, false)
+	}
+
+	// Values outside the padded area are 0, and the padded area is large enough that the kernel never wraps around.
+	for i := range c.spectrum {
+		c.spectrum[i] = 0
+	}
+	for x := 0; x < c.paddedWidth; x++ {
+		for y := 0; y < c.paddedHeight; y++ {
+			c.spectrum[x*fftHeight+y] = complex(c.paddedValues[x*c.paddedHeight+y], 0)
+		}
+	}
+	c.fft.transform(c.spectrum, false)
+	for i := range c.spectrum {
+		c.spectrum[i] *= c.kernelSpectrum[i]
+	}
+	c.fft.transform(c.spectrum, true)
+
+	radius := c.kernel.radius
+	for x := 0; x < c.width; x++ {
+		for y := 0; y < c.height; y++ {
+			c.potentials[x*c.height+y] = real(c.spectrum[(x+radius)*fftHeight+y+radius])
+		}
+	}
+}
+
+// Get the potential of the unit, units outside the prepared area are convolved directly.
+func (c *convolution) getPotential(coord *ggol.Coordinate, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) float64 {
+	if c.isPrepared && coord.X >= c.area.From.X && coord.X <= c.area.To.X && coord.Y >= c.area.From.Y && coord.Y <= c.area.To.Y {
+		return c.potentials[(coord.X-c.area.From.X)*c.height+coord.Y-c.area.From.Y]
+	}
+	potential := 0.0
+	for i := range c.kernel.relativeCoords {
+		value, _ := getAdjacentUnit(coord, &c.kernel.relativeCoords[i])
+		potential += c.kernel.weights[i] * *value
+	}
+	return potential
+}
+
+// Next units in the prepared area, every unit has its own slot so generators can return pointers
+// to them without allocating, even when units are generated concurrently.
+type nextUnitsBuffer[T any] struct {
+	area   ggol.Area
+	height int
+	units  []T
+}
+
+func (b *nextUnitsBuffer[T]) prepare(area *ggol.Area) {
+	b.area = *area
+	b.height = area.To.Y - area.From.Y + 1
+	size := (area.To.X - area.From.X + 1) * b.height
+	if cap(b.units) < size {
+		b.units = make([]T, size)
+	}
+	b.units = b.units[:size]
+}
+
+// Store the next unit and get the pointer to it, units outside the prepared area get a new pointer.
+func (b *nextUnitsBuffer[T]) store(coord *ggol.Coordinate, unit T) *T {
+	if b.units == nil || coord.X < b.area.From.X || coord.X > b.area.To.X || coord.Y < b.area.From.Y || coord.Y > b.area.To.Y {
+		nextUnit := new(T)
+		*nextUnit = unit
+		return nextUnit
+	}
+	index := (coord.X-b.area.From.X)*b.height + coord.Y - b.area.From.Y
+	b.units[index] = unit
+	return &b.units[index]
+}
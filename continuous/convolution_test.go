@@ -0,0 +1,112 @@
+package continuous
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateRandomValuesForTest(width int, height int, seed int64) *[][]float64 {
+	random := rand.New(rand.NewSource(seed))
+	values := make([][]float64, width)
+	for x := range values {
+		values[x] = make([]float64, height)
+		for y := range values[x] {
+			values[x][y] = random.Float64()
+		}
+	}
+	return &values
+}
+
+func testConvolutionGetPotentialCaseOne(t *testing.T) {
+	// Potentials convolved directly, with FFT and one by one should be the same.
+	kernel, _ := NewRingKernel(6, []float64{1, 0.3})
+	area := ggol.Area{From: ggol.Coordinate{X: 0, Y: 0}, To: ggol.Coordinate{X: 20, Y: 13}}
+	for _, boundaryPolicy := range []ggol.BoundaryPolicy{ggol.BoundaryPolicyWrap, ggol.BoundaryPolicyConstant, ggol.BoundaryPolicyMirror} {
+		g, _ := ggol.NewGame(generateRandomValuesForTest(21, 14, 1))
+		g.SetBoundaryPolicy(boundaryPolicy)
+		var getAdjacentUnit ggol.AdjacentUnitGetter[float64]
+		g.SetGenerationPreparer(func(_ *ggol.Area, g ggol.AdjacentUnitGetter[float64]) {
+			getAdjacentUnit = g
+		})
+		g.GenerateNextUnits()
+
+		directConvolution := newConvolution(kernel, ConvolutionMethodDirect)
+		directConvolution.prepare(&area, getAdjacentUnit)
+		fftConvolution := newConvolution(kernel, ConvolutionMethodFFT)
+		fftConvolution.prepare(&area, getAdjacentUnit)
+		unpreparedConvolution := newConvolution(kernel, ConvolutionMethodDirect)
+		for x := area.From.X; x <= area.To.X; x++ {
+			for y := area.From.Y; y <= area.To.Y; y++ {
+				coord := ggol.Coordinate{X: x, Y: y}
+				expectedPotential := unpreparedConvolution.getPotential(&coord, getAdjacentUnit)
+				if potential := directConvolution.getPotential(&coord, getAdjacentUnit); math.Abs(potential-expectedPotential) > 1e-9 {
+					t.Fatalf("Direct potential at %v should be %v, but got %v.", coord, expectedPotential, potential)
+				}
+				if potential := fftConvolution.getPotential(&coord, getAdjacentUnit); math.Abs(potential-expectedPotential) > 1e-9 {
+					t.Fatalf("FFT potential at %v should be %v, but got %v.", coord, expectedPotential, potential)
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testConvolutionGetPotentialCaseTwo(t *testing.T) {
+	// Units outside the prepared area are convolved one by one.
+	kernel, _ := NewCustomKernel([][]float64{{0, 1, 0}, {1, 0, 1}, {0, 1, 0}})
+	g, _ := ggol.NewGame(generateRandomValuesForTest(5, 5, 2))
+	var getAdjacentUnit ggol.AdjacentUnitGetter[float64]
+	g.SetGenerationPreparer(func(_ *ggol.Area, g ggol.AdjacentUnitGetter[float64]) {
+		getAdjacentUnit = g
+	})
+	g.GenerateNextUnits()
+
+	c := newConvolution(kernel, ConvolutionMethodFFT)
+	c.prepare(&ggol.Area{From: ggol.Coordinate{X: 1, Y: 1}, To: ggol.Coordinate{X: 2, Y: 2}}, getAdjacentUnit)
+	units := *g.GetUnits()
+	coord := ggol.Coordinate{X: 4, Y: 0}
+	expectedPotential := (units[3][0] + units[0][0] + units[4][4] + units[4][1]) / 4
+	if potential := c.getPotential(&coord, getAdjacentUnit); math.Abs(potential-expectedPotential) > 1e-9 {
+		t.Fatalf("Potential at %v should be %v, but got %v.", coord, expectedPotential, potential)
+	}
+	t.Log("Passed")
+}
+
+func TestConvolutionGetPotential(t *testing.T) {
+	testConvolutionGetPotentialCaseOne(t)
+	testConvolutionGetPotentialCaseTwo(t)
+}
+
+func testConvolutionIsFFTUsedCaseOne(t *testing.T) {
+	smallKernel, _ := NewDiskKernel(1)
+	largeKernel, _ := NewGaussianKernel(13, 0.5, 0.15)
+	area := ggol.Area{From: ggol.Coordinate{X: 0, Y: 0}, To: ggol.Coordinate{X: 127, Y: 127}}
+	testCases := []struct {
+		kernel         *Kernel
+		method         ConvolutionMethod
+		expectedUseFFT bool
+	}{
+		{smallKernel, ConvolutionMethodAuto, false},
+		{largeKernel, ConvolutionMethodAuto, true},
+		{largeKernel, ConvolutionMethodDirect, false},
+		{smallKernel, ConvolutionMethodFFT, true},
+	}
+	for _, testCase := range testCases {
+		c := newConvolution(testCase.kernel, testCase.method)
+		c.prepare(&area, func(_ *ggol.Coordinate, _ *ggol.Coordinate) (*float64, bool) {
+			value := 0.0
+			return &value, true
+		})
+		if c.isFFTUsed() != testCase.expectedUseFFT {
+			t.Fatalf("Kernel of radius %v with method %v should use FFT: %v.", testCase.kernel.GetRadius(), testCase.method, testCase.expectedUseFFT)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestConvolutionIsFFTUsed(t *testing.T) {
+	testConvolutionIsFFTUsedCaseOne(t)
+}
@@ -0,0 +1,104 @@
+package continuous
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Radix-2 fast Fourier transform of a fixed size, the size must be a power of 2.
+type fft struct {
+	size int
+	// twiddles[k] is e^(-2πik/size), for k from 0 to "size / 2 - 1".
+	twiddles []complex128
+	// reversedIndexes[i] is i with its bits reversed.
+	reversedIndexes []int
+}
+
+// Get the smallest power of 2 that is at least n.
+func getFFTSize(n int) int {
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+func newFFT(size int) *fft {
+	f := fft{
+		size:            size,
+		twiddles:        make([]complex128, size/2),
+		reversedIndexes: make([]int, size),
+	}
+	for k := range f.twiddles {
+		f.twiddles[k] = cmplx.Exp(complex(0, -2*math.Pi*float64(k)/float64(size)))
+	}
+	bitsCount := 0
+	for 1<<bitsCount < size {
+		bitsCount++
+	}
+	for i := range f.reversedIndexes {
+		reversedIndex := 0
+		for bit := 0; bit < bitsCount; bit++ {
+			if i&(1<<bit) != 0 {
+				reversedIndex |= 1 << (bitsCount - 1 - bit)
+			}
+		}
+		f.reversedIndexes[i] = reversedIndex
+	}
+	return &f
+}
+
+// Transform values[offset + i * stride] for i from 0 to "size - 1" in place.
+// The inverse transform is not divided by size, so do it yourself.
+func (f *fft) transform(values []complex128, offset int, stride int, isInverse bool) {
+	for i, j := range f.reversedIndexes {
+		if i < j {
+			values[offset+i*stride], values[offset+j*stride] = values[offset+j*stride], values[offset+i*stride]
+		}
+	}
+	for length := 2; length <= f.size; length <<= 1 {
+		halfLength := length / 2
+		twiddleStep := f.size / length
+		for start := 0; start < f.size; start += length {
+			for k := 0; k < halfLength; k++ {
+				twiddle := f.twiddles[k*twiddleStep]
+				if isInverse {
+					twiddle = complex(real(twiddle), -imag(twiddle))
+				}
+				evenIndex := offset + (start+k)*stride
+				oddIndex := evenIndex + halfLength*stride
+				odd := values[oddIndex] * twiddle
+				values[oddIndex] = values[evenIndex] - odd
+				values[evenIndex] += odd
+			}
+		}
+	}
+}
+
+// Two-dimensional FFT of values[x * height + y], width and height must be powers of 2.
+type fft2D struct {
+	width  int
+	height int
+	xFFT   *fft
+	yFFT   *fft
+}
+
+func newFFT2D(width int, height int) *fft2D {
+	return &fft2D{width: width, height: height, xFFT: newFFT(width), yFFT: newFFT(height)}
+}
+
+// Transform values in place, the inverse transform is divided by "width * height".
+func (f *fft2D) transform(values []complex128, isInverse bool) {
+	for x := 0; x < f.width; x++ {
+		f.yFFT.transform(values, x*f.height, 1, isInverse)
+	}
+	for y := 0; y < f.height; y++ {
+		f.xFFT.transform(values, y, f.height, isInverse)
+	}
+	if isInverse {
+		scale := complex(1/float64(f.width*f.height), 0)
+		for i := range values {
+			values[i] *= scale
+		}
+	}
+}
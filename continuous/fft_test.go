@@ -0,0 +1,113 @@
+package continuous
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+func generateRandomComplexesForTest(size int, seed int64) []complex128 {
+	random := rand.New(rand.NewSource(seed))
+	values := make([]complex128, size)
+	for i := range values {
+		values[i] = complex(random.Float64()*2-1, random.Float64()*2-1)
+	}
+	return values
+}
+
+func areComplexesClose(a []complex128, b []complex128) bool {
+	for i := range a {
+		if cmplx.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func testGetFFTSizeCaseOne(t *testing.T) {
+	testCases := map[int]int{1: 1, 2: 2, 3: 4, 4: 4, 5: 8, 100: 128, 128: 128, 129: 256}
+	for n, expectedSize := range testCases {
+		if size := getFFTSize(n); size != expectedSize {
+			t.Fatalf("FFT size of %v should be %v, but got %v.", n, expectedSize, size)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetFFTSize(t *testing.T) {
+	testGetFFTSizeCaseOne(t)
+}
+
+func testFFTTransformCaseOne(t *testing.T) {
+	// Compare with the discrete Fourier transform by definition.
+	for _, size := range []int{1, 2, 4, 8, 32} {
+		values := generateRandomComplexesForTest(size, int64(size))
+		expectedValues := make([]complex128, size)
+		for k := range expectedValues {
+			for n, value := range values {
+				expectedValues[k] += value * cmplx.Exp(complex(0, -2*math.Pi*float64(k*n)/float64(size)))
+			}
+		}
+		newFFT(size).transform(values, 0, 1, false)
+		if !areComplexesClose(values, expectedValues) {
+			t.Fatalf("Transform of size %v is not the same as the discrete Fourier transform.", size)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testFFTTransformCaseTwo(t *testing.T) {
+	// Transform values at offset 1 with stride 3, and others should be untouched.
+	values := generateRandomComplexesForTest(3*16, 1)
+	originalValues := append([]complex128{}, values...)
+	f := newFFT(16)
+	f.transform(values, 1, 3, false)
+	f.transform(values, 1, 3, true)
+	for i := range values {
+		if i%3 == 1 {
+			values[i] /= 16
+		}
+	}
+	if !areComplexesClose(values, originalValues) {
+		t.Fatalf("Inverse transform should get original values back.")
+	}
+	t.Log("Passed")
+}
+
+func TestFFTTransform(t *testing.T) {
+	testFFTTransformCaseOne(t)
+	testFFTTransformCaseTwo(t)
+}
+
+func testFFT2DTransformCaseOne(t *testing.T) {
+	width, height := 8, 4
+	values := generateRandomComplexesForTest(width*height, 2)
+	originalValues := append([]complex128{}, values...)
+	expectedValues := make([]complex128, width*height)
+	for u := 0; u < width; u++ {
+		for v := 0; v < height; v++ {
+			for x := 0; x < width; x++ {
+				for y := 0; y < height; y++ {
+					angle := -2 * math.Pi * (float64(u*x)/float64(width) + float64(v*y)/float64(height))
+					expectedValues[u*height+v] += originalValues[x*height+y] * cmplx.Exp(complex(0, angle))
+				}
+			}
+		}
+	}
+
+	f := newFFT2D(width, height)
+	f.transform(values, false)
+	if !areComplexesClose(values, expectedValues) {
+		t.Fatalf("2D transform is not the same as the discrete Fourier transform.")
+	}
+	f.transform(values, true)
+	if !areComplexesClose(values, originalValues) {
+		t.Fatalf("2D inverse transform should get original values back.")
+	}
+	t.Log("Passed")
+}
+
+func TestFFT2DTransform(t *testing.T) {
+	testFFT2DTransformCaseOne(t)
+}
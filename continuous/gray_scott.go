@@ -0,0 +1,200 @@
+package continuous
+
+import (
+	"math"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// ReactionDiffusionUnit has concentrations of 2 chemicals, U is fed into the map and V consumes U to reproduce itself.
+type ReactionDiffusionUnit struct {
+	U float64
+	V float64
+}
+
+// LaplacianStencil tells how the Laplacian of a unit is approximated with adjacent units, the weights sum up to 0.
+type LaplacianStencil struct {
+	relativeCoords []ggol.Coordinate
+	weights        []float64
+	// The sum of absolute weights, it bounds how fast the diffusion can be.
+	absoluteWeightsSum float64
+}
+
+// Build a Laplacian stencil with your own weights, weights[x][y] is the weight of the unit at (x - radius, y - radius),
+// so its width and height must be the same odd number, and the center weight must be negative.
+func NewCustomLaplacianStencil(weights [][]float64) (*LaplacianStencil, error) {
+	size := len(weights)
+	if size%2 == 0 {
+		return nil, &ErrLaplacianStencilIsInvalid{}
+	}
+	radius := size / 2
+	if len(weights[radius]) != size || weights[radius][radius] >= 0 {
+		return nil, &ErrLaplacianStencilIsInvalid{}
+	}
+	stencil := LaplacianStencil{}
+	weightsSum := 0.0
+	for x := range weights {
+		if len(weights[x]) != size {
+			return nil, &ErrLaplacianStencilIsInvalid{}
+		}
+		for y, weight := range weights[x] {
+			if weight != 0 {
+				stencil.relativeCoords = append(stencil.relativeCoords, ggol.Coordinate{X: x - radius, Y: y - radius})
+				stencil.weights = append(stencil.weights, weight)
+				weightsSum += weight
+				stencil.absoluteWeightsSum += math.Abs(weight)
+			}
+		}
+	}
+	if math.Abs(weightsSum) > 1e-9 {
+		return nil, &ErrLaplacianStencilIsInvalid{}
+	}
+	return &stencil, nil
+}
+
+// Build the five-point Laplacian stencil, it only looks at 4 orthogonal neighbors.
+func NewFivePointLaplacianStencil() *LaplacianStencil {
+	stencil, _ := NewCustomLaplacianStencil([][]float64{
+		{0, 1, 0},
+		{1, -4, 1},
+		{0, 1, 0},
+	})
+	return stencil
+}
+
+// Build the nine-point Laplacian stencil, diagonal neighbors count a quarter of orthogonal ones,
+// so patterns look more isotropic.
+func NewNinePointLaplacianStencil() *LaplacianStencil {
+	stencil, _ := NewCustomLaplacianStencil([][]float64{
+		{0.05, 0.2, 0.05},
+		{0.2, -1, 0.2},
+		{0.05, 0.2, 0.05},
+	})
+	return stencil
+}
+
+// Get the max delta time that keeps the diffusion of the rate stable with the stencil.
+func (s *LaplacianStencil) GetMaxDeltaTime(diffusionRate float64) float64 {
+	if diffusionRate == 0 {
+		return math.Inf(1)
+	}
+	return 2 / (diffusionRate * s.absoluteWeightsSum)
+}
+
+// Get the Laplacian of U and V at the coordinate.
+func (s *LaplacianStencil) getLaplacian(
+	coord *ggol.Coordinate,
+	getAdjacentUnit ggol.AdjacentUnitGetter[ReactionDiffusionUnit],
+) (laplacianU float64, laplacianV float64) {
+	for i := range s.relativeCoords {
+		adjacentUnit, _ := getAdjacentUnit(coord, &s.relativeCoords[i])
+		laplacianU += s.weights[i] * adjacentUnit.U
+		laplacianV += s.weights[i] * adjacentUnit.V
+	}
+	return laplacianU, laplacianV
+}
+
+// GrayScott is the Gray-Scott model of reaction-diffusion, U is fed at the feed rate, V is killed at the kill rate,
+// and "U + 2V -> 3V" happens while both of them diffuse at their own rates.
+type GrayScott struct {
+	feedRate       float64
+	killRate       float64
+	diffusionRateU float64
+	diffusionRateV float64
+	stencil        *LaplacianStencil
+	deltaTime      float64
+}
+
+// Build a Gray-Scott model, rates must not be negative, and delta time must be in (0, 1] and small enough
+// to keep the diffusion stable with the stencil.
+// E.g. feed rate 0.0545, kill rate 0.062, diffusion rates 1 and 0.5 with the nine-point stencil and delta time 1 grow corals.
+func NewGrayScott(
+	feedRate float64,
+	killRate float64,
+	diffusionRateU float64,
+	diffusionRateV float64,
+	stencil *LaplacianStencil,
+	deltaTime float64,
+) (*GrayScott, error) {
+	rates := []struct {
+		name string
+		rate float64
+	}{
+		{"Feed rate", feedRate}, {"Kill rate", killRate}, {"Diffusion rate of U", diffusionRateU}, {"Diffusion rate of V", diffusionRateV},
+	}
+	for _, rate := range rates {
+		if rate.rate < 0 || math.IsNaN(rate.rate) || math.IsInf(rate.rate, 0) {
+			return nil, &ErrRateIsInvalid{Name: rate.name, Rate: rate.rate}
+		}
+	}
+	if stencil == nil {
+		return nil, &ErrLaplacianStencilIsInvalid{}
+	}
+	if deltaTime <= 0 || deltaTime > 1 {
+		return nil, &ErrDeltaTimeIsInvalid{DeltaTime: deltaTime}
+	}
+	for _, diffusionRate := range []float64{diffusionRateU, diffusionRateV} {
+		if maxDeltaTime := stencil.GetMaxDeltaTime(diffusionRate); deltaTime > maxDeltaTime {
+			return nil, &ErrDiffusionIsUnstable{DiffusionRate: diffusionRate, DeltaTime: deltaTime, MaxDeltaTime: maxDeltaTime}
+		}
+	}
+	return &GrayScott{
+		feedRate:       feedRate,
+		killRate:       killRate,
+		diffusionRateU: diffusionRateU,
+		diffusionRateV: diffusionRateV,
+		stencil:        stencil,
+		deltaTime:      deltaTime,
+	}, nil
+}
+
+// Get the unit without V, it never changes when all its adjacent units are the same, so it's a good default unit of infinite games.
+func (g *GrayScott) GetSteadyUnit() ReactionDiffusionUnit {
+	return ReactionDiffusionUnit{U: 1, V: 0}
+}
+
+// Get the next unit with Laplacians of U and V, concentrations are clamped from 0 to 1.
+func (g *GrayScott) GetNextUnit(unit *ReactionDiffusionUnit, laplacianU float64, laplacianV float64) ReactionDiffusionUnit {
+	reaction := unit.U * unit.V * unit.V
+	return ReactionDiffusionUnit{
+		U: clampValue(unit.U + g.deltaTime*(g.diffusionRateU*laplacianU-reaction+g.feedRate*(1-unit.U))),
+		V: clampValue(unit.V + g.deltaTime*(g.diffusionRateV*laplacianV+reaction-(g.feedRate+g.killRate)*unit.V)),
+	}
+}
+
+// Generate a NextUnitGenerator of the Gray-Scott model without the preparer, next units get new pointers,
+// so you can set it to any number of games.
+func NewGrayScottNextUnitGenerator(grayScott *GrayScott) ggol.NextUnitGenerator[ReactionDiffusionUnit] {
+	// It's never prepared, so every next unit is allocated.
+	nextUnits := nextUnitsBuffer[ReactionDiffusionUnit]{}
+	return func(
+		coord *ggol.Coordinate,
+		unit *ReactionDiffusionUnit,
+		getAdjacentUnit ggol.AdjacentUnitGetter[ReactionDiffusionUnit],
+	) *ReactionDiffusionUnit {
+		laplacianU, laplacianV := grayScott.stencil.getLaplacian(coord, getAdjacentUnit)
+		return nextUnits.store(coord, grayScott.GetNextUnit(unit, laplacianU, laplacianV))
+	}
+}
+
+// Set a GenerationPreparer and a NextUnitGenerator of the Gray-Scott model to the game. The preparer reserves next units
+// before every generation, so generating doesn't allocate. Every game gets its own next units.
+// The game only takes the preparer with UpdateOrderSynchronous, otherwise you get the error and nothing is set.
+func SetGrayScottGenerators(game ggol.Game[ReactionDiffusionUnit], grayScott *GrayScott) error {
+	nextUnits := nextUnitsBuffer[ReactionDiffusionUnit]{}
+	err := game.SetGenerationPreparer(func(area *ggol.Area, _ ggol.AdjacentUnitGetter[ReactionDiffusionUnit]) {
+		nextUnits.prepare(area)
+	})
+	if err != nil {
+		return err
+	}
+	game.SetNextUnitGenerator(func(
+		coord *ggol.Coordinate,
+		unit *ReactionDiffusionUnit,
+		getAdjacentUnit ggol.AdjacentUnitGetter[ReactionDiffusionUnit],
+	) *ReactionDiffusionUnit {
+		laplacianU, laplacianV := grayScott.stencil.getLaplacian(coord, getAdjacentUnit)
+		return nextUnits.store(coord, grayScott.GetNextUnit(unit, laplacianU, laplacianV))
+	})
+	return nil
+}
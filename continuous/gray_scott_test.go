@@ -0,0 +1,250 @@
+package continuous
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func newCoralGrayScottForTest() *GrayScott {
+	grayScott, _ := NewGrayScott(0.0545, 0.062, 1, 0.5, NewNinePointLaplacianStencil(), 1)
+	return grayScott
+}
+
+// Seed a square of V in the middle of the map full of U.
+func generateReactionDiffusionUnitsForTest(width int, height int) *[][]ReactionDiffusionUnit {
+	units := make([][]ReactionDiffusionUnit, width)
+	for x := range units {
+		units[x] = make([]ReactionDiffusionUnit, height)
+		for y := range units[x] {
+			units[x][y] = ReactionDiffusionUnit{U: 1, V: 0}
+			if math.Abs(float64(x-width/2)) < 3 && math.Abs(float64(y-height/2)) < 3 {
+				units[x][y] = ReactionDiffusionUnit{U: 0.5, V: 0.25 + 0.05*float64((x+2*y)%5)}
+			}
+		}
+	}
+	return &units
+}
+
+func testNewCustomLaplacianStencilCaseOne(t *testing.T) {
+	invalidWeights := [][][]float64{
+		{},
+		{{1, -1}, {0, 0}},
+		{{0, 1, 0}, {1, -3, 1}, {0, 1, 0}},
+		{{0, -1, 0}, {-1, 4, -1}, {0, -1, 0}},
+		{{0, 1, 0}, {1, -4, 1}, {0, 1}},
+	}
+	for _, weights := range invalidWeights {
+		if _, err := NewCustomLaplacianStencil(weights); err == nil {
+			t.Fatalf("Should get error with weights %v.", weights)
+		} else if _, ok := err.(*ErrLaplacianStencilIsInvalid); !ok {
+			t.Fatalf("Should get ErrLaplacianStencilIsInvalid, but got %v.", err)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewCustomLaplacianStencilCaseTwo(t *testing.T) {
+	// Laplacian of "x ^ 2 + y ^ 2" is 4 everywhere, and the one of "x * y" is 0.
+	getAdjacentUnit := func(originCoord *ggol.Coordinate, relativeCoord *ggol.Coordinate) (*ReactionDiffusionUnit, bool) {
+		x := float64(originCoord.X + relativeCoord.X)
+		y := float64(originCoord.Y + relativeCoord.Y)
+		return &ReactionDiffusionUnit{U: x*x + y*y, V: x * y}, true
+	}
+	for _, stencil := range []*LaplacianStencil{NewFivePointLaplacianStencil(), NewNinePointLaplacianStencil()} {
+		laplacianU, laplacianV := stencil.getLaplacian(&ggol.Coordinate{X: 3, Y: -7}, getAdjacentUnit)
+		expectedLaplacianU := 4.0
+		if stencil.absoluteWeightsSum == 2 {
+			// The nine-point stencil is scaled down by 4 in exchange for delta time.
+			expectedLaplacianU = 1.2
+		}
+		if math.Abs(laplacianU-expectedLaplacianU) > 1e-9 || math.Abs(laplacianV) > 1e-9 {
+			t.Fatalf("Laplacians should be %v and 0, but got %v and %v.", expectedLaplacianU, laplacianU, laplacianV)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNewCustomLaplacianStencil(t *testing.T) {
+	testNewCustomLaplacianStencilCaseOne(t)
+	testNewCustomLaplacianStencilCaseTwo(t)
+}
+
+func testLaplacianStencilGetMaxDeltaTimeCaseOne(t *testing.T) {
+	if maxDeltaTime := NewFivePointLaplacianStencil().GetMaxDeltaTime(1); maxDeltaTime != 0.25 {
+		t.Fatalf("Max delta time of the five-point stencil should be 0.25, but got %v.", maxDeltaTime)
+	}
+	if maxDeltaTime := NewNinePointLaplacianStencil().GetMaxDeltaTime(0.5); maxDeltaTime != 2 {
+		t.Fatalf("Max delta time of the nine-point stencil should be 2, but got %v.", maxDeltaTime)
+	}
+	if maxDeltaTime := NewFivePointLaplacianStencil().GetMaxDeltaTime(0); !math.IsInf(maxDeltaTime, 1) {
+		t.Fatalf("Max delta time without diffusion should be infinite, but got %v.", maxDeltaTime)
+	}
+	t.Log("Passed")
+}
+
+func TestLaplacianStencilGetMaxDeltaTime(t *testing.T) {
+	testLaplacianStencilGetMaxDeltaTimeCaseOne(t)
+}
+
+func testNewGrayScottCaseOne(t *testing.T) {
+	stencil := NewFivePointLaplacianStencil()
+	if _, err := NewGrayScott(-0.01, 0.062, 0.2, 0.1, stencil, 1); err == nil {
+		t.Fatalf("Should get error when feed rate is negative.")
+	} else if errRateIsInvalid, ok := err.(*ErrRateIsInvalid); !ok || errRateIsInvalid.Name != "Feed rate" {
+		t.Fatalf("Should get ErrRateIsInvalid of feed rate, but got %v.", err)
+	}
+	if _, err := NewGrayScott(0.0545, 0.062, 0.2, math.NaN(), stencil, 1); err == nil {
+		t.Fatalf("Should get error when diffusion rate is NaN.")
+	} else if _, ok := err.(*ErrRateIsInvalid); !ok {
+		t.Fatalf("Should get ErrRateIsInvalid, but got %v.", err)
+	}
+	if _, err := NewGrayScott(0.0545, 0.062, 0.2, 0.1, nil, 1); err == nil {
+		t.Fatalf("Should get error when stencil is nil.")
+	} else if _, ok := err.(*ErrLaplacianStencilIsInvalid); !ok {
+		t.Fatalf("Should get ErrLaplacianStencilIsInvalid, but got %v.", err)
+	}
+	if _, err := NewGrayScott(0.0545, 0.062, 0.2, 0.1, stencil, 0); err == nil {
+		t.Fatalf("Should get error when delta time is 0.")
+	} else if _, ok := err.(*ErrDeltaTimeIsInvalid); !ok {
+		t.Fatalf("Should get ErrDeltaTimeIsInvalid, but got %v.", err)
+	}
+	if _, err := NewGrayScott(0.0545, 0.062, 1, 0.5, stencil, 0.5); err == nil {
+		t.Fatalf("Should get error when diffusion is unstable.")
+	} else if errDiffusionIsUnstable, ok := err.(*ErrDiffusionIsUnstable); !ok || errDiffusionIsUnstable.MaxDeltaTime != 0.25 {
+		t.Fatalf("Should get ErrDiffusionIsUnstable with max delta time 0.25, but got %v.", err)
+	}
+	if _, err := NewGrayScott(0.0545, 0.062, 1, 0.5, stencil, 0.25); err != nil {
+		t.Fatalf("Delta time 0.25 should be stable, but got error: %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewGrayScott(t *testing.T) {
+	testNewGrayScottCaseOne(t)
+}
+
+func testGrayScottGetNextUnitCaseOne(t *testing.T) {
+	grayScott := newCoralGrayScottForTest()
+	steadyUnit := grayScott.GetSteadyUnit()
+	if nextUnit := grayScott.GetNextUnit(&steadyUnit, 0, 0); nextUnit != steadyUnit {
+		t.Fatalf("Steady unit should not change, but got %v.", nextUnit)
+	}
+	// U: 0.5 + (1 * 0.1 - 0.5 * 0.25 * 0.25 + 0.0545 * 0.5), V: 0.25 + (0.5 * -0.1 + 0.5 * 0.25 * 0.25 - 0.1165 * 0.25).
+	nextUnit := grayScott.GetNextUnit(&ReactionDiffusionUnit{U: 0.5, V: 0.25}, 0.1, -0.1)
+	if math.Abs(nextUnit.U-0.596) > 1e-9 || math.Abs(nextUnit.V-0.202125) > 1e-9 {
+		t.Fatalf("Next unit should be {0.596 0.202125}, but got %v.", nextUnit)
+	}
+	t.Log("Passed")
+}
+
+func TestGrayScottGetNextUnit(t *testing.T) {
+	testGrayScottGetNextUnitCaseOne(t)
+}
+
+func testSetGrayScottGeneratorsCaseOne(t *testing.T) {
+	// Units generated with and without the preparer should be the same, and V should spread from the seed.
+	for _, boundaryPolicy := range []ggol.BoundaryPolicy{ggol.BoundaryPolicyWrap, ggol.BoundaryPolicyConstant, ggol.BoundaryPolicyMirror} {
+		preparedGame, _ := ggol.NewGame(generateReactionDiffusionUnitsForTest(40, 40))
+		SetGrayScottGenerators(preparedGame, newCoralGrayScottForTest())
+		preparedGame.SetBoundaryPolicy(boundaryPolicy)
+		unpreparedGame, _ := ggol.NewGame(generateReactionDiffusionUnitsForTest(40, 40))
+		unpreparedGame.SetNextUnitGenerator(NewGrayScottNextUnitGenerator(newCoralGrayScottForTest()))
+		unpreparedGame.SetBoundaryPolicy(boundaryPolicy)
+
+		for i := 0; i < 200; i++ {
+			preparedGame.GenerateNextUnits()
+			unpreparedGame.GenerateNextUnits()
+		}
+		preparedUnits := *preparedGame.GetUnits()
+		unpreparedUnits := *unpreparedGame.GetUnits()
+		for x := range preparedUnits {
+			for y := range preparedUnits[x] {
+				if preparedUnits[x][y] != unpreparedUnits[x][y] {
+					t.Fatalf("Units at (%v, %v) should be the same with the preparer.", x, y)
+				}
+			}
+		}
+		if unit, _ := preparedGame.GetUnit(&ggol.Coordinate{X: 20, Y: 27}); unit.V < 0.1 {
+			t.Fatalf("V should spread from the seed, but got %v.", unit)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetGrayScottGeneratorsCaseTwo(t *testing.T) {
+	// Infinite games work like other games, units far from the seed stay steady.
+	grayScott := newCoralGrayScottForTest()
+	g := ggol.NewInfiniteGame(grayScott.GetSteadyUnit())
+	SetGrayScottGenerators(g, grayScott)
+	units := *generateReactionDiffusionUnitsForTest(10, 10)
+	for x := range units {
+		for y := range units[x] {
+			g.SetUnit(&ggol.Coordinate{X: x - 5, Y: y - 5}, &units[x][y])
+		}
+	}
+	for i := 0; i < 50; i++ {
+		g.GenerateNextUnits()
+	}
+	area := ggol.Area{From: ggol.Coordinate{X: -60, Y: -60}, To: ggol.Coordinate{X: 60, Y: 60}}
+	unitsInArea, _ := g.GetUnitsInArea(&area)
+	if (*unitsInArea)[60][60].V == 0 {
+		t.Fatalf("V at the seed should not be 0.")
+	}
+	if (*unitsInArea)[0][0] != grayScott.GetSteadyUnit() {
+		t.Fatalf("Units far from the seed should stay steady, but got %v.", (*unitsInArea)[0][0])
+	}
+	t.Log("Passed")
+}
+
+func testSetGrayScottGeneratorsCaseThree(t *testing.T) {
+	g, _ := ggol.NewGame(generateReactionDiffusionUnitsForTest(32, 32))
+	SetGrayScottGenerators(g, newCoralGrayScottForTest())
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func testSetGrayScottGeneratorsCaseFour(t *testing.T) {
+	// Two games with the same model keep their own next units, units of one game never show up in the other.
+	grayScott := newCoralGrayScottForTest()
+	preparedGames := make([]ggol.Game[ReactionDiffusionUnit], 2)
+	unpreparedGames := make([]ggol.Game[ReactionDiffusionUnit], 2)
+	for i := range preparedGames {
+		preparedGames[i], _ = ggol.NewGame(generateReactionDiffusionUnitsForTest(24+i*10, 30-i*8))
+		SetGrayScottGenerators(preparedGames[i], grayScott)
+		unpreparedGames[i], _ = ggol.NewGame(generateReactionDiffusionUnitsForTest(24+i*10, 30-i*8))
+		unpreparedGames[i].SetNextUnitGenerator(NewGrayScottNextUnitGenerator(grayScott))
+	}
+	for generation := 0; generation < 20; generation++ {
+		for i := range preparedGames {
+			preparedGames[i].GenerateNextUnits()
+		}
+		for i := range preparedGames {
+			preparedUnits := *preparedGames[i].GetUnits()
+			unpreparedUnits := *unpreparedGames[i].GenerateNextUnits()
+			for x := range preparedUnits {
+				for y := range preparedUnits[x] {
+					if preparedUnits[x][y] != unpreparedUnits[x][y] {
+						t.Fatalf("Game %v generation %v: units at (%v, %v) are different with the preparer.", i, generation+1, x, y)
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestSetGrayScottGenerators(t *testing.T) {
+	testSetGrayScottGeneratorsCaseOne(t)
+	testSetGrayScottGeneratorsCaseTwo(t)
+	testSetGrayScottGeneratorsCaseThree(t)
+	testSetGrayScottGeneratorsCaseFour(t)
+}
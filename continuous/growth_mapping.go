@@ -0,0 +1,33 @@
+package continuous
+
+import "math"
+
+// GrowthMapping maps the potential of a unit to its growth from -1 to 1, Lenia adds "deltaTime * growth" to the unit.
+type GrowthMapping func(potential float64) (growth float64)
+
+// Build a Gaussian growth mapping "2 * exp(-((potential - center) / width) ^ 2 / 2) - 1", it's the one of Orbium.
+func NewGaussianGrowthMapping(center float64, width float64) GrowthMapping {
+	return func(potential float64) float64 {
+		return 2*math.Exp(-(potential-center)*(potential-center)/(2*width*width)) - 1
+	}
+}
+
+// Build a polynomial growth mapping "2 * max(0, 1 - (potential - center) ^ 2 / (9 * width ^ 2)) ^ 4 - 1",
+// the original growth mapping of Lenia.
+func NewPolynomialGrowthMapping(center float64, width float64) GrowthMapping {
+	return func(potential float64) float64 {
+		base := math.Max(0, 1-(potential-center)*(potential-center)/(9*width*width))
+		return 2*base*base*base*base - 1
+	}
+}
+
+// Build a step growth mapping, the growth is 1 when the potential is within "width" from "center", otherwise it's -1.
+// It works like life-like rules with continuous units.
+func NewStepGrowthMapping(center float64, width float64) GrowthMapping {
+	return func(potential float64) float64 {
+		if math.Abs(potential-center) <= width {
+			return 1
+		}
+		return -1
+	}
+}
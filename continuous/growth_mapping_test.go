@@ -0,0 +1,36 @@
+package continuous
+
+import (
+	"math"
+	"testing"
+)
+
+func testGrowthMappingsCaseOne(t *testing.T) {
+	testCases := []struct {
+		name           string
+		growthMapping  GrowthMapping
+		potential      float64
+		expectedGrowth float64
+	}{
+		{"Gaussian", NewGaussianGrowthMapping(0.15, 0.015), 0.15, 1},
+		{"Gaussian", NewGaussianGrowthMapping(0.15, 0.015), 0.165, 2*math.Exp(-0.5) - 1},
+		{"Gaussian", NewGaussianGrowthMapping(0.15, 0.015), 0.6, -1},
+		{"Polynomial", NewPolynomialGrowthMapping(0.15, 0.015), 0.15, 1},
+		{"Polynomial", NewPolynomialGrowthMapping(0.15, 0.015), 0.15 + 0.045, -1},
+		{"Polynomial", NewPolynomialGrowthMapping(0.15, 0.015), 0.15 - 0.045/math.Sqrt2, 2*0.0625 - 1},
+		{"Polynomial", NewPolynomialGrowthMapping(0.15, 0.015), 0, -1},
+		{"Step", NewStepGrowthMapping(0.3, 0.05), 0.3, 1},
+		{"Step", NewStepGrowthMapping(0.3, 0.05), 0.26, 1},
+		{"Step", NewStepGrowthMapping(0.3, 0.05), 0.36, -1},
+	}
+	for _, testCase := range testCases {
+		if growth := testCase.growthMapping(testCase.potential); math.Abs(growth-testCase.expectedGrowth) > 1e-9 {
+			t.Fatalf("%v growth of potential %v should be %v, but got %v.", testCase.name, testCase.potential, testCase.expectedGrowth, growth)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGrowthMappings(t *testing.T) {
+	testGrowthMappingsCaseOne(t)
+}
@@ -0,0 +1,132 @@
+package continuous
+
+import (
+	"math"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Kernel tells how much every unit within the radius contributes to the potential of a unit,
+// weights are normalized so they sum up to 1.
+type Kernel struct {
+	radius int
+	// Only units with positive weights are kept.
+	relativeCoords []ggol.Coordinate
+	weights        []float64
+}
+
+// Build a kernel with the weight of every relative coordinate within the radius,
+// "getWeight" gets the distance from the center, it can be non-integer.
+func newKernel(radius int, getWeight func(distance float64) float64) (*Kernel, error) {
+	if radius < 1 {
+		return nil, &ggol.ErrRadiusIsInvalid{Radius: radius}
+	}
+	weights := make([][]float64, 2*radius+1)
+	for x := range weights {
+		weights[x] = make([]float64, 2*radius+1)
+		for y := range weights[x] {
+			weights[x][y] = getWeight(math.Hypot(float64(x-radius), float64(y-radius)))
+		}
+	}
+	return NewCustomKernel(weights)
+}
+
+// Build a kernel with your own weights, weights[x][y] is the weight of the unit at (x - radius, y - radius),
+// so its width and height must be the same odd number. Negative weights are ignored.
+func NewCustomKernel(weights [][]float64) (*Kernel, error) {
+	size := len(weights)
+	if size%2 == 0 {
+		return nil, &ErrKernelIsInvalid{}
+	}
+	radius := size / 2
+	kernel := Kernel{radius: radius}
+	weightsSum := 0.0
+	for x := range weights {
+		if len(weights[x]) != size {
+			return nil, &ErrKernelIsInvalid{}
+		}
+		for y, weight := range weights[x] {
+			if weight > 0 {
+				kernel.relativeCoords = append(kernel.relativeCoords, ggol.Coordinate{X: x - radius, Y: y - radius})
+				kernel.weights = append(kernel.weights, weight)
+				weightsSum += weight
+			}
+		}
+	}
+	if weightsSum == 0 {
+		return nil, &ErrKernelIsInvalid{}
+	}
+	for i := range kernel.weights {
+		kernel.weights[i] /= weightsSum
+	}
+	return &kernel, nil
+}
+
+// Build a Lenia kernel with rings of the given peaks, e.g. []float64{1} for 1 ring, []float64{1, 0.5} for 2 rings
+// with the outer one half as strong. Every ring is a smooth bump "exp(4 - 1 / (r * (1 - r)))" across its width.
+func NewRingKernel(radius int, peaks []float64) (*Kernel, error) {
+	if len(peaks) == 0 {
+		return nil, &ErrKernelIsInvalid{}
+	}
+	return newKernel(radius, func(distance float64) float64 {
+		r := distance / float64(radius) * float64(len(peaks))
+		ringIndex := int(r)
+		if ringIndex >= len(peaks) {
+			return 0
+		}
+		ringR := r - float64(ringIndex)
+		if ringR <= 0 {
+			return 0
+		}
+		return peaks[ringIndex] * math.Exp(4-1/(ringR*(1-ringR)))
+	})
+}
+
+// Build a Gaussian kernel "exp(-((r - center) / width) ^ 2 / 2)", where r is the distance divided by the radius,
+// so "center" 0 gives a Gaussian blob, and "center" 0.5 gives a Gaussian ring like the one of Orbium in Lenia.
+func NewGaussianKernel(radius int, center float64, width float64) (*Kernel, error) {
+	if width <= 0 {
+		return nil, &ErrKernelIsInvalid{}
+	}
+	return newKernel(radius, func(distance float64) float64 {
+		r := distance / float64(radius)
+		if r >= 1 {
+			return 0
+		}
+		return math.Exp(-(r - center) * (r - center) / (2 * width * width))
+	})
+}
+
+// Build a disk kernel of SmoothLife, units within the radius are 1, and the edge is anti-aliased over 1 unit.
+func NewDiskKernel(radius float64) (*Kernel, error) {
+	return NewAnnulusKernel(0, radius)
+}
+
+// Build an annulus kernel of SmoothLife, units between the inner and the outer radius are 1,
+// and the edges are anti-aliased over 1 unit.
+func NewAnnulusKernel(innerRadius float64, outerRadius float64) (*Kernel, error) {
+	getDiskWeight := func(radius float64, distance float64) float64 {
+		return math.Max(0, math.Min(1, radius+0.5-distance))
+	}
+	return newKernel(int(math.Ceil(outerRadius+0.5)), func(distance float64) float64 {
+		if innerRadius <= 0 {
+			return getDiskWeight(outerRadius, distance)
+		}
+		return getDiskWeight(outerRadius, distance) - getDiskWeight(innerRadius, distance)
+	})
+}
+
+// Get the radius of the kernel.
+func (k *Kernel) GetRadius() int {
+	return k.radius
+}
+
+// Get the normalized weight of the unit at the relative coordinate.
+func (k *Kernel) GetWeight(relativeCoord *ggol.Coordinate) float64 {
+	for i := range k.relativeCoords {
+		if k.relativeCoords[i] == *relativeCoord {
+			return k.weights[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,174 @@
+package continuous
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func getKernelWeightsSumForTest(kernel *Kernel) float64 {
+	weightsSum := 0.0
+	for x := -kernel.GetRadius(); x <= kernel.GetRadius(); x++ {
+		for y := -kernel.GetRadius(); y <= kernel.GetRadius(); y++ {
+			weightsSum += kernel.GetWeight(&ggol.Coordinate{X: x, Y: y})
+		}
+	}
+	return weightsSum
+}
+
+func testNewCustomKernelCaseOne(t *testing.T) {
+	kernel, _ := NewCustomKernel([][]float64{
+		{1, 0, -1},
+		{0, 2, 0},
+		{0, 0, 1},
+	})
+	if kernel.GetRadius() != 1 {
+		t.Fatalf("Radius should be 1, but got %v.", kernel.GetRadius())
+	}
+	testCases := map[ggol.Coordinate]float64{
+		{X: -1, Y: -1}: 0.25,
+		{X: -1, Y: 1}:  0,
+		{X: 0, Y: 0}:   0.5,
+		{X: 1, Y: 1}:   0.25,
+		{X: 1, Y: 0}:   0,
+		{X: 2, Y: 2}:   0,
+	}
+	for relativeCoord, expectedWeight := range testCases {
+		if weight := kernel.GetWeight(&relativeCoord); weight != expectedWeight {
+			t.Fatalf("Weight at %v should be %v, but got %v.", relativeCoord, expectedWeight, weight)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewCustomKernelCaseTwo(t *testing.T) {
+	invalidWeights := [][][]float64{
+		{},
+		{{1, 1}, {1, 1}},
+		{{1, 1, 1}, {1, 1}, {1, 1, 1}},
+		{{0, 0, 0}, {0, -1, 0}, {0, 0, 0}},
+	}
+	for _, weights := range invalidWeights {
+		if _, err := NewCustomKernel(weights); err == nil {
+			t.Fatalf("Should get error with weights %v.", weights)
+		} else if _, ok := err.(*ErrKernelIsInvalid); !ok {
+			t.Fatalf("Should get ErrKernelIsInvalid, but got %v.", err)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNewCustomKernel(t *testing.T) {
+	testNewCustomKernelCaseOne(t)
+	testNewCustomKernelCaseTwo(t)
+}
+
+func testNewRingKernelCaseOne(t *testing.T) {
+	kernel, _ := NewRingKernel(10, []float64{1})
+	if weightsSum := getKernelWeightsSumForTest(kernel); math.Abs(weightsSum-1) > 1e-9 {
+		t.Fatalf("Weights should sum up to 1, but got %v.", weightsSum)
+	}
+	if weight := kernel.GetWeight(&ggol.Coordinate{X: 0, Y: 0}); weight != 0 {
+		t.Fatalf("Weight at the center should be 0, but got %v.", weight)
+	}
+	// The peak of the ring is at half of the radius.
+	if kernel.GetWeight(&ggol.Coordinate{X: 5, Y: 0}) <= kernel.GetWeight(&ggol.Coordinate{X: 2, Y: 0}) ||
+		kernel.GetWeight(&ggol.Coordinate{X: 5, Y: 0}) <= kernel.GetWeight(&ggol.Coordinate{X: 0, Y: 8}) {
+		t.Fatalf("Weight at half of the radius should be the highest.")
+	}
+	if weight := kernel.GetWeight(&ggol.Coordinate{X: 8, Y: 8}); weight != 0 {
+		t.Fatalf("Weight outside the radius should be 0, but got %v.", weight)
+	}
+	t.Log("Passed")
+}
+
+func testNewRingKernelCaseTwo(t *testing.T) {
+	// The outer ring is half as strong as the inner ring.
+	kernel, _ := NewRingKernel(12, []float64{1, 0.5})
+	innerPeak := kernel.GetWeight(&ggol.Coordinate{X: 3, Y: 0})
+	outerPeak := kernel.GetWeight(&ggol.Coordinate{X: 9, Y: 0})
+	if math.Abs(outerPeak/innerPeak-0.5) > 1e-9 {
+		t.Fatalf("Peak of the outer ring should be half of the inner one, but got %v and %v.", outerPeak, innerPeak)
+	}
+	if weight := kernel.GetWeight(&ggol.Coordinate{X: 0, Y: 6}); weight != 0 {
+		t.Fatalf("Weight between rings should be 0, but got %v.", weight)
+	}
+	t.Log("Passed")
+}
+
+func testNewRingKernelCaseThree(t *testing.T) {
+	if _, err := NewRingKernel(0, []float64{1}); err == nil {
+		t.Fatalf("Should get error when radius is less than 1.")
+	} else if _, ok := err.(*ggol.ErrRadiusIsInvalid); !ok {
+		t.Fatalf("Should get ErrRadiusIsInvalid, but got %v.", err)
+	}
+	if _, err := NewRingKernel(5, []float64{}); err == nil {
+		t.Fatalf("Should get error when there are no rings.")
+	} else if _, ok := err.(*ErrKernelIsInvalid); !ok {
+		t.Fatalf("Should get ErrKernelIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewRingKernel(t *testing.T) {
+	testNewRingKernelCaseOne(t)
+	testNewRingKernelCaseTwo(t)
+	testNewRingKernelCaseThree(t)
+}
+
+func testNewGaussianKernelCaseOne(t *testing.T) {
+	kernel, _ := NewGaussianKernel(13, 0.5, 0.15)
+	if weightsSum := getKernelWeightsSumForTest(kernel); math.Abs(weightsSum-1) > 1e-9 {
+		t.Fatalf("Weights should sum up to 1, but got %v.", weightsSum)
+	}
+	center := kernel.GetWeight(&ggol.Coordinate{X: 0, Y: 0})
+	peak := kernel.GetWeight(&ggol.Coordinate{X: 0, Y: -13 / 2})
+	expectedRatio := math.Exp(-(0.5*0.5)/(2*0.15*0.15)) / math.Exp(-(6.0/13-0.5)*(6.0/13-0.5)/(2*0.15*0.15))
+	if math.Abs(center/peak-expectedRatio) > 1e-9 {
+		t.Fatalf("Ratio of the center to the ring should be %v, but got %v.", expectedRatio, center/peak)
+	}
+	if _, err := NewGaussianKernel(13, 0.5, 0); err == nil {
+		t.Fatalf("Should get error when width is 0.")
+	} else if _, ok := err.(*ErrKernelIsInvalid); !ok {
+		t.Fatalf("Should get ErrKernelIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewGaussianKernel(t *testing.T) {
+	testNewGaussianKernelCaseOne(t)
+}
+
+func testNewAnnulusKernelCaseOne(t *testing.T) {
+	kernel, _ := NewAnnulusKernel(3, 9)
+	if kernel.GetRadius() != 10 {
+		t.Fatalf("Radius should be 10, but got %v.", kernel.GetRadius())
+	}
+	if weightsSum := getKernelWeightsSumForTest(kernel); math.Abs(weightsSum-1) > 1e-9 {
+		t.Fatalf("Weights should sum up to 1, but got %v.", weightsSum)
+	}
+	innerWeight := kernel.GetWeight(&ggol.Coordinate{X: 2, Y: 0})
+	ringWeight := kernel.GetWeight(&ggol.Coordinate{X: 6, Y: 0})
+	edgeWeight := kernel.GetWeight(&ggol.Coordinate{X: 9, Y: 0})
+	if innerWeight != 0 || ringWeight == 0 || math.Abs(edgeWeight/ringWeight-0.5) > 1e-9 {
+		t.Fatalf("Annulus should be empty inside, full in the ring and half at the edge, but got %v, %v and %v.", innerWeight, ringWeight, edgeWeight)
+	}
+	t.Log("Passed")
+}
+
+func testNewAnnulusKernelCaseTwo(t *testing.T) {
+	kernel, _ := NewDiskKernel(3)
+	if weight := kernel.GetWeight(&ggol.Coordinate{X: 0, Y: 0}); weight != kernel.GetWeight(&ggol.Coordinate{X: 2, Y: 1}) {
+		t.Fatalf("Disk should be full inside.")
+	}
+	if _, err := NewDiskKernel(-1); err == nil {
+		t.Fatalf("Should get error when the disk is empty.")
+	}
+	t.Log("Passed")
+}
+
+func TestNewAnnulusKernel(t *testing.T) {
+	testNewAnnulusKernelCaseOne(t)
+	testNewAnnulusKernelCaseTwo(t)
+}
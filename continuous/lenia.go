@@ -0,0 +1,98 @@
+package continuous
+
+import (
+	"math"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Lenia is a continuous automaton, units are float64 values from 0 to 1, the potential of a unit is the sum of
+// units weighted by the kernel, and the unit grows by "deltaTime * growthMapping(potential)" every generation.
+type Lenia struct {
+	kernel            *Kernel
+	growthMapping     GrowthMapping
+	deltaTime         float64
+	convolutionMethod ConvolutionMethod
+}
+
+// Build a Lenia with the kernel, the growth mapping and delta time, delta time must be in (0, 1].
+func NewLenia(kernel *Kernel, growthMapping GrowthMapping, deltaTime float64) (*Lenia, error) {
+	if kernel == nil {
+		return nil, &ErrKernelIsInvalid{}
+	}
+	if deltaTime <= 0 || deltaTime > 1 {
+		return nil, &ErrDeltaTimeIsInvalid{DeltaTime: deltaTime}
+	}
+	return &Lenia{
+		kernel:            kernel,
+		growthMapping:     growthMapping,
+		deltaTime:         deltaTime,
+		convolutionMethod: ConvolutionMethodAuto,
+	}, nil
+}
+
+// Build the Lenia of Orbium, the most well-known glider of Lenia, with a Gaussian ring kernel of radius 13.
+func NewOrbiumLenia() *Lenia {
+	kernel, _ := NewGaussianKernel(13, 0.5, 0.15)
+	lenia, _ := NewLenia(kernel, NewGaussianGrowthMapping(0.15, 0.015), 0.1)
+	return lenia
+}
+
+// Set the convolution method, it only affects generators generated afterwards. Default is ConvolutionMethodAuto.
+func (l *Lenia) SetConvolutionMethod(convolutionMethod ConvolutionMethod) error {
+	if !convolutionMethod.isValid() {
+		return &ErrConvolutionMethodIsInvalid{ConvolutionMethod: convolutionMethod}
+	}
+	l.convolutionMethod = convolutionMethod
+	return nil
+}
+
+// Get the kernel.
+func (l *Lenia) GetKernel() *Kernel {
+	return l.kernel
+}
+
+// Get delta time.
+func (l *Lenia) GetDeltaTime() float64 {
+	return l.deltaTime
+}
+
+// Get the next value of the unit with its potential, it's clamped from 0 to 1.
+func (l *Lenia) GetNextValue(value float64, potential float64) float64 {
+	return clampValue(value + l.deltaTime*l.growthMapping(potential))
+}
+
+func clampValue(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
+
+// Generate a NextUnitGenerator of Lenia for float64 units, it convolves units one by one without the preparer,
+// so you can set it to any number of games.
+func NewLeniaNextUnitGenerator(lenia *Lenia) ggol.NextUnitGenerator[float64] {
+	// Neither of them is prepared, so potentials are convolved directly and next units get new pointers.
+	potentials := newConvolution(lenia.kernel, lenia.convolutionMethod)
+	nextValues := nextUnitsBuffer[float64]{}
+	return func(coord *ggol.Coordinate, unit *float64, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) *float64 {
+		return nextValues.store(coord, lenia.GetNextValue(*unit, potentials.getPotential(coord, getAdjacentUnit)))
+	}
+}
+
+// Set a GenerationPreparer and a NextUnitGenerator of Lenia to the game. The preparer convolves all units with the kernel
+// before every generation, directly or with FFT, and the generator stores next units without allocating.
+// Every game gets its own potentials and next units. The game only takes the preparer with UpdateOrderSynchronous,
+// otherwise you get the error and nothing is set.
+func SetLeniaGenerators(game ggol.Game[float64], lenia *Lenia) error {
+	potentials := newConvolution(lenia.kernel, lenia.convolutionMethod)
+	nextValues := nextUnitsBuffer[float64]{}
+	err := game.SetGenerationPreparer(func(area *ggol.Area, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) {
+		potentials.prepare(area, getAdjacentUnit)
+		nextValues.prepare(area)
+	})
+	if err != nil {
+		return err
+	}
+	game.SetNextUnitGenerator(func(coord *ggol.Coordinate, unit *float64, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) *float64 {
+		return nextValues.store(coord, lenia.GetNextValue(*unit, potentials.getPotential(coord, getAdjacentUnit)))
+	})
+	return nil
+}
@@ -0,0 +1,298 @@
+package continuous
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Orbium of Lenia, orbiumUnitsForTest[y][x] is the value of the unit at (x, y).
+var orbiumUnitsForTest = [][]float64{
+	{0, 0, 0, 0, 0, 0, 0.1, 0.14, 0.1, 0, 0, 0.03, 0.03, 0, 0, 0.3, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0.08, 0.24, 0.3, 0.3, 0.18, 0.14, 0.15, 0.16, 0.15, 0.09, 0.2, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0.15, 0.34, 0.44, 0.46, 0.38, 0.18, 0.14, 0.11, 0.13, 0.19, 0.18, 0.45, 0, 0, 0},
+	{0, 0, 0, 0, 0.06, 0.13, 0.39, 0.5, 0.5, 0.37, 0.06, 0, 0, 0, 0.02, 0.16, 0.68, 0, 0, 0},
+	{0, 0, 0, 0.11, 0.17, 0.17, 0.33, 0.4, 0.38, 0.28, 0.14, 0, 0, 0, 0, 0, 0.18, 0.42, 0, 0},
+	{0, 0, 0.09, 0.18, 0.13, 0.06, 0.08, 0.26, 0.32, 0.32, 0.27, 0, 0, 0, 0, 0, 0, 0.82, 0, 0},
+	{0.27, 0, 0.16, 0.12, 0, 0, 0, 0.25, 0.38, 0.44, 0.45, 0.34, 0, 0, 0, 0, 0, 0.22, 0.17, 0},
+	{0, 0.07, 0.2, 0.02, 0, 0, 0, 0.31, 0.48, 0.57, 0.6, 0.57, 0, 0, 0, 0, 0, 0, 0.49, 0},
+	{0, 0.59, 0.19, 0, 0, 0, 0, 0.2, 0.57, 0.69, 0.76, 0.76, 0.49, 0, 0, 0, 0, 0, 0.36, 0},
+	{0, 0.58, 0.19, 0, 0, 0, 0, 0, 0.67, 0.83, 0.9, 0.92, 0.87, 0.12, 0, 0, 0, 0, 0.22, 0.07},
+	{0, 0, 0.46, 0, 0, 0, 0, 0, 0.7, 0.93, 1, 1, 1, 0.61, 0, 0, 0, 0, 0.18, 0.11},
+	{0, 0, 0.82, 0, 0, 0, 0, 0, 0.47, 1, 1, 0.98, 1, 0.96, 0.27, 0, 0, 0, 0.19, 0.1},
+	{0, 0, 0.46, 0, 0, 0, 0, 0, 0.25, 1, 1, 0.84, 0.92, 0.97, 0.54, 0.14, 0.04, 0.1, 0.21, 0.05},
+	{0, 0, 0, 0.4, 0, 0, 0, 0, 0.09, 0.8, 1, 0.82, 0.8, 0.85, 0.63, 0.31, 0.18, 0.19, 0.2, 0.01},
+	{0, 0, 0, 0.36, 0.1, 0, 0, 0, 0.05, 0.54, 0.86, 0.79, 0.74, 0.72, 0.6, 0.39, 0.28, 0.24, 0.13, 0},
+	{0, 0, 0, 0.01, 0.3, 0.07, 0, 0, 0.08, 0.36, 0.64, 0.7, 0.64, 0.6, 0.51, 0.39, 0.29, 0.19, 0.04, 0},
+	{0, 0, 0, 0, 0.1, 0.24, 0.14, 0.1, 0.15, 0.29, 0.45, 0.53, 0.52, 0.46, 0.4, 0.31, 0.21, 0.08, 0, 0},
+	{0, 0, 0, 0, 0, 0.08, 0.21, 0.21, 0.22, 0.29, 0.36, 0.39, 0.37, 0.33, 0.26, 0.18, 0.09, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0.03, 0.13, 0.19, 0.22, 0.24, 0.24, 0.23, 0.18, 0.13, 0.05, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0.02, 0.06, 0.08, 0.09, 0.07, 0.05, 0.01, 0, 0, 0, 0, 0},
+}
+
+func testNewLeniaCaseOne(t *testing.T) {
+	kernel, _ := NewRingKernel(5, []float64{1})
+	for _, deltaTime := range []float64{0, -0.1, 1.5} {
+		if _, err := NewLenia(kernel, NewGaussianGrowthMapping(0.15, 0.015), deltaTime); err == nil {
+			t.Fatalf("Should get error when delta time is %v.", deltaTime)
+		} else if _, ok := err.(*ErrDeltaTimeIsInvalid); !ok {
+			t.Fatalf("Should get ErrDeltaTimeIsInvalid, but got %v.", err)
+		}
+	}
+	if _, err := NewLenia(nil, NewGaussianGrowthMapping(0.15, 0.015), 0.1); err == nil {
+		t.Fatalf("Should get error when kernel is nil.")
+	} else if _, ok := err.(*ErrKernelIsInvalid); !ok {
+		t.Fatalf("Should get ErrKernelIsInvalid, but got %v.", err)
+	}
+	if lenia, err := NewLenia(kernel, NewGaussianGrowthMapping(0.15, 0.015), 1); err != nil || lenia.GetDeltaTime() != 1 {
+		t.Fatalf("Delta time 1 should be valid, but got error: %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewLenia(t *testing.T) {
+	testNewLeniaCaseOne(t)
+}
+
+func testLeniaSetConvolutionMethodCaseOne(t *testing.T) {
+	lenia := NewOrbiumLenia()
+	if err := lenia.SetConvolutionMethod(ConvolutionMethodFFT); err != nil {
+		t.Fatalf("Should set FFT convolution method, but got error: %v.", err)
+	}
+	if err := lenia.SetConvolutionMethod(ConvolutionMethod(3)); err == nil {
+		t.Fatalf("Should get error when convolution method is invalid.")
+	} else if _, ok := err.(*ErrConvolutionMethodIsInvalid); !ok {
+		t.Fatalf("Should get ErrConvolutionMethodIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestLeniaSetConvolutionMethod(t *testing.T) {
+	testLeniaSetConvolutionMethodCaseOne(t)
+}
+
+func testLeniaGetNextValueCaseOne(t *testing.T) {
+	lenia := NewOrbiumLenia()
+	testCases := []struct {
+		value             float64
+		potential         float64
+		expectedNextValue float64
+	}{
+		{0.5, 0.15, 0.6},
+		{0.5, 0.9, 0.4},
+		{0.95, 0.15, 1},
+		{0.05, 0.9, 0},
+	}
+	for _, testCase := range testCases {
+		if nextValue := lenia.GetNextValue(testCase.value, testCase.potential); math.Abs(nextValue-testCase.expectedNextValue) > 1e-9 {
+			t.Fatalf("Value %v with potential %v should become %v, but got %v.", testCase.value, testCase.potential, testCase.expectedNextValue, nextValue)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestLeniaGetNextValue(t *testing.T) {
+	testLeniaGetNextValueCaseOne(t)
+}
+
+func testSetLeniaGeneratorsCaseOne(t *testing.T) {
+	// Units generated directly, with FFT and without the preparer should be the same, and they stay in [0, 1].
+	kernel, _ := NewRingKernel(7, []float64{0.5, 1})
+	for _, boundaryPolicy := range []ggol.BoundaryPolicy{ggol.BoundaryPolicyWrap, ggol.BoundaryPolicyConstant, ggol.BoundaryPolicyMirror} {
+		games := make([]ggol.Game[float64], 0)
+		for _, convolutionMethod := range []ConvolutionMethod{ConvolutionMethodDirect, ConvolutionMethodFFT, ConvolutionMethodAuto} {
+			lenia, _ := NewLenia(kernel, NewPolynomialGrowthMapping(0.2, 0.03), 0.2)
+			lenia.SetConvolutionMethod(convolutionMethod)
+			g, _ := ggol.NewGame(generateRandomValuesForTest(30, 24, 1))
+			if convolutionMethod != ConvolutionMethodAuto {
+				SetLeniaGenerators(g, lenia)
+			} else {
+				g.SetNextUnitGenerator(NewLeniaNextUnitGenerator(lenia))
+			}
+			g.SetBoundaryPolicy(boundaryPolicy)
+			games = append(games, g)
+		}
+		for i := 0; i < 5; i++ {
+			expectedUnits := *games[2].GenerateNextUnits()
+			for _, g := range games[:2] {
+				units := *g.GenerateNextUnits()
+				for x := range units {
+					for y := range units[x] {
+						if math.Abs(units[x][y]-expectedUnits[x][y]) > 1e-9 {
+							t.Fatalf("Generation %v: unit at (%v, %v) should be %v, but got %v.", i+1, x, y, expectedUnits[x][y], units[x][y])
+						}
+						if units[x][y] < 0 || units[x][y] > 1 {
+							t.Fatalf("Generation %v: unit at (%v, %v) should be in [0, 1], but got %v.", i+1, x, y, units[x][y])
+						}
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLeniaGeneratorsCaseTwo(t *testing.T) {
+	kernel, _ := NewRingKernel(4, []float64{1})
+	lenia, _ := NewLenia(kernel, NewGaussianGrowthMapping(0.15, 0.03), 0.1)
+	lenia.SetConvolutionMethod(ConvolutionMethodFFT)
+	preparedGame := ggol.NewInfiniteGame(0.0)
+	SetLeniaGenerators(preparedGame, lenia)
+	unpreparedGame := ggol.NewInfiniteGame(0.0)
+	unpreparedGame.SetNextUnitGenerator(NewLeniaNextUnitGenerator(lenia))
+
+	values := *generateRandomValuesForTest(12, 12, 2)
+	for x := range values {
+		for y := range values[x] {
+			preparedGame.SetUnit(&ggol.Coordinate{X: x - 6, Y: y - 6}, &values[x][y])
+			unpreparedGame.SetUnit(&ggol.Coordinate{X: x - 6, Y: y - 6}, &values[x][y])
+		}
+	}
+	area := ggol.Area{From: ggol.Coordinate{X: -20, Y: -20}, To: ggol.Coordinate{X: 20, Y: 20}}
+	for i := 0; i < 4; i++ {
+		preparedGame.GenerateNextUnits()
+		unpreparedGame.GenerateNextUnits()
+		preparedUnits, _ := preparedGame.GetUnitsInArea(&area)
+		unpreparedUnits, _ := unpreparedGame.GetUnitsInArea(&area)
+		for x := range *preparedUnits {
+			for y := range (*preparedUnits)[x] {
+				if math.Abs((*preparedUnits)[x][y]-(*unpreparedUnits)[x][y]) > 1e-9 {
+					t.Fatalf("Generation %v: units at (%v, %v) are different with FFT.", i+1, x+area.From.X, y+area.From.Y)
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLeniaGeneratorsCaseThree(t *testing.T) {
+	for _, convolutionMethod := range []ConvolutionMethod{ConvolutionMethodDirect, ConvolutionMethodFFT} {
+		lenia := NewOrbiumLenia()
+		lenia.SetConvolutionMethod(convolutionMethod)
+		g, _ := ggol.NewGame(generateRandomValuesForTest(32, 32, 3))
+		SetLeniaGenerators(g, lenia)
+		g.GenerateNextUnits()
+
+		allocsPerTick := testing.AllocsPerRun(5, func() {
+			g.GenerateNextUnits()
+		})
+		if allocsPerTick != 0 {
+			t.Fatalf("Should not allocate when generating next units with method %v, but got %v allocations per tick.", convolutionMethod, allocsPerTick)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLeniaGeneratorsCaseFour(t *testing.T) {
+	// Orbium glides without losing its mass.
+	units := make([][]float64, 64)
+	for x := range units {
+		units[x] = make([]float64, 64)
+	}
+	for y := range orbiumUnitsForTest {
+		for x := range orbiumUnitsForTest[y] {
+			units[20+x][20+y] = orbiumUnitsForTest[y][x]
+		}
+	}
+	g, _ := ggol.NewGame(&units)
+	SetLeniaGenerators(g, NewOrbiumLenia())
+
+	getMassAndCenter := func() (float64, float64, float64) {
+		mass, centerX, centerY := 0.0, 0.0, 0.0
+		g.IterateUnits(func(coord *ggol.Coordinate, unit *float64) {
+			mass += *unit
+			centerX += *unit * float64(coord.X)
+			centerY += *unit * float64(coord.Y)
+		})
+		return mass, centerX / mass, centerY / mass
+	}
+	initialMass, initialCenterX, initialCenterY := getMassAndCenter()
+	for i := 0; i < 30; i++ {
+		g.GenerateNextUnits()
+	}
+	mass, centerX, centerY := getMassAndCenter()
+	if math.Abs(mass-initialMass) > initialMass*0.1 {
+		t.Fatalf("Mass of Orbium should stay around %v, but got %v.", initialMass, mass)
+	}
+	if math.Hypot(centerX-initialCenterX, centerY-initialCenterY) < 3 {
+		t.Fatalf("Orbium should glide, but its center only moved from (%v, %v) to (%v, %v).", initialCenterX, initialCenterY, centerX, centerY)
+	}
+	t.Log("Passed")
+}
+
+func testSetLeniaGeneratorsCaseFive(t *testing.T) {
+	// Games of different sizes with the same Lenia have their own potentials and next units, even when they're generated concurrently.
+	kernel, _ := NewRingKernel(5, []float64{1})
+	lenia, _ := NewLenia(kernel, NewGaussianGrowthMapping(0.15, 0.03), 0.2)
+	sizes := []ggol.Size{{Width: 20, Height: 26}, {Width: 34, Height: 14}}
+	preparedGames := make([]ggol.Game[float64], len(sizes))
+	unpreparedGames := make([]ggol.Game[float64], len(sizes))
+	for i, size := range sizes {
+		preparedGames[i], _ = ggol.NewGame(generateRandomValuesForTest(size.Width, size.Height, int64(i)))
+		SetLeniaGenerators(preparedGames[i], lenia)
+		unpreparedGames[i], _ = ggol.NewGame(generateRandomValuesForTest(size.Width, size.Height, int64(i)))
+		unpreparedGames[i].SetNextUnitGenerator(NewLeniaNextUnitGenerator(lenia))
+	}
+	for generation := 0; generation < 4; generation++ {
+		wg := sync.WaitGroup{}
+		for i := range preparedGames {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				preparedGames[i].GenerateNextUnits()
+			}(i)
+		}
+		wg.Wait()
+		for i := range preparedGames {
+			preparedUnits := *preparedGames[i].GetUnits()
+			unpreparedUnits := *unpreparedGames[i].GenerateNextUnits()
+			for x := range preparedUnits {
+				for y := range preparedUnits[x] {
+					if math.Abs(preparedUnits[x][y]-unpreparedUnits[x][y]) > 1e-9 {
+						t.Fatalf("Game %v generation %v: units at (%v, %v) are different with the preparer.", i, generation+1, x, y)
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLeniaGeneratorsCaseSix(t *testing.T) {
+	g, _ := ggol.NewGame(generateRandomValuesForTest(8, 8, 7))
+	g.SetUpdateOrder(ggol.UpdateOrderRandom, 0)
+	if _, ok := SetLeniaGenerators(g, NewOrbiumLenia()).(*ggol.ErrUpdateOrderIsNotSupported); !ok {
+		t.Fatalf("Should get ErrUpdateOrderIsNotSupported when units are not updated synchronously.")
+	}
+	t.Log("Passed")
+}
+
+func TestSetLeniaGenerators(t *testing.T) {
+	testSetLeniaGeneratorsCaseOne(t)
+	testSetLeniaGeneratorsCaseTwo(t)
+	testSetLeniaGeneratorsCaseThree(t)
+	testSetLeniaGeneratorsCaseFour(t)
+	testSetLeniaGeneratorsCaseFive(t)
+	testSetLeniaGeneratorsCaseSix(t)
+}
+
+func benchmarkLenia(b *testing.B, convolutionMethod ConvolutionMethod) {
+	lenia := NewOrbiumLenia()
+	lenia.SetConvolutionMethod(convolutionMethod)
+	g, _ := ggol.NewGame(generateRandomValuesForTest(128, 128, 4))
+	SetLeniaGenerators(g, lenia)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.GenerateNextUnits()
+	}
+}
+
+func BenchmarkLeniaWithDirectConvolution(b *testing.B) {
+	benchmarkLenia(b, ConvolutionMethodDirect)
+}
+
+func BenchmarkLeniaWithFFTConvolution(b *testing.B) {
+	benchmarkLenia(b, ConvolutionMethodFFT)
+}
@@ -0,0 +1,142 @@
+package continuous
+
+import (
+	"math"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Smoothness of the transition of SmoothLife, "outer" for the filling of the annulus and "inner" for the one of the disk.
+const (
+	smoothLifeOuterAlpha = 0.028
+	smoothLifeInnerAlpha = 0.147
+)
+
+// SmoothLife is a continuous Game of Life, the unit is alive as much as the filling of the inner disk,
+// and it's born or survives when the filling of the outer annulus is within the birth or the survival range.
+type SmoothLife struct {
+	innerKernel       *Kernel
+	outerKernel       *Kernel
+	birthRange        [2]float64
+	survivalRange     [2]float64
+	deltaTime         float64
+	convolutionMethod ConvolutionMethod
+}
+
+// Build a SmoothLife with radii of the inner disk and the outer annulus, ranges of the outer filling to be born
+// and to survive, and delta time in (0, 1]. Rafler's SmoothLife uses radii 7 and 21, birth range [0.278, 0.365]
+// and survival range [0.267, 0.445].
+func NewSmoothLife(
+	innerRadius float64,
+	outerRadius float64,
+	birthRange [2]float64,
+	survivalRange [2]float64,
+	deltaTime float64,
+) (*SmoothLife, error) {
+	if innerRadius <= 0 || outerRadius <= innerRadius {
+		return nil, &ErrKernelIsInvalid{}
+	}
+	if deltaTime <= 0 || deltaTime > 1 {
+		return nil, &ErrDeltaTimeIsInvalid{DeltaTime: deltaTime}
+	}
+	innerKernel, err := NewDiskKernel(innerRadius)
+	if err != nil {
+		return nil, err
+	}
+	outerKernel, err := NewAnnulusKernel(innerRadius, outerRadius)
+	if err != nil {
+		return nil, err
+	}
+	return &SmoothLife{
+		innerKernel:       innerKernel,
+		outerKernel:       outerKernel,
+		birthRange:        birthRange,
+		survivalRange:     survivalRange,
+		deltaTime:         deltaTime,
+		convolutionMethod: ConvolutionMethodAuto,
+	}, nil
+}
+
+// Set the convolution method, it only affects generators generated afterwards. Default is ConvolutionMethodAuto.
+func (s *SmoothLife) SetConvolutionMethod(convolutionMethod ConvolutionMethod) error {
+	if !convolutionMethod.isValid() {
+		return &ErrConvolutionMethodIsInvalid{ConvolutionMethod: convolutionMethod}
+	}
+	s.convolutionMethod = convolutionMethod
+	return nil
+}
+
+// Get the kernel of the inner disk.
+func (s *SmoothLife) GetInnerKernel() *Kernel {
+	return s.innerKernel
+}
+
+// Get the kernel of the outer annulus.
+func (s *SmoothLife) GetOuterKernel() *Kernel {
+	return s.outerKernel
+}
+
+func smoothStep(x float64, a float64, alpha float64) float64 {
+	return 1 / (1 + math.Exp(-(x-a)*4/alpha))
+}
+
+// Get the transition from 0 to 1 with the inner filling and the outer filling, it's close to 1 when
+// the outer filling is within the birth range for dead units, or within the survival range for live units.
+func (s *SmoothLife) GetTransition(innerFilling float64, outerFilling float64) float64 {
+	aliveness := smoothStep(innerFilling, 0.5, smoothLifeInnerAlpha)
+	from := s.birthRange[0]*(1-aliveness) + s.survivalRange[0]*aliveness
+	to := s.birthRange[1]*(1-aliveness) + s.survivalRange[1]*aliveness
+	return smoothStep(outerFilling, from, smoothLifeOuterAlpha) * (1 - smoothStep(outerFilling, to, smoothLifeOuterAlpha))
+}
+
+// Get the next value of the unit, it grows by "deltaTime * (2 * transition - 1)" and it's clamped from 0 to 1.
+func (s *SmoothLife) GetNextValue(value float64, innerFilling float64, outerFilling float64) float64 {
+	return clampValue(value + s.deltaTime*(2*s.GetTransition(innerFilling, outerFilling)-1))
+}
+
+// Get the next value of the unit with both fillings from the convolutions.
+func (s *SmoothLife) generateNextValue(
+	innerFillings *convolution,
+	outerFillings *convolution,
+	coord *ggol.Coordinate,
+	unit *float64,
+	getAdjacentUnit ggol.AdjacentUnitGetter[float64],
+) float64 {
+	innerFilling := innerFillings.getPotential(coord, getAdjacentUnit)
+	outerFilling := outerFillings.getPotential(coord, getAdjacentUnit)
+	return s.GetNextValue(*unit, innerFilling, outerFilling)
+}
+
+// Generate a NextUnitGenerator of SmoothLife for float64 units, it convolves units one by one without the preparer,
+// so you can set it to any number of games.
+func NewSmoothLifeNextUnitGenerator(smoothLife *SmoothLife) ggol.NextUnitGenerator[float64] {
+	// None of them is prepared, so fillings are convolved directly and next units get new pointers.
+	innerFillings := newConvolution(smoothLife.innerKernel, smoothLife.convolutionMethod)
+	outerFillings := newConvolution(smoothLife.outerKernel, smoothLife.convolutionMethod)
+	nextValues := nextUnitsBuffer[float64]{}
+	return func(coord *ggol.Coordinate, unit *float64, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) *float64 {
+		return nextValues.store(coord, smoothLife.generateNextValue(innerFillings, outerFillings, coord, unit, getAdjacentUnit))
+	}
+}
+
+// Set a GenerationPreparer and a NextUnitGenerator of SmoothLife to the game. The preparer convolves all units with both kernels
+// before every generation, directly or with FFT, and the generator stores next units without allocating.
+// Every game gets its own fillings and next units. The game only takes the preparer with UpdateOrderSynchronous,
+// otherwise you get the error and nothing is set.
+func SetSmoothLifeGenerators(game ggol.Game[float64], smoothLife *SmoothLife) error {
+	innerFillings := newConvolution(smoothLife.innerKernel, smoothLife.convolutionMethod)
+	outerFillings := newConvolution(smoothLife.outerKernel, smoothLife.convolutionMethod)
+	nextValues := nextUnitsBuffer[float64]{}
+	err := game.SetGenerationPreparer(func(area *ggol.Area, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) {
+		innerFillings.prepare(area, getAdjacentUnit)
+		outerFillings.prepare(area, getAdjacentUnit)
+		nextValues.prepare(area)
+	})
+	if err != nil {
+		return err
+	}
+	game.SetNextUnitGenerator(func(coord *ggol.Coordinate, unit *float64, getAdjacentUnit ggol.AdjacentUnitGetter[float64]) *float64 {
+		return nextValues.store(coord, smoothLife.generateNextValue(innerFillings, outerFillings, coord, unit, getAdjacentUnit))
+	})
+	return nil
+}
@@ -0,0 +1,156 @@
+package continuous
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func newRaflerSmoothLifeForTest(innerRadius float64, outerRadius float64) *SmoothLife {
+	smoothLife, _ := NewSmoothLife(innerRadius, outerRadius, [2]float64{0.278, 0.365}, [2]float64{0.267, 0.445}, 0.1)
+	return smoothLife
+}
+
+func testNewSmoothLifeCaseOne(t *testing.T) {
+	for _, radii := range [][2]float64{{0, 9}, {3, 3}, {5, 2}} {
+		if _, err := NewSmoothLife(radii[0], radii[1], [2]float64{0.278, 0.365}, [2]float64{0.267, 0.445}, 0.1); err == nil {
+			t.Fatalf("Should get error when radii are %v.", radii)
+		} else if _, ok := err.(*ErrKernelIsInvalid); !ok {
+			t.Fatalf("Should get ErrKernelIsInvalid, but got %v.", err)
+		}
+	}
+	if _, err := NewSmoothLife(3, 9, [2]float64{0.278, 0.365}, [2]float64{0.267, 0.445}, 2); err == nil {
+		t.Fatalf("Should get error when delta time is 2.")
+	} else if _, ok := err.(*ErrDeltaTimeIsInvalid); !ok {
+		t.Fatalf("Should get ErrDeltaTimeIsInvalid, but got %v.", err)
+	}
+	smoothLife := newRaflerSmoothLifeForTest(3, 9)
+	if smoothLife.GetInnerKernel().GetRadius() != 4 || smoothLife.GetOuterKernel().GetRadius() != 10 {
+		t.Fatalf("Radii of kernels should be 4 and 10, but got %v and %v.", smoothLife.GetInnerKernel().GetRadius(), smoothLife.GetOuterKernel().GetRadius())
+	}
+	if err := smoothLife.SetConvolutionMethod(ConvolutionMethod(-1)); err == nil {
+		t.Fatalf("Should get error when convolution method is invalid.")
+	} else if _, ok := err.(*ErrConvolutionMethodIsInvalid); !ok {
+		t.Fatalf("Should get ErrConvolutionMethodIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewSmoothLife(t *testing.T) {
+	testNewSmoothLifeCaseOne(t)
+}
+
+func testSmoothLifeGetTransitionCaseOne(t *testing.T) {
+	smoothLife := newRaflerSmoothLifeForTest(3, 9)
+	testCases := []struct {
+		innerFilling float64
+		outerFilling float64
+		isAlive      bool
+	}{
+		// Dead units are born within [0.278, 0.365].
+		{0, 0.32, true}, {0, 0.2, false}, {0, 0.42, false},
+		// Live units survive within [0.267, 0.445].
+		{1, 0.42, true}, {1, 0.32, true}, {1, 0.2, false}, {1, 0.6, false},
+	}
+	for _, testCase := range testCases {
+		transition := smoothLife.GetTransition(testCase.innerFilling, testCase.outerFilling)
+		if (transition > 0.5) != testCase.isAlive {
+			t.Fatalf("Transition with inner filling %v and outer filling %v should be alive: %v, but got %v.", testCase.innerFilling, testCase.outerFilling, testCase.isAlive, transition)
+		}
+	}
+	if nextValue := smoothLife.GetNextValue(1, 1, 0.32); nextValue != 1 {
+		t.Fatalf("Next value should be clamped to 1, but got %v.", nextValue)
+	}
+	if nextValue := smoothLife.GetNextValue(0.5, 0, 0.9); math.Abs(nextValue-0.4) > 1e-6 {
+		t.Fatalf("Next value should be 0.4, but got %v.", nextValue)
+	}
+	t.Log("Passed")
+}
+
+func TestSmoothLifeGetTransition(t *testing.T) {
+	testSmoothLifeGetTransitionCaseOne(t)
+}
+
+func testSetSmoothLifeGeneratorsCaseOne(t *testing.T) {
+	// Units generated directly, with FFT and without the preparer should be the same.
+	for _, boundaryPolicy := range []ggol.BoundaryPolicy{ggol.BoundaryPolicyWrap, ggol.BoundaryPolicyConstant, ggol.BoundaryPolicyMirror} {
+		games := make([]ggol.Game[float64], 0)
+		for _, convolutionMethod := range []ConvolutionMethod{ConvolutionMethodDirect, ConvolutionMethodFFT, ConvolutionMethodAuto} {
+			smoothLife := newRaflerSmoothLifeForTest(2, 6)
+			smoothLife.SetConvolutionMethod(convolutionMethod)
+			g, _ := ggol.NewGame(generateRandomValuesForTest(26, 20, 5))
+			if convolutionMethod != ConvolutionMethodAuto {
+				SetSmoothLifeGenerators(g, smoothLife)
+			} else {
+				g.SetNextUnitGenerator(NewSmoothLifeNextUnitGenerator(smoothLife))
+			}
+			g.SetBoundaryPolicy(boundaryPolicy)
+			games = append(games, g)
+		}
+		for i := 0; i < 5; i++ {
+			expectedUnits := *games[2].GenerateNextUnits()
+			for _, g := range games[:2] {
+				units := *g.GenerateNextUnits()
+				for x := range units {
+					for y := range units[x] {
+						if math.Abs(units[x][y]-expectedUnits[x][y]) > 1e-9 {
+							t.Fatalf("Generation %v: unit at (%v, %v) should be %v, but got %v.", i+1, x, y, expectedUnits[x][y], units[x][y])
+						}
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetSmoothLifeGeneratorsCaseTwo(t *testing.T) {
+	g, _ := ggol.NewGame(generateRandomValuesForTest(32, 32, 6))
+	SetSmoothLifeGenerators(g, newRaflerSmoothLifeForTest(3, 9))
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(5, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func testSetSmoothLifeGeneratorsCaseThree(t *testing.T) {
+	// Two games with the same SmoothLife have their own fillings and next units.
+	smoothLife := newRaflerSmoothLifeForTest(2, 6)
+	preparedGames := make([]ggol.Game[float64], 2)
+	unpreparedGames := make([]ggol.Game[float64], 2)
+	for i := range preparedGames {
+		preparedGames[i], _ = ggol.NewGame(generateRandomValuesForTest(20+i*8, 24-i*6, int64(10+i)))
+		SetSmoothLifeGenerators(preparedGames[i], smoothLife)
+		unpreparedGames[i], _ = ggol.NewGame(generateRandomValuesForTest(20+i*8, 24-i*6, int64(10+i)))
+		unpreparedGames[i].SetNextUnitGenerator(NewSmoothLifeNextUnitGenerator(smoothLife))
+	}
+	for generation := 0; generation < 4; generation++ {
+		for i := range preparedGames {
+			preparedGames[i].GenerateNextUnits()
+		}
+		for i := range preparedGames {
+			preparedUnits := *preparedGames[i].GetUnits()
+			unpreparedUnits := *unpreparedGames[i].GenerateNextUnits()
+			for x := range preparedUnits {
+				for y := range preparedUnits[x] {
+					if math.Abs(preparedUnits[x][y]-unpreparedUnits[x][y]) > 1e-9 {
+						t.Fatalf("Game %v generation %v: units at (%v, %v) are different with the preparer.", i, generation+1, x, y)
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestSetSmoothLifeGenerators(t *testing.T) {
+	testSetSmoothLifeGeneratorsCaseOne(t)
+	testSetSmoothLifeGeneratorsCaseTwo(t)
+	testSetSmoothLifeGeneratorsCaseThree(t)
+}
@@ -55,6 +55,28 @@ func defauUnitForTestIterator(coord *Coordinate, unit *unitForTest, getAdjacentU
 	}
 }
 
+var liveUnitForTest unitForTest = unitForTest{hasLiveCell: true}
+var deadUnitForTest unitForTest = unitForTest{hasLiveCell: false}
+var adjacentCoordsForTest [8]Coordinate = [8]Coordinate{
+	{X: -1, Y: -1}, {X: -1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: -1},
+	{X: 0, Y: 1}, {X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1},
+}
+
+// Same rules as defauUnitForTestIterator, but it never allocates, so we can measure allocations of the game itself.
+func nonAllocatingUnitForTestIterator(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+	var aliveAdjacentCellsCount int = 0
+	for i := 0; i < len(adjacentCoordsForTest); i += 1 {
+		adjUnit, isCrossBorder := getAdjacentUnit(coord, &adjacentCoordsForTest[i])
+		if adjUnit.hasLiveCell && !isCrossBorder {
+			aliveAdjacentCellsCount += 1
+		}
+	}
+	if aliveAdjacentCellsCount == 3 || (unit.hasLiveCell && aliveAdjacentCellsCount == 2) {
+		return &liveUnitForTest
+	}
+	return &deadUnitForTest
+}
+
 func areTwoUnitsHavingLiveCellForTestEqual(a unitsHavingLiveCellForTest, b unitsHavingLiveCellForTest) bool {
 	for i := 0; i < len(a); i++ {
 		for j := 0; j < len(a[i]); j++ {
@@ -77,3 +99,13 @@ func convertUnitForTestMatrixToUnitsHavingLiveCellForTest(g *[][]unitForTest) *u
 
 	return &gMap
 }
+
+// Every unit becomes at least its left unit minus 1, so a unit spreads to the right until it decays to 0.
+func decayingUnitForTestIterator(coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	leftUnit, _ := getAdjacentUnit(coord, &Coordinate{X: -1, Y: 0})
+	nextUnit := *unit
+	if *leftUnit-1 > nextUnit {
+		nextUnit = *leftUnit - 1
+	}
+	return &nextUnit
+}
@@ -52,3 +52,32 @@ func testConvertTestUnitsMatricToHasLiveCellTestUnitsMapCaseOne(t *testing.T) {
 func TestConvertTestUnitsMatricToHasLiveCellTestUnitsMap(t *testing.T) {
 	testConvertTestUnitsMatricToHasLiveCellTestUnitsMapCaseOne(t)
 }
+
+func testNonAllocatingUnitForTestIteratorCaseOne(t *testing.T) {
+	units := generateInitialUnitMatrixForTest(9, 7, initialUnitForTest)
+	for x := 0; x < 9; x++ {
+		for y := 0; y < 7; y++ {
+			(*units)[x][y].hasLiveCell = (x+y*3)%4 == 0
+		}
+	}
+	game, _ := NewGame(units)
+	allocatingGame, _ := NewGame(generateInitialUnitMatrixForTest(9, 7, initialUnitForTest))
+	game.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+		allocatingGame.SetUnit(coord, unit)
+	})
+	game.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+	allocatingGame.SetNextUnitGenerator(defauUnitForTestIterator)
+
+	for i := 0; i < 5; i++ {
+		liveUnitsMap := convertUnitForTestMatrixToUnitsHavingLiveCellForTest(game.GenerateNextUnits())
+		expectedMap := convertUnitForTestMatrixToUnitsHavingLiveCellForTest(allocatingGame.GenerateNextUnits())
+		if !areTwoUnitsHavingLiveCellForTestEqual(*liveUnitsMap, *expectedMap) {
+			t.Fatalf("nonAllocatingUnitForTestIterator should follow the same rules as defauUnitForTestIterator.")
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNonAllocatingUnitForTestIterator(t *testing.T) {
+	testNonAllocatingUnitForTestIteratorCaseOne(t)
+}
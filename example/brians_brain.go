@@ -0,0 +1,61 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+
+	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/rule"
+)
+
+func setBriansBrainUnits(g ggol.Game[rule.GenerationsUnit]) {
+	size := g.GetSize()
+	random := rand.New(rand.NewSource(1))
+	for x := size.Width / 3; x < size.Width*2/3; x += 1 {
+		for y := size.Height / 3; y < size.Height*2/3; y += 1 {
+			if random.Intn(3) == 0 {
+				g.SetUnit(&ggol.Coordinate{X: x, Y: y}, &rule.GenerationsUnit{State: 1})
+			}
+		}
+	}
+}
+
+func drawBriansBrainUnit(coord *ggol.Coordinate, unit *rule.GenerationsUnit, blockSize int, image *image.Paletted) {
+	for i := 0; i < blockSize; i += 1 {
+		for j := 0; j < blockSize; j += 1 {
+			image.SetColorIndex(coord.X*blockSize+i, coord.Y*blockSize+j, uint8(unit.State))
+		}
+	}
+}
+
+func executeBriansBrain() {
+	briansBrainRule, _ := rule.NewGenerationsRule(rule.RuleBriansBrain)
+	initialUnits := make([][]rule.GenerationsUnit, 50)
+	for x := range initialUnits {
+		initialUnits[x] = make([]rule.GenerationsUnit, 50)
+	}
+	game, _ := ggol.NewGame(&initialUnits)
+	size := game.GetSize()
+	game.SetNextUnitGenerator(rule.NewGenerationsNextUnitGenerator(briansBrainRule))
+	setBriansBrainUnits(game)
+
+	briansBrainPalette := briansBrainRule.GeneratePalette(color.RGBA{0xff, 0xff, 0xff, 0xff}, color.RGBA{0x00, 0x00, 0x00, 0xff})
+	var images []*image.Paletted
+	var delays []int
+	blockSize := 10
+	iterationsCount := 100
+	duration := 0
+
+	for i := 0; i < iterationsCount; i += 1 {
+		newImage := image.NewPaletted(image.Rect(0, 0, size.Width*blockSize, size.Height*blockSize), briansBrainPalette)
+		game.IterateUnits(func(coord *ggol.Coordinate, unit *rule.GenerationsUnit) {
+			drawBriansBrainUnit(coord, unit, blockSize, newImage)
+		})
+		images = append(images, newImage)
+		delays = append(delays, duration)
+		game.GenerateNextUnits()
+	}
+
+	outputGif("output/brians_brain.gif", images, delays)
+}
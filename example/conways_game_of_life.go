@@ -5,6 +5,7 @@ import (
 	"image/color"
 
 	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/rule"
 )
 
 type conwaysGameOfLifeUnit struct {
@@ -15,41 +16,12 @@ var initialConwaysGameOfLifeUnit conwaysGameOfLifeUnit = conwaysGameOfLifeUnit{
 	HasLiveCell: false,
 }
 
-func conwaysGameOfLifeNextUnitGenerator(
-	coord *ggol.Coordinate,
-	unit *conwaysGameOfLifeUnit,
-	getAdjacentUnit ggol.AdjacentUnitGetter[conwaysGameOfLifeUnit],
-) (nextUnit *conwaysGameOfLifeUnit) {
-	newUnit := *unit
+func isConwaysGameOfLifeUnitAlive(unit *conwaysGameOfLifeUnit) bool {
+	return unit.HasLiveCell
+}
 
-	var aliveAdjacentCellsCount int = 0
-	for i := -1; i < 2; i += 1 {
-		for j := -1; j < 2; j += 1 {
-			if !(i == 0 && j == 0) {
-				adjUnit, _ := getAdjacentUnit(coord, &ggol.Coordinate{X: i, Y: j})
-				if adjUnit.HasLiveCell {
-					aliveAdjacentCellsCount += 1
-				}
-			}
-		}
-	}
-	if newUnit.HasLiveCell {
-		if aliveAdjacentCellsCount != 2 && aliveAdjacentCellsCount != 3 {
-			newUnit.HasLiveCell = false
-			return &newUnit
-		} else {
-			newUnit.HasLiveCell = true
-			return &newUnit
-		}
-	} else {
-		if aliveAdjacentCellsCount == 3 {
-			newUnit.HasLiveCell = true
-			return &newUnit
-		} else {
-			newUnit.HasLiveCell = false
-			return &newUnit
-		}
-	}
+func generateNextConwaysGameOfLifeUnit(unit *conwaysGameOfLifeUnit, isAlive bool) *conwaysGameOfLifeUnit {
+	return &conwaysGameOfLifeUnit{HasLiveCell: isAlive}
 }
 
 func generateInitialConwaysGameOfLifeUnits(width int, height int, unit conwaysGameOfLifeUnit) *[][]conwaysGameOfLifeUnit {
@@ -91,7 +63,11 @@ func executeGameOfLife() {
 	initialUnits := generateInitialConwaysGameOfLifeUnits(50, 50, initialConwaysGameOfLifeUnit)
 	game, _ := ggol.NewGame(initialUnits)
 	size := game.GetSize()
-	game.SetNextUnitGenerator(conwaysGameOfLifeNextUnitGenerator)
+	conwaysGameOfLifeRule, _ := rule.NewLifeLikeRule(rule.RuleConwaysGameOfLife)
+	game.SetNeighborhoodNextUnitGenerator(
+		conwaysGameOfLifeRule.GetNeighborhood(),
+		rule.NewNeighborhoodNextUnitGenerator(conwaysGameOfLifeRule, isConwaysGameOfLifeUnitAlive, generateNextConwaysGameOfLifeUnit),
+	)
 	setConwaysGameOfLifeUnits(game)
 
 	var conwaysGameOfLifePalette = []color.Color{
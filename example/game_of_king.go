@@ -19,6 +19,7 @@ var initialGameOfKingUnit gameOfKingUnit = gameOfKingUnit{
 }
 
 func gameOfKingNextUnitGenerator(
+	context *ggol.GenerationContext,
 	coord *ggol.Coordinate,
 	unit *gameOfKingUnit,
 	getAdjacentUnit ggol.AdjacentUnitGetter[gameOfKingUnit],
@@ -42,7 +43,8 @@ func gameOfKingNextUnitGenerator(
 	if rightAdjUnit.Direction == DirectionLeft {
 		newUnit.Strength += rightAdjUnit.Strength
 	}
-	newUnit.Direction = Direction(rand.Intn(4))
+	// Random numbers from the context are seeded by the game, so the game replays identically even in parallel.
+	newUnit.Direction = Direction(context.Intn(4))
 
 	return &newUnit
 }
@@ -61,8 +63,9 @@ func generateInitialGameOfKingUnit(width int, height int, unit gameOfKingUnit) *
 func initializeGameOfKingUnits(g ggol.Game[gameOfKingUnit]) {
 	size := g.GetSize()
 	cellsCount := int((size.Width * size.Height) / 2)
+	random := rand.New(rand.NewSource(0))
 	for i := 0; i < cellsCount; i += 1 {
-		g.SetUnit(&ggol.Coordinate{X: rand.Intn(size.Width), Y: rand.Intn(size.Height)}, &gameOfKingUnit{Strength: 1, Direction: 0})
+		g.SetUnit(&ggol.Coordinate{X: random.Intn(size.Width), Y: random.Intn(size.Height)}, &gameOfKingUnit{Strength: 1, Direction: 0})
 	}
 }
 
@@ -85,7 +88,8 @@ func executeGameOfKing() {
 	initialUnits := generateInitialGameOfKingUnit(250, 250, initialGameOfKingUnit)
 	game, _ := ggol.NewGame(initialUnits)
 	size := game.GetSize()
-	game.SetNextUnitGenerator(gameOfKingNextUnitGenerator)
+	game.SetNextUnitGeneratorWithContext(gameOfKingNextUnitGenerator)
+	game.SetSeed(0)
 	initializeGameOfKingUnits(game)
 
 	var gameOfKingPalette = []color.Color{
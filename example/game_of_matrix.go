@@ -3,7 +3,6 @@ package main
 import (
 	"image"
 	"image/color"
-	"math/rand"
 
 	"github.com/dum-dum-genius/ggol"
 )
@@ -21,29 +20,45 @@ var initialGameOfMatrixUnit gameOfMatrixUnit = gameOfMatrixUnit{
 	CountHeight: 50,
 }
 
-// A game can only have 20 word of streams in total
-var totalWordStreamsCount = 0
+// Params of the game, generators get them from GenerationContext.
+type gameOfMatrixParams struct {
+	// A game can only have this many word streams in total.
+	MaxWordStreamsCount int
+	// The count of word streams in the game, units in the top row contribute 1 when a word stream starts
+	// and -1 when it ends.
+	WordStreamsCount *ggol.GlobalState[int, int]
+}
+
+func sumGameOfMatrixWordStreamsCount(state int, contributions []int) int {
+	for _, contribution := range contributions {
+		state += contribution
+	}
+	return state
+}
 
 func gameOfMatrixNextUnitGenerator(
+	context *ggol.GenerationContext,
 	coord *ggol.Coordinate,
 	unit *gameOfMatrixUnit,
 	getAdjacentUnit ggol.AdjacentUnitGetter[gameOfMatrixUnit],
 ) (nextUnit *gameOfMatrixUnit) {
 	newUnit := *unit
 	if coord.Y == 0 {
-		if unit.CountWords == 0 && unit.CountHeight >= 50 && totalWordStreamsCount < 50 {
-			if rand.Intn(50) == 1 {
-				newUnit.WordsLength = 30 + rand.Intn(40)
+		params := context.GetParams().(*gameOfMatrixParams)
+		if unit.CountWords == 0 && unit.CountHeight >= 50 {
+			// Word streams are counted in the last generation, so columns don't need to wait for each other.
+			if context.Intn(50) == 1 && *params.WordStreamsCount.GetFrozenState() < params.MaxWordStreamsCount {
+				params.WordStreamsCount.Contribute(context, 1)
+				newUnit.WordsLength = 30 + context.Intn(40)
 				newUnit.CountWords = 1
 				newUnit.CountHeight = 0
-				totalWordStreamsCount += 1
 			}
 		} else if unit.CountWords < unit.WordsLength {
 			newUnit.CountWords += 1
 		} else if unit.CountWords == unit.WordsLength && unit.CountWords != 0 {
+			params.WordStreamsCount.Contribute(context, -1)
 			newUnit.WordsLength = 0
 			newUnit.CountWords = 0
-			totalWordStreamsCount -= 1
 		}
 		newUnit.CountHeight += 1
 		return &newUnit
@@ -91,7 +106,10 @@ func executeGameOfMatrix() {
 	initialUnits := generateInitialGameOfMatrixUnit(50, 50, initialGameOfMatrixUnit)
 	game, _ := ggol.NewGame(initialUnits)
 	size := game.GetSize()
-	game.SetNextUnitGenerator(gameOfMatrixNextUnitGenerator)
+	wordStreamsCount := ggol.NewGlobalState(0, sumGameOfMatrixWordStreamsCount)
+	game.SetGlobalState(wordStreamsCount)
+	game.SetParams(&gameOfMatrixParams{MaxWordStreamsCount: 50, WordStreamsCount: wordStreamsCount})
+	game.SetNextUnitGeneratorWithContext(gameOfMatrixNextUnitGenerator)
 	initializeGameOfMatrixUnits(game)
 
 	previousSteps := 100
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/continuous"
+)
+
+var grayScottPalette []color.Color = generateGrayScottPalette(32)
+
+// Colors from white to dark teal as V grows.
+func generateGrayScottPalette(colorsCount int) []color.Color {
+	palette := make([]color.Color, colorsCount)
+	for i := range palette {
+		ratio := float64(i) / float64(colorsCount-1)
+		palette[i] = color.RGBA{uint8(0xff - ratio*0xf0), uint8(0xff - ratio*0xa0), uint8(0xff - ratio*0xb0), 0xff}
+	}
+	return palette
+}
+
+func drawGrayScottUnit(coord *ggol.Coordinate, unit *continuous.ReactionDiffusionUnit, blockSize int, image *image.Paletted) {
+	// V rarely goes beyond 0.5, so it's doubled for contrast.
+	colorIndex := uint8(math.Min(unit.V*2, 1) * float64(len(grayScottPalette)-1))
+	for i := 0; i < blockSize; i += 1 {
+		for j := 0; j < blockSize; j += 1 {
+			image.SetColorIndex(coord.X*blockSize+i, coord.Y*blockSize+j, colorIndex)
+		}
+	}
+}
+
+func executeGrayScott() {
+	// Corals grow with feed rate 0.0545 and kill rate 0.062.
+	grayScott, _ := continuous.NewGrayScott(0.0545, 0.062, 1, 0.5, continuous.NewNinePointLaplacianStencil(), 1)
+	size := 120
+	steadyUnit := grayScott.GetSteadyUnit()
+	initialUnits := make([][]continuous.ReactionDiffusionUnit, size)
+	for x := 0; x < size; x += 1 {
+		initialUnits[x] = make([]continuous.ReactionDiffusionUnit, size)
+		for y := 0; y < size; y += 1 {
+			initialUnits[x][y] = steadyUnit
+		}
+	}
+	game, _ := ggol.NewGame(&initialUnits)
+	continuous.SetGrayScottGenerators(game, grayScott)
+	for _, seed := range []ggol.Coordinate{{X: 30, Y: 40}, {X: 80, Y: 30}, {X: 60, Y: 85}} {
+		for x := -3; x <= 3; x += 1 {
+			for y := -3; y <= 3; y += 1 {
+				game.SetUnit(&ggol.Coordinate{X: seed.X + x, Y: seed.Y + y}, &continuous.ReactionDiffusionUnit{U: 0.5, V: 0.5})
+			}
+		}
+	}
+
+	var images []*image.Paletted
+	var delays []int
+	blockSize := 3
+	iterationsCount := 150
+	generationsPerFrame := 20
+	duration := 0
+
+	for i := 0; i < iterationsCount; i += 1 {
+		newImage := image.NewPaletted(image.Rect(0, 0, size*blockSize, size*blockSize), grayScottPalette)
+		game.IterateUnits(func(coord *ggol.Coordinate, unit *continuous.ReactionDiffusionUnit) {
+			drawGrayScottUnit(coord, unit, blockSize, newImage)
+		})
+		images = append(images, newImage)
+		delays = append(delays, duration)
+		for j := 0; j < generationsPerFrame; j += 1 {
+			game.GenerateNextUnits()
+		}
+	}
+
+	outputGif("output/gray_scott.gif", images, delays)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/rule"
+)
+
+var langtonsAntPalette []color.Color = []color.Color{
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+	color.RGBA{0xff, 0x00, 0x00, 0xff},
+}
+
+func setLangtonsAntUnits(g ggol.Game[rule.AntUnit]) {
+	size := g.GetSize()
+	g.SetUnit(&ggol.Coordinate{X: size.Width / 3, Y: size.Height / 3}, &rule.AntUnit{Ants: [4]int{rule.AntHeadingNorth: 1}})
+	g.SetUnit(&ggol.Coordinate{X: size.Width * 2 / 3, Y: size.Height / 2}, &rule.AntUnit{Ants: [4]int{rule.AntHeadingSouth: 1}})
+	g.SetUnit(&ggol.Coordinate{X: size.Width / 2, Y: size.Height * 2 / 3}, &rule.AntUnit{Ants: [4]int{rule.AntHeadingWest: 1}})
+}
+
+func drawLangtonsAntUnit(coord *ggol.Coordinate, unit *rule.AntUnit, blockSize int, image *image.Paletted) {
+	colorIndex := uint8(unit.Color)
+	if unit.HasAnts() {
+		colorIndex = 2
+	}
+	for i := 0; i < blockSize; i += 1 {
+		for j := 0; j < blockSize; j += 1 {
+			image.SetColorIndex(coord.X*blockSize+i, coord.Y*blockSize+j, colorIndex)
+		}
+	}
+}
+
+func executeLangtonsAnt() {
+	langtonsAntRule, _ := rule.NewTurmiteRule(rule.RuleLangtonsAnt)
+	initialUnits := make([][]rule.AntUnit, 80)
+	for x := range initialUnits {
+		initialUnits[x] = make([]rule.AntUnit, 80)
+	}
+	game, _ := ggol.NewGame(&initialUnits)
+	size := game.GetSize()
+	game.SetNeighborhoodNextUnitGenerator(langtonsAntRule.GetNeighborhood(), rule.NewTurmiteNeighborhoodNextUnitGenerator(langtonsAntRule))
+	setLangtonsAntUnits(game)
+
+	var images []*image.Paletted
+	var delays []int
+	blockSize := 5
+	iterationsCount := 120
+	stepsPerIteration := 100
+	duration := 0
+
+	for i := 0; i < iterationsCount; i += 1 {
+		newImage := image.NewPaletted(image.Rect(0, 0, size.Width*blockSize, size.Height*blockSize), langtonsAntPalette)
+		game.IterateUnits(func(coord *ggol.Coordinate, unit *rule.AntUnit) {
+			drawLangtonsAntUnit(coord, unit, blockSize, newImage)
+		})
+		images = append(images, newImage)
+		delays = append(delays, duration)
+		for j := 0; j < stepsPerIteration; j += 1 {
+			game.GenerateNextUnits()
+		}
+	}
+
+	outputGif("output/langtons_ant.gif", images, delays)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+
+	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/rule"
+)
+
+var latticeGasPalette []color.Color = []color.Color{
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+	color.RGBA{0x1f, 0x4e, 0x8c, 0xff},
+}
+
+func drawLatticeGasUnit(coord *ggol.Coordinate, unit *rule.LatticeGasUnit, blockSize int, image *image.Paletted) {
+	colorIndex := uint8(0)
+	if unit.HasParticle {
+		colorIndex = 1
+	}
+	for i := 0; i < blockSize; i += 1 {
+		for j := 0; j < blockSize; j += 1 {
+			image.SetColorIndex(coord.X*blockSize+i, coord.Y*blockSize+j, colorIndex)
+		}
+	}
+}
+
+func executeLatticeGas() {
+	size := 120
+	initialUnits := make([][]rule.LatticeGasUnit, size)
+	for x := 0; x < size; x += 1 {
+		initialUnits[x] = make([]rule.LatticeGasUnit, size)
+	}
+	game, _ := ggol.NewBlockGame(&initialUnits)
+	nextBlockGenerator, previousBlockGenerator := rule.NewHPPGasBlockGenerators()
+	game.SetNextBlockGenerator(nextBlockGenerator)
+	game.SetPreviousBlockGenerator(previousBlockGenerator)
+	// A dense square of gas in the middle of a thin gas.
+	random := rand.New(rand.NewSource(0))
+	for x := 0; x < size; x += 1 {
+		for y := 0; y < size; y += 1 {
+			density := 8
+			if x >= 40 && x < 80 && y >= 40 && y < 80 {
+				density = 2
+			}
+			if random.Intn(density) == 0 {
+				game.SetUnit(&ggol.Coordinate{X: x, Y: y}, &rule.LatticeGasUnit{HasParticle: true})
+			}
+		}
+	}
+
+	var images []*image.Paletted
+	var delays []int
+	blockSize := 3
+	iterationsCount := 60
+	generationsPerFrame := 2
+	duration := 0
+
+	drawFrame := func() {
+		newImage := image.NewPaletted(image.Rect(0, 0, size*blockSize, size*blockSize), latticeGasPalette)
+		game.IterateUnits(func(coord *ggol.Coordinate, unit *rule.LatticeGasUnit) {
+			drawLatticeGasUnit(coord, unit, blockSize, newImage)
+		})
+		images = append(images, newImage)
+		delays = append(delays, duration)
+	}
+	// The gas spreads out, then time goes backward and the gas goes back to the square.
+	for i := 0; i < iterationsCount; i += 1 {
+		drawFrame()
+		for j := 0; j < generationsPerFrame; j += 1 {
+			game.GenerateNextUnits()
+		}
+	}
+	for i := 0; i < iterationsCount; i += 1 {
+		drawFrame()
+		for j := 0; j < generationsPerFrame; j += 1 {
+			game.GeneratePreviousUnits()
+		}
+	}
+	drawFrame()
+
+	outputGif("output/lattice_gas.gif", images, delays)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/continuous"
+)
+
+// Orbium of Lenia, orbiumUnits[y][x] is the value of the unit at (x, y).
+var orbiumUnits = [][]float64{
+	{0, 0, 0, 0, 0, 0, 0.1, 0.14, 0.1, 0, 0, 0.03, 0.03, 0, 0, 0.3, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0.08, 0.24, 0.3, 0.3, 0.18, 0.14, 0.15, 0.16, 0.15, 0.09, 0.2, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0.15, 0.34, 0.44, 0.46, 0.38, 0.18, 0.14, 0.11, 0.13, 0.19, 0.18, 0.45, 0, 0, 0},
+	{0, 0, 0, 0, 0.06, 0.13, 0.39, 0.5, 0.5, 0.37, 0.06, 0, 0, 0, 0.02, 0.16, 0.68, 0, 0, 0},
+	{0, 0, 0, 0.11, 0.17, 0.17, 0.33, 0.4, 0.38, 0.28, 0.14, 0, 0, 0, 0, 0, 0.18, 0.42, 0, 0},
+	{0, 0, 0.09, 0.18, 0.13, 0.06, 0.08, 0.26, 0.32, 0.32, 0.27, 0, 0, 0, 0, 0, 0, 0.82, 0, 0},
+	{0.27, 0, 0.16, 0.12, 0, 0, 0, 0.25, 0.38, 0.44, 0.45, 0.34, 0, 0, 0, 0, 0, 0.22, 0.17, 0},
+	{0, 0.07, 0.2, 0.02, 0, 0, 0, 0.31, 0.48, 0.57, 0.6, 0.57, 0, 0, 0, 0, 0, 0, 0.49, 0},
+	{0, 0.59, 0.19, 0, 0, 0, 0, 0.2, 0.57, 0.69, 0.76, 0.76, 0.49, 0, 0, 0, 0, 0, 0.36, 0},
+	{0, 0.58, 0.19, 0, 0, 0, 0, 0, 0.67, 0.83, 0.9, 0.92, 0.87, 0.12, 0, 0, 0, 0, 0.22, 0.07},
+	{0, 0, 0.46, 0, 0, 0, 0, 0, 0.7, 0.93, 1, 1, 1, 0.61, 0, 0, 0, 0, 0.18, 0.11},
+	{0, 0, 0.82, 0, 0, 0, 0, 0, 0.47, 1, 1, 0.98, 1, 0.96, 0.27, 0, 0, 0, 0.19, 0.1},
+	{0, 0, 0.46, 0, 0, 0, 0, 0, 0.25, 1, 1, 0.84, 0.92, 0.97, 0.54, 0.14, 0.04, 0.1, 0.21, 0.05},
+	{0, 0, 0, 0.4, 0, 0, 0, 0, 0.09, 0.8, 1, 0.82, 0.8, 0.85, 0.63, 0.31, 0.18, 0.19, 0.2, 0.01},
+	{0, 0, 0, 0.36, 0.1, 0, 0, 0, 0.05, 0.54, 0.86, 0.79, 0.74, 0.72, 0.6, 0.39, 0.28, 0.24, 0.13, 0},
+	{0, 0, 0, 0.01, 0.3, 0.07, 0, 0, 0.08, 0.36, 0.64, 0.7, 0.64, 0.6, 0.51, 0.39, 0.29, 0.19, 0.04, 0},
+	{0, 0, 0, 0, 0.1, 0.24, 0.14, 0.1, 0.15, 0.29, 0.45, 0.53, 0.52, 0.46, 0.4, 0.31, 0.21, 0.08, 0, 0},
+	{0, 0, 0, 0, 0, 0.08, 0.21, 0.21, 0.22, 0.29, 0.36, 0.39, 0.37, 0.33, 0.26, 0.18, 0.09, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0.03, 0.13, 0.19, 0.22, 0.24, 0.24, 0.23, 0.18, 0.13, 0.05, 0, 0, 0, 0},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0.02, 0.06, 0.08, 0.09, 0.07, 0.05, 0.01, 0, 0, 0, 0, 0},
+}
+
+var leniaPalette []color.Color = generateLeniaPalette(32)
+
+// Colors from dark blue to yellow as values grow from 0 to 1.
+func generateLeniaPalette(colorsCount int) []color.Color {
+	palette := make([]color.Color, colorsCount)
+	for i := range palette {
+		ratio := float64(i) / float64(colorsCount-1)
+		palette[i] = color.RGBA{uint8(0x10 + ratio*0xe0), uint8(0x10 + ratio*0xd0), uint8(0x40 + ratio*(0x30-0x40)), 0xff}
+	}
+	return palette
+}
+
+func drawLeniaUnit(coord *ggol.Coordinate, unit *float64, blockSize int, image *image.Paletted) {
+	colorIndex := uint8(*unit * float64(len(leniaPalette)-1))
+	for i := 0; i < blockSize; i += 1 {
+		for j := 0; j < blockSize; j += 1 {
+			image.SetColorIndex(coord.X*blockSize+i, coord.Y*blockSize+j, colorIndex)
+		}
+	}
+}
+
+func executeLenia() {
+	size := 64
+	initialUnits := make([][]float64, size)
+	for x := 0; x < size; x += 1 {
+		initialUnits[x] = make([]float64, size)
+	}
+	game, _ := ggol.NewGame(&initialUnits)
+	continuous.SetLeniaGenerators(game, continuous.NewOrbiumLenia())
+	for y := range orbiumUnits {
+		for x := range orbiumUnits[y] {
+			game.SetUnit(&ggol.Coordinate{X: 20 + x, Y: 20 + y}, &orbiumUnits[y][x])
+		}
+	}
+
+	var images []*image.Paletted
+	var delays []int
+	blockSize := 5
+	iterationsCount := 200
+	duration := 0
+
+	for i := 0; i < iterationsCount; i += 1 {
+		newImage := image.NewPaletted(image.Rect(0, 0, size*blockSize, size*blockSize), leniaPalette)
+		game.IterateUnits(func(coord *ggol.Coordinate, unit *float64) {
+			drawLeniaUnit(coord, unit, blockSize, newImage)
+		})
+		images = append(images, newImage)
+		delays = append(delays, duration)
+		game.GenerateNextUnits()
+	}
+
+	outputGif("output/lenia.gif", images, delays)
+}
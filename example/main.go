@@ -6,4 +6,11 @@ func main() {
 	executeGameOfKing()
 	executeGameOfBlackAndWhite()
 	executeGameOfLife()
+	executeBriansBrain()
+	executeLangtonsAnt()
+	executeRule30()
+	executeLenia()
+	executeGrayScott()
+	executeSandpile()
+	executeLatticeGas()
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/rule"
+)
+
+var rule30Palette []color.Color = []color.Color{
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+}
+
+func drawRule30Unit(coord *ggol.Coordinate, unit *rule.ColorUnit, blockSize int, image *image.Paletted) {
+	for i := 0; i < blockSize; i += 1 {
+		for j := 0; j < blockSize; j += 1 {
+			image.SetColorIndex(coord.X*blockSize+i, coord.Y*blockSize+j, uint8(unit.Color))
+		}
+	}
+}
+
+func executeRule30() {
+	rule30, _ := rule.NewElementaryRule(rule.RuleElementary30)
+	initialUnits := make([]rule.ColorUnit, 101)
+	game := ggol.NewGame1D(&initialUnits)
+	width := game.GetWidth()
+	historyLength := 60
+	game.SetNextUnitGenerator(rule.NewElementaryNextUnitGenerator(rule30))
+	game.SetHistoryLength(historyLength)
+	game.SetUnit(width/2, &rule.ColorUnit{Color: 1})
+
+	var images []*image.Paletted
+	var delays []int
+	blockSize := 4
+	iterationsCount := 150
+	duration := 0
+
+	for i := 0; i < iterationsCount; i += 1 {
+		// Every frame shows the kept generations, the oldest one is at the top.
+		newImage := image.NewPaletted(image.Rect(0, 0, width*blockSize, historyLength*blockSize), rule30Palette)
+		game.IterateHistory(func(coord *ggol.Coordinate, unit *rule.ColorUnit) {
+			drawRule30Unit(coord, unit, blockSize, newImage)
+		})
+		images = append(images, newImage)
+		delays = append(delays, duration)
+		game.GenerateNextUnits()
+	}
+
+	outputGif("output/rule_30.gif", images, delays)
+}
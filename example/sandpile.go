@@ -0,0 +1,65 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/dum-dum-genius/ggol"
+	"github.com/dum-dum-genius/ggol/rule"
+)
+
+// Colors of 0 to 3 grains, and units having 4 grains or more that are going to topple.
+var sandpilePalette []color.Color = []color.Color{
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+	color.RGBA{0xf2, 0xc1, 0x4e, 0xff},
+	color.RGBA{0xe0, 0x6c, 0x3c, 0xff},
+	color.RGBA{0x5a, 0x2a, 0x6e, 0xff},
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+}
+
+func drawSandpileUnit(coord *ggol.Coordinate, unit *rule.SandpileUnit, blockSize int, image *image.Paletted) {
+	colorIndex := unit.Grains
+	if colorIndex >= len(sandpilePalette) {
+		colorIndex = len(sandpilePalette) - 1
+	}
+	for i := 0; i < blockSize; i += 1 {
+		for j := 0; j < blockSize; j += 1 {
+			image.SetColorIndex(coord.X*blockSize+i, coord.Y*blockSize+j, uint8(colorIndex))
+		}
+	}
+}
+
+func executeSandpile() {
+	size := 81
+	initialUnits := make([][]rule.SandpileUnit, size)
+	for x := 0; x < size; x += 1 {
+		initialUnits[x] = make([]rule.SandpileUnit, size)
+	}
+	game, _ := ggol.NewGame(&initialUnits)
+	game.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	game.SetNextUnitGenerator(rule.NewSandpileNextUnitGenerator())
+	game.SetUnit(&ggol.Coordinate{X: size / 2, Y: size / 2}, &rule.SandpileUnit{Grains: 10000})
+
+	var images []*image.Paletted
+	var delays []int
+	blockSize := 4
+	generationsPerFrame := 100
+	duration := 0
+
+	for {
+		newImage := image.NewPaletted(image.Rect(0, 0, size*blockSize, size*blockSize), sandpilePalette)
+		game.IterateUnits(func(coord *ggol.Coordinate, unit *rule.SandpileUnit) {
+			drawSandpileUnit(coord, unit, blockSize, newImage)
+		})
+		images = append(images, newImage)
+		delays = append(delays, duration)
+		// Stop when the pile doesn't topple anymore.
+		if _, err := game.GenerateNextUnitsUntilStable(generationsPerFrame, ggol.AreUnitsEqual[rule.SandpileUnit]); err == nil {
+			break
+		}
+	}
+	// Keep the stable pile on the screen for a while.
+	delays[len(delays)-1] = 300
+
+	outputGif("output/sandpile.gif", images, delays)
+}
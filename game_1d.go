@@ -0,0 +1,339 @@
+package ggol
+
+import (
+	"fmt"
+	"sync"
+)
+
+// This error will be thrown when you're trying to set or get an unit with invalid X in the 1D game.
+type ErrXIsInvalid struct {
+	X int
+}
+
+// Tell you that X is invalid.
+func (e *ErrXIsInvalid) Error() string {
+	return fmt.Sprintf("X %v is outside the border.", e.X)
+}
+
+// This error will be thrown when the length of history is less than 1.
+type ErrHistoryLengthIsInvalid struct {
+	Length int
+}
+
+// Tell you that the length of history is invalid.
+func (e *ErrHistoryLengthIsInvalid) Error() string {
+	return fmt.Sprintf("History length %v is not valid, it should be at least 1.", e.Length)
+}
+
+// This function will be passed into NextUnit1DGenerator, this is how you can adajcent units in NextUnit1DGenerator.
+// Also, 2nd argument "isCrossBorder" tells you if the adjacent unit is outside the border.
+type AdjacentUnit1DGetter[T any] func(originX int, relativeX int) (unit *T, isCrossBorder bool)
+
+// NextUnit1DGenerator tells the game how you're gonna generate next status of the given unit.
+// The "unit" is reused by the game, so don't keep it after the generator returns.
+type NextUnit1DGenerator[T any] func(x int, unit *T, getAdjacentUnit AdjacentUnit1DGetter[T]) (nextUnit *T)
+
+// UnitsIterator1DCallback will be called when iterating through units.
+type UnitsIterator1DCallback[T any] func(x int, unit *T)
+
+// "T" in the Game1D interface represents the type of unit, it's defined by you.
+// Game1D works the same as Game, but units are in a row, units[x] is the unit at x.
+// It keeps the latest generations as history, so you can render the space-time of the game.
+type Game1D[T any] interface {
+	// Generate next units, the way you generate next units will be depending on the NextUnit1DGenerator function
+	// you passed in SetNextUnitGenerator.
+	GenerateNextUnits() (units *[]T)
+	// Set NextUnit1DGenerator, which tells the game how you want to generate next unit of the given unit.
+	SetNextUnitGenerator(nextUnitGenerator NextUnit1DGenerator[T])
+	// Set how many goroutines GenerateNextUnits can use at most, default is 1.
+	SetConcurrency(concurrency int) (err error)
+	// Set the boundary policy, only BoundaryPolicyWrap, BoundaryPolicyConstant, BoundaryPolicyClamp and BoundaryPolicyMirror are supported.
+	SetBoundaryPolicy(policy BoundaryPolicy) (err error)
+	// Set the unit outside the border when the boundary policy is BoundaryPolicyConstant.
+	SetBoundaryUnit(unit *T)
+	// Set how many generations are kept in history, including the current one, default is 1.
+	SetHistoryLength(length int) (err error)
+	// Set the status of the unit at the given x.
+	SetUnit(x int, unit *T) (err error)
+	// Get the width of the game.
+	GetWidth() (width int)
+	// Get the status of the unit at the given x.
+	GetUnit(x int) (unit *T, err error)
+	// Get all units in the game.
+	GetUnits() (units *[]T)
+	// Get the history as a 2D map, history[x][t] is the unit at x in the t-th kept generation,
+	// the oldest generation is t = 0 and the current one is the last.
+	GetHistory() (history *[][]T)
+	// Iterate through all units in the game
+	IterateUnits(callback UnitsIterator1DCallback[T])
+	// Iterate through the history like a 2D game, the unit at coordinate (x, t) is the unit at x in the t-th kept generation.
+	IterateHistory(callback UnitsIteratorCallback[T])
+}
+
+type game1DInfo[T any] struct {
+	width              int
+	nextUnitGenerator  NextUnit1DGenerator[T]
+	adjacentUnitGetter AdjacentUnit1DGetter[T]
+	concurrency        int
+	boundaryPolicy     BoundaryPolicy
+	boundaryUnit       T
+	historyLength      int
+	// Generations are kept in a ring, the current units are rows[currentRowIndex], and the next units are generated
+	// into the row after it, which is the oldest one. There are at least 2 rows so the game can swap them.
+	rows            [][]T
+	currentRowIndex int
+	rowsCount       int
+	locker          sync.RWMutex
+}
+
+func defaultNextUnit1DGenerator[T any](x int, unit *T, getAdjacentUnit AdjacentUnit1DGetter[T]) (nextUnit *T) {
+	return unit
+}
+
+// Return a new Game1D with the given units.
+func NewGame1D[T any](
+	units *[]T,
+) Game1D[T] {
+	width := len(*units)
+	// Copy units into the ring, so the game never writes next units into the slice of the caller.
+	initialUnits := make([]T, width)
+	copy(initialUnits, *units)
+	newG := game1DInfo[T]{
+		width:             width,
+		nextUnitGenerator: defaultNextUnit1DGenerator[T],
+		concurrency:       1,
+		boundaryPolicy:    BoundaryPolicyWrap,
+		historyLength:     1,
+		rows:              [][]T{initialUnits, make([]T, width)},
+		currentRowIndex:   0,
+		rowsCount:         1,
+		locker:            sync.RWMutex{},
+	}
+	newG.adjacentUnitGetter = newG.getAdjacentUnit
+
+	return &newG
+}
+
+func (g *game1DInfo[T]) isXInvalid(x int) bool {
+	return x < 0 || x >= g.width
+}
+
+func (g *game1DInfo[T]) getAdjacentUnit(originX int, relativeX int) (unit *T, crossBorder bool) {
+	targetX := originX + relativeX
+	if g.isXInvalid(targetX) {
+		if g.boundaryPolicy == BoundaryPolicyConstant {
+			return &g.boundaryUnit, true
+		}
+		return &g.rows[g.currentRowIndex][resolveIndexWithBoundaryPolicy(g.boundaryPolicy, targetX, g.width)], true
+	}
+
+	return &g.rows[g.currentRowIndex][targetX], false
+}
+
+// Generate next units from "fromX" to "toX" (exclusive) into the given row.
+func (g *game1DInfo[T]) generateNextUnitsInSegment(nextUnits []T, fromX int, toX int) {
+	units := g.rows[g.currentRowIndex]
+	for x := fromX; x < toX; x++ {
+		nextUnit := g.nextUnitGenerator(x, &units[x], g.adjacentUnitGetter)
+		nextUnits[x] = *nextUnit
+	}
+}
+
+// Generate next units.
+func (g *game1DInfo[T]) GenerateNextUnits() *[]T {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	nextRowIndex := (g.currentRowIndex + 1) % len(g.rows)
+	nextUnits := g.rows[nextRowIndex]
+
+	workersCount := g.concurrency
+	if workersCount > g.width {
+		workersCount = g.width
+	}
+	if workersCount <= 1 {
+		g.generateNextUnitsInSegment(nextUnits, 0, g.width)
+	} else {
+		// Split the row into segments, every worker takes care of one segment.
+		unitsCountPerWorker := (g.width + workersCount - 1) / workersCount
+		wg := sync.WaitGroup{}
+		for fromX := 0; fromX < g.width; fromX += unitsCountPerWorker {
+			toX := fromX + unitsCountPerWorker
+			if toX > g.width {
+				toX = g.width
+			}
+			wg.Add(1)
+			go func(fromX int, toX int) {
+				defer wg.Done()
+				g.generateNextUnitsInSegment(nextUnits, fromX, toX)
+			}(fromX, toX)
+		}
+		wg.Wait()
+	}
+
+	g.currentRowIndex = nextRowIndex
+	if g.rowsCount < g.historyLength {
+		g.rowsCount += 1
+	}
+
+	return &g.rows[g.currentRowIndex]
+}
+
+func (g *game1DInfo[T]) SetNextUnitGenerator(nextUnitGenerator NextUnit1DGenerator[T]) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.nextUnitGenerator = nextUnitGenerator
+}
+
+// Set the max count of goroutines used to generate next units.
+func (g *game1DInfo[T]) SetConcurrency(concurrency int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if concurrency < 1 {
+		return &ErrConcurrencyIsInvalid{concurrency}
+	}
+	g.concurrency = concurrency
+
+	return nil
+}
+
+// Set the boundary policy, policies that twist the map are only for 2D games.
+func (g *game1DInfo[T]) SetBoundaryPolicy(policy BoundaryPolicy) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if !policy.isValid() {
+		return &ErrBoundaryPolicyIsInvalid{policy}
+	}
+	if policy == BoundaryPolicyKleinBottle || policy == BoundaryPolicyProjectivePlane {
+		return &ErrBoundaryPolicyIsNotSupported{}
+	}
+	g.boundaryPolicy = policy
+
+	return nil
+}
+
+// Set the unit outside the border for BoundaryPolicyConstant.
+func (g *game1DInfo[T]) SetBoundaryUnit(unit *T) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.boundaryUnit = *unit
+}
+
+// Set how many generations are kept, the latest generations already kept stay in history.
+func (g *game1DInfo[T]) SetHistoryLength(length int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if length < 1 {
+		return &ErrHistoryLengthIsInvalid{length}
+	}
+
+	rowsCount := g.rowsCount
+	if rowsCount > length {
+		rowsCount = length
+	}
+	rows := make([][]T, length)
+	if length < 2 {
+		rows = make([][]T, 2)
+	}
+	// Move kept generations to the front of the new ring, from the oldest to the current one.
+	for t := 0; t < rowsCount; t++ {
+		rows[t] = g.rows[(g.currentRowIndex-rowsCount+1+t+len(g.rows))%len(g.rows)]
+	}
+	for t := rowsCount; t < len(rows); t++ {
+		rows[t] = make([]T, g.width)
+	}
+	g.rows = rows
+	g.currentRowIndex = rowsCount - 1
+	g.rowsCount = rowsCount
+	g.historyLength = length
+
+	return nil
+}
+
+// Update the unit at the given x.
+func (g *game1DInfo[T]) SetUnit(x int, unit *T) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if g.isXInvalid(x) {
+		return &ErrXIsInvalid{x}
+	}
+	g.rows[g.currentRowIndex][x] = *unit
+
+	return nil
+}
+
+// Get the game width.
+func (g *game1DInfo[T]) GetWidth() int {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.width
+}
+
+// Get the unit at the given x.
+func (g *game1DInfo[T]) GetUnit(x int) (*T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	if g.isXInvalid(x) {
+		return nil, &ErrXIsInvalid{x}
+	}
+
+	return &g.rows[g.currentRowIndex][x], nil
+}
+
+// Get all units in the game
+func (g *game1DInfo[T]) GetUnits() *[]T {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return &g.rows[g.currentRowIndex]
+}
+
+// Get the row of the t-th kept generation, the oldest one is t = 0.
+func (g *game1DInfo[T]) getHistoryRow(t int) []T {
+	return g.rows[(g.currentRowIndex-g.rowsCount+1+t+len(g.rows))%len(g.rows)]
+}
+
+// Get a copy of the history, history[x][t] is the unit at x in the t-th kept generation.
+func (g *game1DInfo[T]) GetHistory() *[][]T {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	history := make([][]T, g.width)
+	for x := 0; x < g.width; x++ {
+		history[x] = make([]T, g.rowsCount)
+	}
+	for t := 0; t < g.rowsCount; t++ {
+		row := g.getHistoryRow(t)
+		for x := 0; x < g.width; x++ {
+			history[x][t] = row[x]
+		}
+	}
+
+	return &history
+}
+
+// We will iterate all units in the game and call the callbacks with x and unit.
+func (g *game1DInfo[T]) IterateUnits(callback UnitsIterator1DCallback[T]) {
+	units := g.rows[g.currentRowIndex]
+	for x := 0; x < g.width; x++ {
+		callback(x, &units[x])
+	}
+}
+
+// We will iterate the history from the oldest generation and call the callbacks with coordinate (x, t) and unit.
+func (g *game1DInfo[T]) IterateHistory(callback UnitsIteratorCallback[T]) {
+	for t := 0; t < g.rowsCount; t++ {
+		row := g.getHistoryRow(t)
+		for x := 0; x < g.width; x++ {
+			callback(&Coordinate{X: x, Y: t}, &row[x])
+		}
+	}
+}
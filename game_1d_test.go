@@ -0,0 +1,238 @@
+package ggol
+
+import (
+	"testing"
+)
+
+func generateInitialUnitRowForTest(width int, unit unitForTest) *[]unitForTest {
+	units := make([]unitForTest, width)
+	for x := 0; x < width; x += 1 {
+		units[x] = unit
+	}
+	return &units
+}
+
+// Rule 90, a unit becomes alive when exactly one of its left and right units is alive.
+func rule90UnitForTest1DIterator(x int, unit *unitForTest, getAdjacentUnit AdjacentUnit1DGetter[unitForTest]) *unitForTest {
+	leftUnit, _ := getAdjacentUnit(x, -1)
+	rightUnit, _ := getAdjacentUnit(x, 1)
+	if leftUnit.hasLiveCell != rightUnit.hasLiveCell {
+		return &liveUnitForTest
+	}
+	return &deadUnitForTest
+}
+
+func testNewGame1DCaseOne(t *testing.T) {
+	// The game keeps its own copy of initial units, generating and setting units never touch the slice of the caller.
+	initialUnits := generateInitialUnitRowForTest(5, initialUnitForTest)
+	g := NewGame1D(initialUnits)
+	g.SetNextUnitGenerator(rule90UnitForTest1DIterator)
+	g.SetUnit(2, &unitForTest{hasLiveCell: true})
+	g.SetHistoryLength(1)
+	for i := 0; i < 3; i++ {
+		g.GenerateNextUnits()
+	}
+	for x, unit := range *initialUnits {
+		if unit.hasLiveCell {
+			t.Fatalf("Initial unit at %v should not be changed by the game.", x)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNewGame1D(t *testing.T) {
+	testNewGame1DCaseOne(t)
+}
+
+func testGame1DGenerateNextUnitsCaseOne(t *testing.T) {
+	g := NewGame1D(generateInitialUnitRowForTest(7, initialUnitForTest))
+	g.SetNextUnitGenerator(rule90UnitForTest1DIterator)
+	g.SetUnit(3, &unitForTest{hasLiveCell: true})
+
+	expectedRows := []string{".......", "..#.#..", ".#...#.", "#.#.#.#"}
+	for i := 1; i < len(expectedRows); i++ {
+		g.GenerateNextUnits()
+		g.IterateUnits(func(x int, unit *unitForTest) {
+			if unit.hasLiveCell != (expectedRows[i][x] == '#') {
+				t.Fatalf("Generation %v: unit at %v should be alive: %v.", i, x, expectedRows[i][x] == '#')
+			}
+		})
+	}
+	t.Log("Passed")
+}
+
+func testGame1DGenerateNextUnitsCaseTwo(t *testing.T) {
+	serialGame := NewGame1D(generateInitialUnitRowForTest(37, initialUnitForTest))
+	serialGame.SetNextUnitGenerator(rule90UnitForTest1DIterator)
+	parallelGame := NewGame1D(generateInitialUnitRowForTest(37, initialUnitForTest))
+	parallelGame.SetNextUnitGenerator(rule90UnitForTest1DIterator)
+	parallelGame.SetConcurrency(4)
+
+	for _, g := range []Game1D[unitForTest]{serialGame, parallelGame} {
+		g.SetBoundaryPolicy(BoundaryPolicyMirror)
+		g.IterateUnits(func(x int, unit *unitForTest) {
+			unit.hasLiveCell = x%3 == 0 || x%7 == 0
+		})
+	}
+
+	for i := 0; i < 20; i++ {
+		serialUnits := *serialGame.GenerateNextUnits()
+		parallelUnits := *parallelGame.GenerateNextUnits()
+		for x := range serialUnits {
+			if serialUnits[x] != parallelUnits[x] {
+				t.Fatalf("Generation %v in parallel is not identical to the serial one at %v.", i+1, x)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGame1DGenerateNextUnitsCaseThree(t *testing.T) {
+	g := NewGame1D(generateInitialUnitRowForTest(64, initialUnitForTest))
+	g.SetNextUnitGenerator(rule90UnitForTest1DIterator)
+	g.SetHistoryLength(16)
+	g.SetUnit(32, &unitForTest{hasLiveCell: true})
+	for i := 0; i < 20; i++ {
+		g.GenerateNextUnits()
+	}
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestGame1DGenerateNextUnits(t *testing.T) {
+	testGame1DGenerateNextUnitsCaseOne(t)
+	testGame1DGenerateNextUnitsCaseTwo(t)
+	testGame1DGenerateNextUnitsCaseThree(t)
+}
+
+func testGame1DSetBoundaryPolicyCaseOne(t *testing.T) {
+	g := NewGame1D(generateInitialUnitRowForTest(3, initialUnitForTest))
+	if err := g.SetBoundaryPolicy(BoundaryPolicyKleinBottle); err == nil {
+		t.Fatalf("Should get error when setting a boundary policy only for 2D games.")
+	}
+
+	g.SetUnit(0, &unitForTest{hasLiveCell: true})
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	g.SetBoundaryUnit(&unitForTest{hasLiveCell: true})
+	unit, isCrossBorder := g.(*game1DInfo[unitForTest]).getAdjacentUnit(2, 1)
+	if !unit.hasLiveCell || !isCrossBorder {
+		t.Fatalf("Should get boundary unit outside the border.")
+	}
+
+	g.SetBoundaryPolicy(BoundaryPolicyWrap)
+	g.SetBoundaryUnit(&unitForTest{hasLiveCell: false})
+	unit, isCrossBorder = g.(*game1DInfo[unitForTest]).getAdjacentUnit(2, 1)
+	if !unit.hasLiveCell || !isCrossBorder {
+		t.Fatalf("Should get the unit on the other side of the map.")
+	}
+	t.Log("Passed")
+}
+
+func TestGame1DSetBoundaryPolicy(t *testing.T) {
+	testGame1DSetBoundaryPolicyCaseOne(t)
+}
+
+func testGame1DSetUnitCaseOne(t *testing.T) {
+	g := NewGame1D(generateInitialUnitRowForTest(3, initialUnitForTest))
+	g.SetUnit(1, &unitForTest{hasLiveCell: true})
+	unit, _ := g.GetUnit(1)
+	if !unit.hasLiveCell {
+		t.Fatalf("Should correctly set unit.")
+	}
+	if err := g.SetUnit(3, &unitForTest{}); err == nil {
+		t.Fatalf("Should get error when x is outside the game map.")
+	}
+	if _, err := g.GetUnit(-1); err == nil {
+		t.Fatalf("Should get error when x is outside the game map.")
+	}
+	t.Log("Passed")
+}
+
+func TestGame1DSetUnit(t *testing.T) {
+	testGame1DSetUnitCaseOne(t)
+}
+
+func testGame1DGetHistoryCaseOne(t *testing.T) {
+	g := NewGame1D(generateInitialUnitRowForTest(7, initialUnitForTest))
+	g.SetNextUnitGenerator(rule90UnitForTest1DIterator)
+	if err := g.SetHistoryLength(0); err == nil {
+		t.Fatalf("Should get error when history length is less than 1.")
+	}
+	g.SetHistoryLength(3)
+	g.SetUnit(3, &unitForTest{hasLiveCell: true})
+
+	history := *g.GetHistory()
+	if len(history) != 7 || len(history[0]) != 1 || !history[3][0].hasLiveCell {
+		t.Fatalf("History should only have the current generation before generating.")
+	}
+
+	// After 3 generations, the history keeps generation 1 to 3.
+	g.GenerateNextUnits()
+	g.GenerateNextUnits()
+	g.GenerateNextUnits()
+	expectedRows := []string{"..#.#..", ".#...#.", "#.#.#.#"}
+	history = *g.GetHistory()
+	if len(history) != 7 || len(history[0]) != 3 {
+		t.Fatalf("History should be 7 x 3, but got %v x %v.", len(history), len(history[0]))
+	}
+	for x := range history {
+		for i := range history[x] {
+			if history[x][i].hasLiveCell != (expectedRows[i][x] == '#') {
+				t.Fatalf("Unit at (%v, %v) of history should be alive: %v.", x, i, expectedRows[i][x] == '#')
+			}
+		}
+	}
+
+	iteratedUnitsCount := 0
+	g.IterateHistory(func(coord *Coordinate, unit *unitForTest) {
+		iteratedUnitsCount += 1
+		if unit.hasLiveCell != history[coord.X][coord.Y].hasLiveCell {
+			t.Fatalf("Unit at %v of history is not iterated correctly.", coord)
+		}
+	})
+	if iteratedUnitsCount != 21 {
+		t.Fatalf("Should iterate 21 units of history, but got %v.", iteratedUnitsCount)
+	}
+	t.Log("Passed")
+}
+
+func testGame1DGetHistoryCaseTwo(t *testing.T) {
+	g := NewGame1D(generateInitialUnitRowForTest(7, initialUnitForTest))
+	g.SetNextUnitGenerator(rule90UnitForTest1DIterator)
+	g.SetHistoryLength(4)
+	g.SetUnit(3, &unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+	g.GenerateNextUnits()
+
+	// Shrinking history keeps the latest generations.
+	g.SetHistoryLength(2)
+	history := *g.GetHistory()
+	if len(history[0]) != 2 || !history[2][0].hasLiveCell || !history[1][1].hasLiveCell {
+		t.Fatalf("Should keep generation 1 and 2 after shrinking history.")
+	}
+
+	// Growing history keeps what we have, and more generations are kept from now on.
+	g.SetHistoryLength(5)
+	for i := 0; i < 4; i++ {
+		g.GenerateNextUnits()
+	}
+	history = *g.GetHistory()
+	if len(history[0]) != 5 || !history[1][0].hasLiveCell || history[2][0].hasLiveCell {
+		t.Fatalf("Should keep generation 2 to 6 after growing history.")
+	}
+	if units := *g.GetUnits(); units[3] != history[3][4] {
+		t.Fatalf("The last generation of history should be the current units.")
+	}
+	t.Log("Passed")
+}
+
+func TestGame1DGetHistory(t *testing.T) {
+	testGame1DGetHistoryCaseOne(t)
+	testGame1DGetHistoryCaseTwo(t)
+}
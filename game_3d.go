@@ -0,0 +1,412 @@
+package ggol
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Coordniate3D tells you the position of an unit in the 3D game.
+type Coordinate3D struct {
+	X int
+	Y int
+	Z int
+}
+
+// Area3D indicates a cuboid within two coordinates.
+type Area3D struct {
+	From Coordinate3D
+	To   Coordinate3D
+}
+
+// The size of the 3D game.
+type Size3D struct {
+	Width  int
+	Height int
+	Depth  int
+}
+
+// This error will be thrown when you're trying to set or get an unit with invalid 3D coordinate.
+type ErrCoordinate3DIsInvalid struct {
+	Coordinate *Coordinate3D
+}
+
+// Tell you that the coordinate is invalid.
+func (e *ErrCoordinate3DIsInvalid) Error() string {
+	return fmt.Sprintf("Coordinate (%v, %v, %v) is outside the border.", e.Coordinate.X, e.Coordinate.Y, e.Coordinate.Z)
+}
+
+// This error will be thrown when X, Y or Z of "from coordinate" is greater than the one of "to coordinate" in the area.
+type ErrArea3DIsInvalid struct {
+	Area *Area3D
+}
+
+func (e *ErrArea3DIsInvalid) Error() string {
+	return fmt.Sprintf(
+		"Area with from coordinate (%v, %v, %v) and end coordiante (%v, %v, %v) is not valid.",
+		e.Area.From.X, e.Area.From.Y, e.Area.From.Z, e.Area.To.X, e.Area.To.Y, e.Area.To.Z,
+	)
+}
+
+// This function will be passed into NextUnit3DGenerator, this is how you can adajcent units in NextUnit3DGenerator.
+// Also, 2nd argument "isCrossBorder" tells you if the adjacent unit is outside the border.
+type AdjacentUnit3DGetter[T any] func(originCoord *Coordinate3D, relativeCoord *Coordinate3D) (unit *T, isCrossBorder bool)
+
+// NextUnit3DGenerator tells the game how you're gonna generate next status of the given unit.
+// The "coord" and "unit" are reused by the game, so don't keep them after the generator returns.
+type NextUnit3DGenerator[T any] func(coord *Coordinate3D, unit *T, getAdjacentUnit AdjacentUnit3DGetter[T]) (nextUnit *T)
+
+// UnitsIterator3DCallback will be called when iterating through units.
+type UnitsIterator3DCallback[T any] func(coord *Coordinate3D, unit *T)
+
+// "T" in the Game3D interface represents the type of unit, it's defined by you.
+// Game3D works the same as Game, but units are in a 3D space, units[x][y][z] is the unit at (x, y, z).
+type Game3D[T any] interface {
+	// Generate next units, the way you generate next units will be depending on the NextUnit3DGenerator function
+	// you passed in SetNextUnitGenerator. Like Game, buffers of units are swapped in every generation, so always read current units with GetUnits.
+	GenerateNextUnits() (units *[][][]T)
+	// Set NextUnit3DGenerator, which tells the game how you want to generate next unit of the given unit.
+	SetNextUnitGenerator(nextUnitGenerator NextUnit3DGenerator[T])
+	// Set how many goroutines GenerateNextUnits can use at most, default is 1.
+	SetConcurrency(concurrency int) (err error)
+	// Set the boundary policy, only BoundaryPolicyWrap, BoundaryPolicyConstant, BoundaryPolicyClamp and BoundaryPolicyMirror are supported.
+	SetBoundaryPolicy(policy BoundaryPolicy) (err error)
+	// Set the unit outside the border when the boundary policy is BoundaryPolicyConstant.
+	SetBoundaryUnit(unit *T)
+	// Set the status of the unit at the given coordinate.
+	SetUnit(coord *Coordinate3D, unit *T) (err error)
+	// Get the size of the game.
+	GetSize() (size *Size3D)
+	// Get the status of the unit at the given coordinate.
+	GetUnit(coord *Coordinate3D) (unit *T, err error)
+	// Get all units in the area.
+	GetUnitsInArea(area *Area3D) (units *[][][]T, err error)
+	// Get all units in the game, they're current units until the next generation.
+	GetUnits() (units *[][][]T)
+	// Iterate through units in the given area.
+	IterateUnitsInArea(area *Area3D, callback UnitsIterator3DCallback[T]) (err error)
+	// Iterate through all units in the game
+	IterateUnits(callback UnitsIterator3DCallback[T])
+}
+
+type game3DInfo[T any] struct {
+	size               *Size3D
+	units              *[][][]T
+	nextUnits          *[][][]T
+	nextUnitGenerator  NextUnit3DGenerator[T]
+	adjacentUnitGetter AdjacentUnit3DGetter[T]
+	concurrency        int
+	boundaryPolicy     BoundaryPolicy
+	boundaryUnit       T
+	workerCoords       []Coordinate3D
+	locker             sync.RWMutex
+}
+
+func defaultNextUnit3DGenerator[T any](coord *Coordinate3D, unit *T, getAdjacentUnit AdjacentUnit3DGetter[T]) (nextUnit *T) {
+	return unit
+}
+
+// Return a new Game3D with the given units.
+func NewGame3D[T any](
+	units *[][][]T,
+) (Game3D[T], error) {
+	size, err := calculateSize3DFromUnits(units)
+	if err != nil {
+		return nil, err
+	}
+
+	newG := game3DInfo[T]{
+		size:              size,
+		units:             units,
+		nextUnits:         generateUnits3DBuffer[T](size),
+		nextUnitGenerator: defaultNextUnit3DGenerator[T],
+		concurrency:       1,
+		boundaryPolicy:    BoundaryPolicyWrap,
+		workerCoords:      make([]Coordinate3D, 1),
+		locker:            sync.RWMutex{},
+	}
+	newG.adjacentUnitGetter = newG.getAdjacentUnit
+
+	return &newG, nil
+}
+
+func calculateSize3DFromUnits[T any](units *[][][]T) (*Size3D, error) {
+	width := len(*units)
+	var height int = 0
+	var depth int = 0
+	for x := 0; x < width; x++ {
+		newHeight := len((*units)[x])
+		if x != 0 && height != newHeight {
+			return nil, &ErrUnitsIsInvalid{}
+		}
+		height = newHeight
+		for y := 0; y < height; y++ {
+			newDepth := len((*units)[x][y])
+			if (x != 0 || y != 0) && depth != newDepth {
+				return nil, &ErrUnitsIsInvalid{}
+			}
+			depth = newDepth
+		}
+	}
+	return &Size3D{
+		Width:  width,
+		Height: height,
+		Depth:  depth,
+	}, nil
+}
+
+func generateUnits3DBuffer[T any](size *Size3D) *[][][]T {
+	units := make([][][]T, size.Width)
+	for x := 0; x < size.Width; x++ {
+		units[x] = make([][]T, size.Height)
+		for y := 0; y < size.Height; y++ {
+			units[x][y] = make([]T, size.Depth)
+		}
+	}
+	return &units
+}
+
+func (g *game3DInfo[T]) isCoordinateInvalid(c *Coordinate3D) bool {
+	return c.X < 0 || c.X >= g.size.Width || c.Y < 0 || c.Y >= g.size.Height || c.Z < 0 || c.Z >= g.size.Depth
+}
+
+func (g *game3DInfo[T]) isAreaInvalid(area *Area3D) bool {
+	return area.From.X > area.To.X || area.From.Y > area.To.Y || area.From.Z > area.To.Z
+}
+
+// Resolve one axis of the coordinate outside the border.
+func resolveIndexWithBoundaryPolicy(policy BoundaryPolicy, a int, size int) int {
+	switch policy {
+	case BoundaryPolicyClamp:
+		return clampIndex(a, size)
+	case BoundaryPolicyMirror:
+		return mirrorIndex(a, size)
+	default:
+		return floorModulo(a, size)
+	}
+}
+
+func (g *game3DInfo[T]) getAdjacentUnit(
+	originCoord *Coordinate3D,
+	relativeCoord *Coordinate3D,
+) (unit *T, crossBorder bool) {
+	targetX := originCoord.X + relativeCoord.X
+	targetY := originCoord.Y + relativeCoord.Y
+	targetZ := originCoord.Z + relativeCoord.Z
+
+	if (g.isCoordinateInvalid(&Coordinate3D{X: targetX, Y: targetY, Z: targetZ})) {
+		if g.boundaryPolicy == BoundaryPolicyConstant {
+			return &g.boundaryUnit, true
+		}
+		targetX = resolveIndexWithBoundaryPolicy(g.boundaryPolicy, targetX, g.size.Width)
+		targetY = resolveIndexWithBoundaryPolicy(g.boundaryPolicy, targetY, g.size.Height)
+		targetZ = resolveIndexWithBoundaryPolicy(g.boundaryPolicy, targetZ, g.size.Depth)
+		return &(*g.units)[targetX][targetY][targetZ], true
+	}
+
+	return &(*g.units)[targetX][targetY][targetZ], false
+}
+
+// Generate next units of the slices from "fromX" to "toX" (exclusive) with the coordinate of the given worker.
+func (g *game3DInfo[T]) generateNextUnitsInSlices(worker int, fromX int, toX int) {
+	coord := &g.workerCoords[worker]
+	for x := fromX; x < toX; x++ {
+		for y := 0; y < g.size.Height; y++ {
+			for z := 0; z < g.size.Depth; z++ {
+				coord.X = x
+				coord.Y = y
+				coord.Z = z
+				nextUnit := g.nextUnitGenerator(coord, &(*g.units)[x][y][z], g.adjacentUnitGetter)
+				(*g.nextUnits)[x][y][z] = *nextUnit
+			}
+		}
+	}
+}
+
+// Generate next units.
+func (g *game3DInfo[T]) GenerateNextUnits() *[][][]T {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	workersCount := g.concurrency
+	if workersCount > g.size.Width {
+		workersCount = g.size.Width
+	}
+	if workersCount <= 1 {
+		g.generateNextUnitsInSlices(0, 0, g.size.Width)
+	} else {
+		// Split the space into slices along X, every worker takes care of some slices.
+		slicesCountPerWorker := (g.size.Width + workersCount - 1) / workersCount
+		wg := sync.WaitGroup{}
+		for worker := 0; worker*slicesCountPerWorker < g.size.Width; worker++ {
+			fromX := worker * slicesCountPerWorker
+			toX := fromX + slicesCountPerWorker
+			if toX > g.size.Width {
+				toX = g.size.Width
+			}
+			wg.Add(1)
+			go func(worker int, fromX int, toX int) {
+				defer wg.Done()
+				g.generateNextUnitsInSlices(worker, fromX, toX)
+			}(worker, fromX, toX)
+		}
+		wg.Wait()
+	}
+
+	// Swap the buffers, so the next units become current units without copying.
+	g.units, g.nextUnits = g.nextUnits, g.units
+
+	return g.units
+}
+
+func (g *game3DInfo[T]) SetNextUnitGenerator(nextUnitGenerator NextUnit3DGenerator[T]) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.nextUnitGenerator = nextUnitGenerator
+}
+
+// Set the max count of goroutines used to generate next units.
+func (g *game3DInfo[T]) SetConcurrency(concurrency int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if concurrency < 1 {
+		return &ErrConcurrencyIsInvalid{concurrency}
+	}
+	g.concurrency = concurrency
+	g.workerCoords = make([]Coordinate3D, concurrency)
+
+	return nil
+}
+
+// Set the boundary policy, policies that twist the map are only for 2D games.
+func (g *game3DInfo[T]) SetBoundaryPolicy(policy BoundaryPolicy) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if !policy.isValid() {
+		return &ErrBoundaryPolicyIsInvalid{policy}
+	}
+	if policy == BoundaryPolicyKleinBottle || policy == BoundaryPolicyProjectivePlane {
+		return &ErrBoundaryPolicyIsNotSupported{}
+	}
+	g.boundaryPolicy = policy
+
+	return nil
+}
+
+// Set the unit outside the border for BoundaryPolicyConstant.
+func (g *game3DInfo[T]) SetBoundaryUnit(unit *T) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.boundaryUnit = *unit
+}
+
+// Update the unit at the given coordinate.
+func (g *game3DInfo[T]) SetUnit(c *Coordinate3D, unit *T) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if g.isCoordinateInvalid(c) {
+		return &ErrCoordinate3DIsInvalid{c}
+	}
+	(*g.units)[c.X][c.Y][c.Z] = *unit
+
+	return nil
+}
+
+// Get the game size.
+func (g *game3DInfo[T]) GetSize() *Size3D {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.size
+}
+
+// Get the unit at the coordinate.
+func (g *game3DInfo[T]) GetUnit(c *Coordinate3D) (*T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	if g.isCoordinateInvalid(c) {
+		return nil, &ErrCoordinate3DIsInvalid{c}
+	}
+
+	return &(*g.units)[c.X][c.Y][c.Z], nil
+}
+
+// Get all units in the game
+func (g *game3DInfo[T]) GetUnits() *[][][]T {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+	return g.units
+}
+
+// Get all units in the given area.
+func (g *game3DInfo[T]) GetUnitsInArea(area *Area3D) (*[][][]T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	if g.isCoordinateInvalid(&area.From) {
+		return nil, &ErrCoordinate3DIsInvalid{&area.From}
+	}
+
+	if g.isCoordinateInvalid(&area.To) {
+		return nil, &ErrCoordinate3DIsInvalid{&area.To}
+	}
+
+	if g.isAreaInvalid(area) {
+		return nil, &ErrArea3DIsInvalid{area}
+	}
+
+	unitsInArea := make([][][]T, 0)
+	for x := area.From.X; x <= area.To.X; x++ {
+		newSlice := make([][]T, 0)
+		for y := area.From.Y; y <= area.To.Y; y++ {
+			newRow := make([]T, 0)
+			for z := area.From.Z; z <= area.To.Z; z++ {
+				newRow = append(newRow, (*g.units)[x][y][z])
+			}
+			newSlice = append(newSlice, newRow)
+		}
+		unitsInArea = append(unitsInArea, newSlice)
+	}
+
+	return &unitsInArea, nil
+}
+
+// We will iterate all units in the game and call the callbacks with coordiante and unit.
+func (g *game3DInfo[T]) IterateUnits(callback UnitsIterator3DCallback[T]) {
+	for x := 0; x < g.size.Width; x++ {
+		for y := 0; y < g.size.Height; y++ {
+			for z := 0; z < g.size.Depth; z++ {
+				callback(&Coordinate3D{X: x, Y: y, Z: z}, &(*g.units)[x][y][z])
+			}
+		}
+	}
+}
+
+// We will iterate all units in the given area and call the callbacks with coordiante and unit.
+func (g *game3DInfo[T]) IterateUnitsInArea(area *Area3D, callback UnitsIterator3DCallback[T]) error {
+	if g.isCoordinateInvalid(&area.From) {
+		return &ErrCoordinate3DIsInvalid{&area.From}
+	}
+
+	if g.isCoordinateInvalid(&area.To) {
+		return &ErrCoordinate3DIsInvalid{&area.To}
+	}
+
+	if g.isAreaInvalid(area) {
+		return &ErrArea3DIsInvalid{area}
+	}
+
+	for x := area.From.X; x <= area.To.X; x++ {
+		for y := area.From.Y; y <= area.To.Y; y++ {
+			for z := area.From.Z; z <= area.To.Z; z++ {
+				callback(&Coordinate3D{X: x, Y: y, Z: z}, &(*g.units)[x][y][z])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package ggol
+
+import (
+	"testing"
+)
+
+func generateInitialUnit3DMatrixForTest(width int, height int, depth int, unit unitForTest) *[][][]unitForTest {
+	unitMatrix := make([][][]unitForTest, width)
+	for x := 0; x < width; x += 1 {
+		unitMatrix[x] = make([][]unitForTest, height)
+		for y := 0; y < height; y += 1 {
+			unitMatrix[x][y] = make([]unitForTest, depth)
+			for z := 0; z < depth; z += 1 {
+				unitMatrix[x][y][z] = unit
+			}
+		}
+	}
+
+	return &unitMatrix
+}
+
+var faceAdjacentCoords3DForTest []Coordinate3D = []Coordinate3D{
+	{X: -1, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0},
+	{X: 0, Y: -1, Z: 0}, {X: 0, Y: 1, Z: 0},
+	{X: 0, Y: 0, Z: -1}, {X: 0, Y: 0, Z: 1},
+}
+
+// A unit becomes alive when exactly one of the 6 units sharing a face with it is alive.
+func unitForTest3DIterator(coord *Coordinate3D, unit *unitForTest, getAdjacentUnit AdjacentUnit3DGetter[unitForTest]) *unitForTest {
+	aliveAdjacentCellsCount := 0
+	for i := range faceAdjacentCoords3DForTest {
+		adjUnit, _ := getAdjacentUnit(coord, &faceAdjacentCoords3DForTest[i])
+		if adjUnit.hasLiveCell {
+			aliveAdjacentCellsCount += 1
+		}
+	}
+	if aliveAdjacentCellsCount == 1 {
+		return &liveUnitForTest
+	}
+	return &deadUnitForTest
+}
+
+// 3D Life with rule 4555, a dead unit becomes alive with 5 live adjacent units, a live unit survives with 4 or 5.
+func life4555UnitForTest3DIterator(coord *Coordinate3D, unit *unitForTest, getAdjacentUnit AdjacentUnit3DGetter[unitForTest]) *unitForTest {
+	aliveAdjacentCellsCount := 0
+	for i := -1; i < 2; i++ {
+		for j := -1; j < 2; j++ {
+			for k := -1; k < 2; k++ {
+				if i == 0 && j == 0 && k == 0 {
+					continue
+				}
+				adjUnit, _ := getAdjacentUnit(coord, &Coordinate3D{X: i, Y: j, Z: k})
+				if adjUnit.hasLiveCell {
+					aliveAdjacentCellsCount += 1
+				}
+			}
+		}
+	}
+	if aliveAdjacentCellsCount == 5 || (unit.hasLiveCell && aliveAdjacentCellsCount == 4) {
+		return &liveUnitForTest
+	}
+	return &deadUnitForTest
+}
+
+func testNewGame3DCaseOne(t *testing.T) {
+	g, _ := NewGame3D(generateInitialUnit3DMatrixForTest(3, 4, 5, initialUnitForTest))
+	size := g.GetSize()
+	if size.Width != 3 || size.Height != 4 || size.Depth != 5 {
+		t.Fatalf("Size should be 3 x 4 x 5, but got %v.", size)
+	}
+
+	units := make([][][]unitForTest, 2)
+	units[0] = [][]unitForTest{{{}, {}}}
+	units[1] = [][]unitForTest{{{}}}
+	if _, err := NewGame3D(&units); err == nil {
+		t.Fatalf("Should get error when giving invalid units.")
+	}
+	t.Log("Passed")
+}
+
+func TestNewGame3D(t *testing.T) {
+	testNewGame3DCaseOne(t)
+}
+
+func testGame3DGenerateNextUnitsCaseOne(t *testing.T) {
+	g, _ := NewGame3D(generateInitialUnit3DMatrixForTest(5, 5, 5, initialUnitForTest))
+	g.SetNextUnitGenerator(unitForTest3DIterator)
+	g.SetUnit(&Coordinate3D{X: 0, Y: 2, Z: 2}, &unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+
+	expectedLiveCoords := map[Coordinate3D]bool{
+		{X: 4, Y: 2, Z: 2}: true, {X: 1, Y: 2, Z: 2}: true,
+		{X: 0, Y: 1, Z: 2}: true, {X: 0, Y: 3, Z: 2}: true,
+		{X: 0, Y: 2, Z: 1}: true, {X: 0, Y: 2, Z: 3}: true,
+	}
+	g.IterateUnits(func(coord *Coordinate3D, unit *unitForTest) {
+		if unit.hasLiveCell != expectedLiveCoords[*coord] {
+			t.Fatalf("Unit at %v should be alive: %v.", coord, expectedLiveCoords[*coord])
+		}
+	})
+	t.Log("Passed")
+}
+
+func testGame3DGenerateNextUnitsCaseTwo(t *testing.T) {
+	serialGame, _ := NewGame3D(generateInitialUnit3DMatrixForTest(9, 7, 6, initialUnitForTest))
+	serialGame.SetNextUnitGenerator(life4555UnitForTest3DIterator)
+	parallelGame, _ := NewGame3D(generateInitialUnit3DMatrixForTest(9, 7, 6, initialUnitForTest))
+	parallelGame.SetNextUnitGenerator(life4555UnitForTest3DIterator)
+	parallelGame.SetConcurrency(4)
+
+	for _, g := range []Game3D[unitForTest]{serialGame, parallelGame} {
+		g.SetBoundaryPolicy(BoundaryPolicyConstant)
+		g.IterateUnits(func(coord *Coordinate3D, unit *unitForTest) {
+			unit.hasLiveCell = (coord.X*3+coord.Y*5+coord.Z*7)%4 == 0
+		})
+	}
+
+	for i := 0; i < 5; i++ {
+		serialUnits := *serialGame.GenerateNextUnits()
+		parallelUnits := *parallelGame.GenerateNextUnits()
+		for x := range serialUnits {
+			for y := range serialUnits[x] {
+				for z := range serialUnits[x][y] {
+					if serialUnits[x][y][z] != parallelUnits[x][y][z] {
+						t.Fatalf("Generation %v in parallel is not identical to the serial one at (%v, %v, %v).", i+1, x, y, z)
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGame3DGenerateNextUnitsCaseThree(t *testing.T) {
+	g, _ := NewGame3D(generateInitialUnit3DMatrixForTest(5, 5, 5, initialUnitForTest))
+	g.SetNextUnitGenerator(unitForTest3DIterator)
+	g.SetUnit(&Coordinate3D{X: 2, Y: 2, Z: 2}, &unitForTest{hasLiveCell: true})
+
+	for i := 0; i < 2; i++ {
+		if g.GenerateNextUnits() != g.GetUnits() {
+			t.Fatalf("Units of generation %v should be the same as units got from GetUnits.", i+1)
+		}
+	}
+	// The live unit spreads to its 6 face adjacent units, and they spread to units 2 steps away in the second generation.
+	units := g.GetUnits()
+	if (*units)[2][2][2].hasLiveCell || (*units)[3][2][2].hasLiveCell || !(*units)[4][2][2].hasLiveCell {
+		t.Fatalf("Units got from GetUnits should show the current generation.")
+	}
+	t.Log("Passed")
+}
+
+func TestGame3DGenerateNextUnits(t *testing.T) {
+	testGame3DGenerateNextUnitsCaseOne(t)
+	testGame3DGenerateNextUnitsCaseTwo(t)
+	testGame3DGenerateNextUnitsCaseThree(t)
+}
+
+func testGame3DSetBoundaryPolicyCaseOne(t *testing.T) {
+	g, _ := NewGame3D(generateInitialUnit3DMatrixForTest(2, 2, 2, initialUnitForTest))
+	if err := g.SetBoundaryPolicy(BoundaryPolicyKleinBottle); err == nil {
+		t.Fatalf("Should get error when setting a boundary policy only for 2D games.")
+	}
+
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	g.SetBoundaryUnit(&unitForTest{hasLiveCell: true})
+	unit, isCrossBorder := g.(*game3DInfo[unitForTest]).getAdjacentUnit(&Coordinate3D{X: 1, Y: 1, Z: 1}, &Coordinate3D{X: 0, Y: 0, Z: 1})
+	if !unit.hasLiveCell || !isCrossBorder {
+		t.Fatalf("Should get boundary unit outside the border.")
+	}
+	t.Log("Passed")
+}
+
+func TestGame3DSetBoundaryPolicy(t *testing.T) {
+	testGame3DSetBoundaryPolicyCaseOne(t)
+}
+
+func testGame3DSetUnitCaseOne(t *testing.T) {
+	g, _ := NewGame3D(generateInitialUnit3DMatrixForTest(2, 2, 2, initialUnitForTest))
+	c := Coordinate3D{X: 1, Y: 0, Z: 1}
+	g.SetUnit(&c, &unitForTest{hasLiveCell: true})
+	unit, _ := g.GetUnit(&c)
+	if !unit.hasLiveCell {
+		t.Fatalf("Should correctly set unit.")
+	}
+	if err := g.SetUnit(&Coordinate3D{X: 0, Y: 0, Z: 2}, &unitForTest{}); err == nil {
+		t.Fatalf("Should get error when coordinate is outside the game map.")
+	}
+	t.Log("Passed")
+}
+
+func TestGame3DSetUnit(t *testing.T) {
+	testGame3DSetUnitCaseOne(t)
+}
+
+func testGame3DGetUnitsInAreaCaseOne(t *testing.T) {
+	g, _ := NewGame3D(generateInitialUnit3DMatrixForTest(3, 3, 3, initialUnitForTest))
+	g.SetUnit(&Coordinate3D{X: 2, Y: 2, Z: 2}, &unitForTest{hasLiveCell: true})
+
+	unitsInArea, _ := g.GetUnitsInArea(&Area3D{From: Coordinate3D{X: 1, Y: 1, Z: 1}, To: Coordinate3D{X: 2, Y: 2, Z: 2}})
+	if len(*unitsInArea) != 2 || len((*unitsInArea)[0]) != 2 || len((*unitsInArea)[0][0]) != 2 {
+		t.Fatalf("Should get 2 x 2 x 2 units.")
+	}
+	if !(*unitsInArea)[1][1][1].hasLiveCell || (*unitsInArea)[0][0][0].hasLiveCell {
+		t.Fatalf("Did not get all units in the given area correctly.")
+	}
+
+	_, err := g.GetUnitsInArea(&Area3D{From: Coordinate3D{X: 1, Y: 1, Z: 1}, To: Coordinate3D{X: 1, Y: 1, Z: 0}})
+	if err == nil {
+		t.Fatalf("Should get error when the area is invalid.")
+	}
+	t.Log("Passed")
+}
+
+func TestGame3DGetUnitsInArea(t *testing.T) {
+	testGame3DGetUnitsInAreaCaseOne(t)
+}
+
+func testGame3DIterateUnitsInAreaCaseOne(t *testing.T) {
+	g, _ := NewGame3D(generateInitialUnit3DMatrixForTest(3, 3, 3, initialUnitForTest))
+	sumsOfZCoord := 0
+	unitsCount := 0
+	g.IterateUnitsInArea(&Area3D{From: Coordinate3D{X: 0, Y: 0, Z: 1}, To: Coordinate3D{X: 1, Y: 1, Z: 2}}, func(c *Coordinate3D, unit *unitForTest) {
+		sumsOfZCoord += c.Z
+		unitsCount += 1
+	})
+	if sumsOfZCoord != 12 || unitsCount != 8 {
+		t.Fatalf("Did not iterate through units in the given area correctly, sums of Z: %v, count: %v.", sumsOfZCoord, unitsCount)
+	}
+	t.Log("Passed")
+}
+
+func TestGame3DIterateUnitsInArea(t *testing.T) {
+	testGame3DIterateUnitsInAreaCaseOne(t)
+}
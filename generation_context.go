@@ -0,0 +1,97 @@
+package ggol
+
+import "math/bits"
+
+// GenerationContext tells NextUnitGeneratorWithContext about the generation of the unit, and gives it random numbers.
+// Random numbers only depend on the seed of the game, the generation and the coordinate of the unit,
+// so a game with the same seed replays identically no matter how many goroutines generate units.
+// The context is read-only and reused by the game, so don't keep it after the generator returns.
+type GenerationContext struct {
+	// The worker generating the unit, it tells GlobalState where to keep contributions.
+	worker     int
+	seed       int64
+	generation int
+	size       *Size
+	params     any
+	// The phase of the pipeline, it's always 0 when the game has no pipeline.
+	phase int
+	coord Coordinate
+	// It tells updates of the same unit in the same generation apart, units can be updated more than once with UpdateOrderPoisson.
+	updateIndex int
+	// The state of the random numbers, it's derived from the fields above when the first random number is asked.
+	randomState        uint64
+	isRandomStateReady bool
+}
+
+// Prepare the context of the worker for all units of a generation.
+func (c *GenerationContext) prepare(worker int, seed int64, generation int, size *Size, params any) {
+	c.worker = worker
+	c.seed = seed
+	c.generation = generation
+	c.size = size
+	c.params = params
+	c.phase = 0
+}
+
+// Reset the context for the unit at the given coordinate.
+func (c *GenerationContext) reset(coord *Coordinate, updateIndex int) {
+	c.coord = *coord
+	c.updateIndex = updateIndex
+	c.isRandomStateReady = false
+}
+
+// Get the generation of the unit, it's 0 when the game generates next units for the first time.
+func (c *GenerationContext) GetGeneration() int {
+	return c.generation
+}
+
+// Get the phase of the pipeline you set with SetNextUnitGeneratorPipeline, it starts from 0.
+func (c *GenerationContext) GetPhase() int {
+	return c.phase
+}
+
+// Get the size of the game, for games without border, it's the size of the area that covers all generated units.
+func (c *GenerationContext) GetSize() *Size {
+	return c.size
+}
+
+// Get the params you set with SetParams, they are shared by all units, so don't change them in generators.
+func (c *GenerationContext) GetParams() any {
+	return c.params
+}
+
+// The finalizer of SplitMix64, it scrambles every bit of "x" into all bits of the result.
+func mixBits(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// Get a random uint64, it's SplitMix64 seeded by the seed, the generation, the coordinate, the update index and the phase.
+func (c *GenerationContext) Uint64() uint64 {
+	if !c.isRandomStateReady {
+		state := mixBits(uint64(c.seed))
+		state = mixBits(state ^ uint64(c.generation))
+		state = mixBits(state ^ uint64(c.coord.X))
+		state = mixBits(state ^ uint64(c.coord.Y))
+		// Phases are mixed into high bits of the update index, so random numbers without pipelines stay the same.
+		c.randomState = mixBits(state ^ uint64(c.updateIndex) ^ uint64(c.phase)<<32)
+		c.isRandomStateReady = true
+	}
+	c.randomState += 0x9e3779b97f4a7c15
+	return mixBits(c.randomState)
+}
+
+// Get a random float64 in [0, 1).
+func (c *GenerationContext) Float64() float64 {
+	return float64(c.Uint64()>>11) / (1 << 53)
+}
+
+// Get a random int in [0, n), it panics when n is not greater than 0, just like rand.Intn.
+func (c *GenerationContext) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	hi, _ := bits.Mul64(c.Uint64(), uint64(n))
+	return int(hi)
+}
@@ -0,0 +1,190 @@
+package ggol
+
+import (
+	"testing"
+)
+
+func generateRandomNumbersForTest(seed int64, generation int, coord Coordinate, updateIndex int) []uint64 {
+	context := GenerationContext{}
+	context.prepare(0, seed, generation, &Size{Width: 10, Height: 10}, nil)
+	context.reset(&coord, updateIndex)
+	return []uint64{context.Uint64(), context.Uint64(), context.Uint64()}
+}
+
+func areRandomNumbersEqualForTest(numbers []uint64, anotherNumbers []uint64) bool {
+	for i := range numbers {
+		if numbers[i] != anotherNumbers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testGenerationContextUint64CaseOne(t *testing.T) {
+	numbers := generateRandomNumbersForTest(1, 2, Coordinate{X: 3, Y: 4}, 0)
+	if !areRandomNumbersEqualForTest(numbers, generateRandomNumbersForTest(1, 2, Coordinate{X: 3, Y: 4}, 0)) {
+		t.Fatalf("Random numbers should be the same with the same seed, generation and coordinate.")
+	}
+	if numbers[0] == numbers[1] || numbers[1] == numbers[2] {
+		t.Fatalf("Random numbers in a sequence should be different, but got %v.", numbers)
+	}
+	anotherNumbersList := [][]uint64{
+		generateRandomNumbersForTest(2, 2, Coordinate{X: 3, Y: 4}, 0),
+		generateRandomNumbersForTest(1, 3, Coordinate{X: 3, Y: 4}, 0),
+		generateRandomNumbersForTest(1, 2, Coordinate{X: 4, Y: 3}, 0),
+		generateRandomNumbersForTest(1, 2, Coordinate{X: 3, Y: -4}, 0),
+		generateRandomNumbersForTest(1, 2, Coordinate{X: 3, Y: 4}, 1),
+	}
+	for i, anotherNumbers := range anotherNumbersList {
+		if areRandomNumbersEqualForTest(numbers, anotherNumbers) {
+			t.Fatalf("Random numbers of case %v should be different from %v.", i, numbers)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGenerationContextUint64(t *testing.T) {
+	testGenerationContextUint64CaseOne(t)
+}
+
+func testGenerationContextIntnCaseOne(t *testing.T) {
+	context := GenerationContext{}
+	context.reset(&Coordinate{X: 0, Y: 0}, 0)
+	counts := make([]int, 4)
+	for i := 0; i < 40000; i++ {
+		n := context.Intn(4)
+		if n < 0 || n >= 4 {
+			t.Fatalf("Intn(4) should be in [0, 4), but got %v.", n)
+		}
+		counts[n] += 1
+		if f := context.Float64(); f < 0 || f >= 1 {
+			t.Fatalf("Float64 should be in [0, 1), but got %v.", f)
+		}
+	}
+	for n, count := range counts {
+		if count < 9500 || count > 10500 {
+			t.Fatalf("Intn(4) should give %v about 10000 times, but got %v.", n, count)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGenerationContextIntnCaseTwo(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Intn(0) should panic.")
+		}
+	}()
+	context := GenerationContext{}
+	context.Intn(0)
+}
+
+func TestGenerationContextIntn(t *testing.T) {
+	testGenerationContextIntnCaseOne(t)
+	testGenerationContextIntnCaseTwo(t)
+}
+
+// A stochastic generator, the next unit is the sum of the left unit and a random number.
+func randomUnitForTestIterator(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	leftUnit, _ := getAdjacentUnit(coord, &Coordinate{X: -1, Y: 0})
+	nextUnit := (*leftUnit + context.Intn(100)) % 1000
+	return &nextUnit
+}
+
+func generateUnitsWithContextForTest(seed int64, concurrency int, order UpdateOrder) *[][]int {
+	g, _ := NewGame(generateCoordinateUnitsForTest(20, 20))
+	g.SetNextUnitGeneratorWithContext(randomUnitForTestIterator)
+	g.SetSeed(seed)
+	g.SetConcurrency(concurrency)
+	g.SetUpdateOrder(order, seed)
+	for i := 0; i < 5; i++ {
+		g.GenerateNextUnits()
+	}
+	return g.GetUnits()
+}
+
+func testSetNextUnitGeneratorWithContextCaseOne(t *testing.T) {
+	units := generateUnitsWithContextForTest(7, 1, UpdateOrderSynchronous)
+	if !areIntUnitsEqualForTest(units, generateUnitsWithContextForTest(7, 4, UpdateOrderSynchronous)) {
+		t.Fatalf("Units should be the same no matter how many goroutines generate them.")
+	}
+	if areIntUnitsEqualForTest(units, generateUnitsWithContextForTest(8, 1, UpdateOrderSynchronous)) {
+		t.Fatalf("Units should be different with another seed.")
+	}
+	for _, order := range []UpdateOrder{UpdateOrderRandom, UpdateOrderCheckerboard, UpdateOrderPoisson} {
+		if !areIntUnitsEqualForTest(generateUnitsWithContextForTest(7, 1, order), generateUnitsWithContextForTest(7, 3, order)) {
+			t.Fatalf("Units should be replayed identically with update order %v.", order)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorWithContextCaseTwo(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(1, 1))
+	g.SetNextUnitGeneratorWithContext(func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		nextUnit := context.Intn(1 << 30)
+		return &nextUnit
+	})
+	firstUnit := (*g.GenerateNextUnits())[0][0]
+	secondUnit := (*g.GenerateNextUnits())[0][0]
+	if firstUnit == secondUnit {
+		t.Fatalf("Random numbers should be different in every generation, but got %v twice.", firstUnit)
+	}
+
+	// NextUnitGenerator replaces NextUnitGeneratorWithContext.
+	g.SetNextUnitGenerator(countingUnitForTestIterator)
+	if unit := (*g.GenerateNextUnits())[0][0]; unit != secondUnit+1 {
+		t.Fatalf("Unit should be %v after NextUnitGenerator is set, but got %v.", secondUnit+1, unit)
+	}
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorWithContextWithoutAllocation(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(64, 64))
+	units := []int{0, 1, 2, 3}
+	g.SetNextUnitGeneratorWithContext(func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		return &units[context.Intn(4)]
+	})
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorWithContextCaseThree(t *testing.T) {
+	type paramsForTest struct {
+		offset int
+	}
+	g, _ := NewGame(generateCoordinateUnitsForTest(3, 2))
+	g.SetConcurrency(2)
+	g.SetParams(&paramsForTest{offset: 7})
+	g.SetNextUnitGeneratorWithContext(func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		size := context.GetSize()
+		nextUnit := context.GetGeneration()*1000 + size.Width*100 + size.Height*10 + context.GetParams().(*paramsForTest).offset
+		return &nextUnit
+	})
+
+	for generation := 0; generation < 3; generation++ {
+		units := g.GenerateNextUnits()
+		expectedUnit := generation*1000 + 327
+		for x := 0; x < 3; x++ {
+			for y := 0; y < 2; y++ {
+				if (*units)[x][y] != expectedUnit {
+					t.Fatalf("Unit at (%v, %v) should be %v in generation %v, but got %v.", x, y, expectedUnit, generation, (*units)[x][y])
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestSetNextUnitGeneratorWithContext(t *testing.T) {
+	testSetNextUnitGeneratorWithContextCaseOne(t)
+	testSetNextUnitGeneratorWithContextCaseTwo(t)
+	testSetNextUnitGeneratorWithContextCaseThree(t)
+	testSetNextUnitGeneratorWithContextWithoutAllocation(t)
+}
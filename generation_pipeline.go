@@ -0,0 +1,48 @@
+package ggol
+
+// Generate next units with every phase of the pipeline, units of the last generation are kept in the buffer afterwards,
+// just like generating units without pipelines.
+func (g *gameInfo[T]) generateNextUnitsOfPipeline() {
+	var previousUnits *[][]T
+	for phase, nextUnitGenerator := range g.pipeline {
+		g.nextUnitGeneratorWithContext = nextUnitGenerator
+		for worker := range g.workerContexts {
+			g.workerContexts[worker].phase = phase
+		}
+		// Units of the last generation are in the buffer after the first phase, so later phases write into the spare buffer.
+		if g.nextUnits == previousUnits {
+			g.nextUnits, g.spareUnits = g.spareUnits, g.nextUnits
+		}
+		g.generateNextUnitsOfUpdateOrder()
+		if phase == 0 {
+			previousUnits = g.nextUnits
+		}
+	}
+	if g.nextUnits != previousUnits {
+		g.nextUnits, g.spareUnits = g.spareUnits, g.nextUnits
+	}
+}
+
+// Set the pipeline of NextUnitGeneratorWithContext.
+func (g *gameInfo[T]) SetNextUnitGeneratorPipeline(nextUnitGenerators ...NextUnitGeneratorWithContext[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if len(nextUnitGenerators) == 0 {
+		return &ErrNextUnitGeneratorPipelineIsEmpty{}
+	}
+	if g.areUnitsEqual != nil {
+		return &ErrActiveRegionTrackingIsIncompatible{}
+	}
+	g.activateAllUnits()
+
+	g.pipeline = append([]NextUnitGeneratorWithContext[T]{}, nextUnitGenerators...)
+	g.nextUnitGeneratorWithContext = g.pipeline[0]
+	g.neighborhood = nil
+	g.neighborhoodNextUnitGenerator = nil
+	g.neighborhoodPlan = nil
+	if g.spareUnits == nil {
+		g.spareUnits = generateUnitsBuffer[T](g.size)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package ggol
+
+import (
+	"sync"
+	"testing"
+)
+
+func addingUnitForTestPhase(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	nextUnit := *unit + 1
+	return &nextUnit
+}
+
+func leftUnitForTestPhase(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	leftUnit, _ := getAdjacentUnit(coord, &Coordinate{X: -1, Y: 0})
+	return leftUnit
+}
+
+// Units are capped at 3, so they stop growing after addingUnitForTestPhase adds 1 to them 3 times.
+func cappingUnitForTestPhase(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	nextUnit := *unit
+	if nextUnit > 3 {
+		nextUnit = 3
+	}
+	return &nextUnit
+}
+
+func testSetNextUnitGeneratorPipelineCaseOne(t *testing.T) {
+	for _, concurrency := range []int{1, 2} {
+		g, _ := NewGame(generateCoordinateUnitsForTest(3, 3))
+		g.SetConcurrency(concurrency)
+		g.SetNextUnitGeneratorPipeline(addingUnitForTestPhase, leftUnitForTestPhase)
+
+		units := g.GenerateNextUnits()
+		// The second phase reads units of the first phase, and the left unit of (0, y) is (2, y).
+		expectedUnits := [][]int{{21, 22, 23}, {1, 2, 3}, {11, 12, 13}}
+		for x := 0; x < 3; x++ {
+			for y := 0; y < 3; y++ {
+				if (*units)[x][y] != expectedUnits[x][y] {
+					t.Fatalf("Unit at (%v, %v) should be %v with concurrency %v, but got %v.", x, y, expectedUnits[x][y], concurrency, (*units)[x][y])
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorPipelineCaseTwo(t *testing.T) {
+	for _, order := range []UpdateOrder{UpdateOrderSynchronous, UpdateOrderSequential, UpdateOrderCheckerboard} {
+		g, _ := NewGame(generateCoordinateUnitsForTest(2, 2))
+		g.SetUpdateOrder(order, 0)
+		for x := 0; x < 2; x++ {
+			for y := 0; y < 2; y++ {
+				g.SetUnit(&Coordinate{X: x, Y: y}, &[]int{0}[0])
+			}
+		}
+		g.SetNextUnitGeneratorPipeline(addingUnitForTestPhase, cappingUnitForTestPhase, cappingUnitForTestPhase)
+
+		// Units are compared with units of the last generation, not the last phase.
+		report, err := g.GenerateNextUnitsUntilStable(10, AreUnitsEqual[int])
+		if err != nil {
+			t.Fatalf("Units should be stable with update order %v, but got %v.", order, err)
+		}
+		if report.GenerationsCount != 3 || report.ChangedUnitsCount != 12 {
+			t.Fatalf("Units should change 12 times in 3 generations with update order %v, but got %v.", order, report)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorPipelineCaseThree(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(16, 16))
+	g.SetConcurrency(4)
+	g.SetNextUnitGeneratorPipeline(addingUnitForTestPhase, addingUnitForTestPhase)
+
+	// Every generation adds 2 to units, readers should never see units of the first phase.
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			g.GenerateNextUnits()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		// GetUnitsInArea copies units while the game is locked.
+		units, _ := g.GetUnitsInArea(&Area{From: Coordinate{X: 3, Y: 4}, To: Coordinate{X: 3, Y: 4}})
+		if unit := (*units)[0][0]; (unit-34)%2 != 0 {
+			t.Fatalf("Unit should never be in the middle of a generation, but got %v.", unit)
+		}
+	}
+	wg.Wait()
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorPipelineCaseFour(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(1, 1))
+	var phases []int
+	var randomNumbers []int
+	recordingPhase := func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		phases = append(phases, context.GetPhase())
+		randomNumbers = append(randomNumbers, context.Intn(1<<30))
+		return unit
+	}
+	g.SetNextUnitGeneratorPipeline(recordingPhase, recordingPhase)
+	g.GenerateNextUnits()
+	if len(phases) != 2 || phases[0] != 0 || phases[1] != 1 {
+		t.Fatalf("Phases should be [0 1], but got %v.", phases)
+	}
+	if randomNumbers[0] == randomNumbers[1] {
+		t.Fatalf("Random numbers should be different in every phase, but got %v twice.", randomNumbers[0])
+	}
+
+	// NextUnitGenerator replaces the pipeline.
+	g.SetNextUnitGenerator(countingUnitForTestIterator)
+	if unit := (*g.GenerateNextUnits())[0][0]; unit != 1 {
+		t.Fatalf("Unit should be 1 after NextUnitGenerator is set, but got %v.", unit)
+	}
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorPipelineCaseFive(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(1, 1))
+	err := g.SetNextUnitGeneratorPipeline()
+	if _, ok := err.(*ErrNextUnitGeneratorPipelineIsEmpty); !ok {
+		t.Fatalf("Should get ErrNextUnitGeneratorPipelineIsEmpty, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func testSetNextUnitGeneratorPipelineWithoutAllocation(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(64, 64))
+	units := []int{0, 1, 2, 3}
+	nextUnitGenerator := func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		return &units[(*unit+context.GetPhase())%4]
+	}
+	g.SetNextUnitGeneratorPipeline(nextUnitGenerator, nextUnitGenerator, nextUnitGenerator)
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestSetNextUnitGeneratorPipeline(t *testing.T) {
+	testSetNextUnitGeneratorPipelineCaseOne(t)
+	testSetNextUnitGeneratorPipelineCaseTwo(t)
+	testSetNextUnitGeneratorPipelineCaseThree(t)
+	testSetNextUnitGeneratorPipelineCaseFour(t)
+	testSetNextUnitGeneratorPipelineCaseFive(t)
+	testSetNextUnitGeneratorPipelineWithoutAllocation(t)
+}
@@ -1,16 +1,84 @@
 package ggol
 
 import (
+	"math/rand"
 	"sync"
 )
 
 // "T" in the Game interface represents the type of unit, it's defined by you.
 type Game[T any] interface {
 	// Generate next units, the way you generate next units will be depending on the NextUnitGenerator function
-	// you passed in SetNextUnitGenerator.
+	// you passed in SetNextUnitGenerator. The game swaps its two buffers of units in every generation, so the units
+	// you passed into NewGame or got before might hold an older generation, always read current units with GetUnits.
 	GenerateNextUnits() (units *[][]T)
+	// Keep generating next units until a generation changes nothing, like toppling sandpiles until no unit topples.
+	// It generates "maxGenerationsCount" generations at most, including the last one that changes nothing,
+	// and returns ErrUnitsAreNotStable with the report so far when units are still changing.
+	GenerateNextUnitsUntilStable(maxGenerationsCount int, areUnitsEqual UnitsEqualityChecker[T]) (report *StabilizationReport, err error)
 	// Set NextUnitGenerator, which tells the game how you want to generate next unit of the given unit.
 	SetNextUnitGenerator(nextUnitGenerator NextUnitGenerator[T])
+	// Set NeighborhoodNextUnitGenerator, the game collects neighbors in the neighborhood for every unit and passes them into it.
+	// It replaces the NextUnitGenerator you set before, and vice versa. The neighborhood can't be nil, and its topology
+	// has to be the topology of the game, otherwise you get ErrNeighborhoodTopologyIsMismatched.
+	SetNeighborhoodNextUnitGenerator(neighborhood *Neighborhood, nextUnitGenerator NeighborhoodNextUnitGenerator[T]) (err error)
+	// Set NextUnitGeneratorWithContext, the game passes the GenerationContext of every unit into it.
+	// It replaces the NextUnitGenerator you set before, and vice versa.
+	// It returns ErrActiveRegionTrackingIsIncompatible when active region tracking is on.
+	SetNextUnitGeneratorWithContext(nextUnitGenerator NextUnitGeneratorWithContext[T]) (err error)
+	// Set a pipeline of NextUnitGeneratorWithContext, GenerateNextUnits runs them one by one as phases of one generation,
+	// every phase generates units from units of the phase before, and you never get units of a phase in between.
+	// It replaces the NextUnitGenerator you set before, and vice versa.
+	// It returns ErrActiveRegionTrackingIsIncompatible when active region tracking is on.
+	SetNextUnitGeneratorPipeline(nextUnitGenerators ...NextUnitGeneratorWithContext[T]) (err error)
+	// Set the seed of random numbers you get from GenerationContext, default is 0.
+	// HashLife games never give you GenerationContext, so they return ErrSeedIsNotSupported.
+	SetSeed(seed int64) (err error)
+	// Set params shared by all units, you get them from GenerationContext, default is nil.
+	// HashLife games never give you GenerationContext, so they return ErrParamsAreNotSupported.
+	SetParams(params any) (err error)
+	// Set GlobalState, generators read it frozen before every generation and contribute to it with GenerationContext,
+	// then its reducer folds contributions into the next state after every generation. Set nil to remove it.
+	// It returns ErrActiveRegionTrackingIsIncompatible when active region tracking is on.
+	SetGlobalState(globalState GlobalStateHolder) (err error)
+	// Add a zone with the name, units in the zone are generated with the NextUnitGenerator of the zone from the same units
+	// as other units. When zones overlap, the zone added later takes precedence. With pipelines, units in zones are generated
+	// in the first phase and kept in other phases. Zones take effect from the next generation.
+	AddZone(name string, zone *Zone[T]) (err error)
+	// Remove the zone with the name, units in it follow the generators of the game again from the next generation.
+	RemoveZone(name string) (err error)
+	// Track units changed in every generation, so only units within "radius" of changed units are generated in the next generation,
+	// and other units are kept. Your generators must only read units within "radius" and always give the same unit for the same units,
+	// so it returns ErrActiveRegionTrackingIsIncompatible when GlobalState, NextUnitGeneratorWithContext or a pipeline is set.
+	// It only works with UpdateOrderSynchronous, otherwise all units are generated. Set nil "areUnitsEqual" to stop tracking,
+	// ggol.AreUnitsEqual works for any comparable unit type.
+	SetActiveRegionTracking(areUnitsEqual UnitsEqualityChecker[T], radius int) (err error)
+	// Set GenerationPreparer, it's called before every generation, set nil to remove it.
+	// It's called only once for units updated in the same generation, so it only works with UpdateOrderSynchronous,
+	// and it returns ErrUpdateOrderIsNotSupported with other update orders.
+	SetGenerationPreparer(generationPreparer GenerationPreparer[T]) error
+	// Set how many goroutines GenerateNextUnits can use at most, default is 1, which means units are generated serially.
+	// When it's greater than 1, your NextUnitGenerator will be called concurrently, so make sure it's safe to do that.
+	// HashLife games always generate units in one goroutine, so they return ErrConcurrencyIsNotSupported when it's greater than 1.
+	SetConcurrency(concurrency int) (err error)
+	// Set the update order, it tells the game in which order units are updated in a generation, default is UpdateOrderSynchronous.
+	// The seed decides the random order of UpdateOrderRandom and UpdateOrderPoisson, so the same seed gives you the same units.
+	// Units are updated one by one in UpdateOrderSequential, UpdateOrderRandom and UpdateOrderPoisson, so concurrency is ignored.
+	// Units read by GenerationPreparer change during other orders, so it returns ErrUpdateOrderIsNotSupported when GenerationPreparer is set.
+	SetUpdateOrder(order UpdateOrder, seed int64) (err error)
+	// Set the boundary policy, it tells the game which unit AdjacentUnitGetter gives you when the adjacent unit is outside the border.
+	// It returns ErrSizeIsNotSupported when the map can't wrap around with the topology, see ErrSizeIsNotSupported.
+	SetBoundaryPolicy(policy BoundaryPolicy) (err error)
+	// Set the unit outside the border when the boundary policy is BoundaryPolicyConstant, default is the zero value of T.
+	// Infinite games and HashLife games have no border, so they return ErrBoundaryUnitIsNotSupported.
+	SetBoundaryUnit(unit *T) (err error)
+	// Set the topology, it tells the game how units are arranged, default is TopologySquare.
+	// It decides units you get from IterateUnitsWithinDistance and the Neighborhood the game takes, so read adjacent units
+	// with Topology.GetAdjacentRelativeCoordinates or use the Neighborhood of the topology, like NewTriangularNeighborhood.
+	// It returns ErrNeighborhoodTopologyIsMismatched when the Neighborhood you set is of another topology, set the topology first,
+	// and ErrSizeIsNotSupported when the map can't wrap around with the boundary policy.
+	SetTopology(topology Topology) (err error)
+	// Get the topology of the game.
+	GetTopology() (topology Topology)
 	// Set the status of the unit at the given coordinate.
 	SetUnit(coord *Coordinate, unit *T) (err error)
 	// Get the size of the game.
@@ -19,19 +87,74 @@ type Game[T any] interface {
 	GetUnit(coord *Coordinate) (unit *T, err error)
 	// Get all units in the area.
 	GetUnitsInArea(area *Area) (units *[][]T, err error)
-	// Get all units in the game.
+	// Get all units in the game, they're current units until the next generation.
 	GetUnits() (units *[][]T)
 	// Iterate through units in the given area.
 	IterateUnitsInArea(area *Area, callback UnitsIteratorCallback[T]) (err error)
 	// Iterate through all units in the game
 	IterateUnits(callback UnitsIteratorCallback[T])
+	// Iterate through units that can be reached from the center by walking through at most "distance" adjacent units,
+	// adjacent units are decided by the topology and the boundary policy of the game.
+	IterateUnitsWithinDistance(center *Coordinate, distance int, callback UnitsIteratorCallback[T]) (err error)
 }
 
 type gameInfo[T any] struct {
-	size              *Size
-	units             *[][]T
+	size *Size
+	// The units of current generation.
+	units *[][]T
+	// The buffer that next units will be written into, it's swapped with units after every generation.
+	nextUnits         *[][]T
 	nextUnitGenerator NextUnitGenerator[T]
-	locker            sync.RWMutex
+	// When nextUnitGeneratorWithContext is set, it's used instead of nextUnitGenerator.
+	nextUnitGeneratorWithContext NextUnitGeneratorWithContext[T]
+	// When pipeline is set, its generators are set to nextUnitGeneratorWithContext one by one in every generation.
+	pipeline []NextUnitGeneratorWithContext[T]
+	// Zones in the order they were added, and the index of the zone of every unit, it's nil when there's no zone.
+	zones       []zoneInfo[T]
+	zoneIndexes [][]int
+	// When areUnitsEqual is set, only units within activeRadius of changedCoords are generated in synchronous generations.
+	areUnitsEqual UnitsEqualityChecker[T]
+	activeRadius  int
+	// It's true when the changed units are unknown, like after rules are changed, so all units have to be generated.
+	areAllUnitsActive bool
+	// Units changed in the last generation or set with SetUnit.
+	changedCoords []Coordinate
+	// Units to generate in this generation, the marks keep a unit from being collected twice.
+	activeCoords []Coordinate
+	activeMarks  [][]bool
+	// The third buffer of pipelines, so units of the last generation are kept while phases swap the other two buffers.
+	spareUnits  *[][]T
+	seed        int64
+	params      any
+	globalState GlobalStateHolder
+	// The count of generated generations, it tells GenerationContext which generation it is.
+	generationsCount int
+	// We keep the method value of getAdjacentUnit so we don't allocate it in every generation.
+	adjacentUnitGetter AdjacentUnitGetter[T]
+	generationPreparer GenerationPreparer[T]
+	// The area of the whole map passed into GenerationPreparer.
+	area           Area
+	concurrency    int
+	boundaryPolicy BoundaryPolicy
+	boundaryUnit   T
+	topology       Topology
+	updateOrder    UpdateOrder
+	// The random source of UpdateOrderRandom and UpdateOrderPoisson, it's seeded in SetUpdateOrder.
+	random *rand.Rand
+	// The permutation of all coordinates for UpdateOrderRandom, it's shuffled in every generation.
+	updateOrderCoords []Coordinate
+	// When neighborhoodNextUnitGenerator is set, it's used instead of nextUnitGenerator.
+	neighborhood                  *Neighborhood
+	neighborhoodNextUnitGenerator NeighborhoodNextUnitGenerator[T]
+	// It's built lazily and dropped when the boundary policy changes.
+	neighborhoodPlan *neighborhoodPlan
+	// Every worker has its own coordinate to pass into NextUnitGenerator, so we don't allocate one for every unit.
+	workerCoords []Coordinate
+	// Every worker has its own slice of neighbors to pass into NeighborhoodNextUnitGenerator.
+	workerNeighbors [][]*T
+	// Every worker has its own context to pass into NextUnitGeneratorWithContext.
+	workerContexts []GenerationContext
+	locker         sync.RWMutex
 }
 
 func defaultNextUnitGenerator[T any](coord *Coordinate, unit *T, getAdjacentUnit AdjacentUnitGetter[T]) (nextUnit *T) {
@@ -48,15 +171,32 @@ func NewGame[T any](
 	}
 
 	newG := gameInfo[T]{
-		size,
-		units,
-		defaultNextUnitGenerator[T],
-		sync.RWMutex{},
+		size:              size,
+		units:             units,
+		nextUnits:         generateUnitsBuffer[T](size),
+		nextUnitGenerator: defaultNextUnitGenerator[T],
+		concurrency:       1,
+		boundaryPolicy:    BoundaryPolicyWrap,
+		topology:          TopologySquare,
+		updateOrder:       UpdateOrderSynchronous,
+		area:              Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: size.Width - 1, Y: size.Height - 1}},
+		workerCoords:      make([]Coordinate, 1),
+		workerContexts:    make([]GenerationContext, 1),
+		locker:            sync.RWMutex{},
 	}
+	newG.adjacentUnitGetter = newG.getAdjacentUnit
 
 	return &newG, nil
 }
 
+func generateUnitsBuffer[T any](size *Size) *[][]T {
+	units := make([][]T, size.Width)
+	for x := 0; x < size.Width; x++ {
+		units[x] = make([]T, size.Height)
+	}
+	return &units
+}
+
 func calculateSizeFromUnits[T any](units *[][]T) (*Size, error) {
 	width := len(*units)
 	var height int = 0
@@ -87,21 +227,139 @@ func (g *gameInfo[T]) getAdjacentUnit(
 ) (unit *T, crossBorder bool) {
 	targetX := originCoord.X + relativeCoord.X
 	targetY := originCoord.Y + relativeCoord.Y
-	var isCrossBorder bool = false
 
 	if (g.isCoordinateInvalid(&Coordinate{X: targetX, Y: targetY})) {
-		isCrossBorder = true
-		for targetX < 0 {
-			targetX += g.size.Width
+		targetX, targetY, isResolved := resolveCoordinateWithBoundaryPolicy(g.boundaryPolicy, g.size, targetX, targetY)
+		if !isResolved {
+			return &g.boundaryUnit, true
 		}
-		for targetY < 0 {
-			targetY += g.size.Height
+		return &(*g.units)[targetX][targetY], true
+	}
+
+	return &(*g.units)[targetX][targetY], false
+}
+
+// Generate the next unit at (x, y) from current units with the coordinate of the given worker,
+// "updateIndex" tells how many times the unit has been updated in this generation.
+func (g *gameInfo[T]) generateNextUnit(worker int, x int, y int, updateIndex int) *T {
+	coord := &g.workerCoords[worker]
+	coord.X = x
+	coord.Y = y
+	if g.zoneIndexes != nil {
+		if zoneIndex := g.zoneIndexes[x][y]; zoneIndex >= 0 {
+			if g.workerContexts[worker].phase > 0 {
+				return &(*g.units)[x][y]
+			}
+			return g.zones[zoneIndex].nextUnitGenerator(coord, &(*g.units)[x][y], g.adjacentUnitGetter)
 		}
-		targetX = targetX % g.size.Width
-		targetY = targetY % g.size.Height
 	}
+	if g.nextUnitGeneratorWithContext != nil {
+		context := &g.workerContexts[worker]
+		context.reset(coord, updateIndex)
+		return g.nextUnitGeneratorWithContext(context, coord, &(*g.units)[x][y], g.adjacentUnitGetter)
+	}
+	if g.neighborhoodNextUnitGenerator != nil {
+		neighbors := g.collectNeighbors(x, y, g.workerNeighbors[worker])
+		return g.neighborhoodNextUnitGenerator(coord, &(*g.units)[x][y], neighbors)
+	}
+	return g.nextUnitGenerator(coord, &(*g.units)[x][y], g.adjacentUnitGetter)
+}
 
-	return &(*g.units)[targetX][targetY], isCrossBorder
+// Generate next units of the columns from "fromX" to "toX" (exclusive) with the coordinate of the given worker.
+// When "parity" is 0 or 1, only units with "(x + y) % 2 == parity" are generated, otherwise all units are generated.
+func (g *gameInfo[T]) generateNextUnitsInColumns(worker int, fromX int, toX int, parity int) {
+	for x := fromX; x < toX; x++ {
+		fromY, stepY := 0, 1
+		if parity == 0 || parity == 1 {
+			fromY, stepY = (x+parity)%2, 2
+		}
+		for y := fromY; y < g.size.Height; y += stepY {
+			(*g.nextUnits)[x][y] = *g.generateNextUnit(worker, x, y, 0)
+		}
+	}
+}
+
+// Generate next units of the given parity into the buffer, with goroutines as many as the concurrency.
+func (g *gameInfo[T]) generateNextUnitsInStripes(parity int) {
+	workersCount := g.concurrency
+	if workersCount > g.size.Width {
+		workersCount = g.size.Width
+	}
+	if workersCount <= 1 {
+		g.generateNextUnitsInColumns(0, 0, g.size.Width, parity)
+		return
+	}
+
+	// Split the map into vertical stripes, every worker takes care of one stripe.
+	stripeWidth := (g.size.Width + workersCount - 1) / workersCount
+	wg := sync.WaitGroup{}
+	for worker := 0; worker*stripeWidth < g.size.Width; worker++ {
+		fromX := worker * stripeWidth
+		toX := fromX + stripeWidth
+		if toX > g.size.Width {
+			toX = g.size.Width
+		}
+		wg.Add(1)
+		go func(worker int, fromX int, toX int) {
+			defer wg.Done()
+			g.generateNextUnitsInColumns(worker, fromX, toX, parity)
+		}(worker, fromX, toX)
+	}
+	wg.Wait()
+	// Collect contributions of every stripe in the order of workers, so they're in the same order as generating serially.
+	if g.globalState != nil {
+		g.globalState.collectContributions()
+	}
+}
+
+// Generate all next units from current units at once, units of the last generation are kept in the buffer afterwards.
+func (g *gameInfo[T]) generateNextUnitsSynchronously() {
+	g.generateNextUnitsInStripes(-1)
+	// Swap the buffers, so the next units become current units without copying.
+	g.units, g.nextUnits = g.nextUnits, g.units
+}
+
+// Generate next units once with the update order, units of the last generation are kept in the buffer afterwards.
+func (g *gameInfo[T]) generateNextUnitsOfUpdateOrder() {
+	switch g.updateOrder {
+	case UpdateOrderSynchronous:
+		g.generateNextUnitsSynchronously()
+	case UpdateOrderCheckerboard:
+		g.generateNextUnitsOfParity(0)
+		g.generateNextUnitsOfParity(1)
+	default:
+		g.updateUnitsAsynchronously()
+	}
+}
+
+// Generate next units with the update order or the pipeline, units of the last generation are kept in the buffer afterwards.
+func (g *gameInfo[T]) generateNextUnits() {
+	if g.generationPreparer != nil {
+		g.generationPreparer(&g.area, g.adjacentUnitGetter)
+	}
+	if g.neighborhoodNextUnitGenerator != nil && g.neighborhoodPlan == nil {
+		g.neighborhoodPlan = g.buildNeighborhoodPlan()
+	}
+	for worker := range g.workerContexts {
+		g.workerContexts[worker].prepare(worker, g.seed, g.generationsCount, g.size, g.params)
+	}
+	if g.globalState != nil {
+		g.globalState.prepareGeneration(len(g.workerContexts))
+	}
+
+	if len(g.pipeline) > 0 {
+		g.generateNextUnitsOfPipeline()
+		g.activateAllUnits()
+	} else if g.areUnitsEqual != nil && g.updateOrder == UpdateOrderSynchronous {
+		g.generateNextUnitsOfActiveRegion()
+	} else {
+		g.generateNextUnitsOfUpdateOrder()
+		g.activateAllUnits()
+	}
+	if g.globalState != nil {
+		g.globalState.reduceContributions()
+	}
+	g.generationsCount += 1
 }
 
 // Generate next units.
@@ -109,28 +367,241 @@ func (g *gameInfo[T]) GenerateNextUnits() *[][]T {
 	g.locker.Lock()
 	defer g.locker.Unlock()
 
-	nextUnits := make([][]T, g.size.Width)
+	g.generateNextUnits()
+	return g.units
+}
 
-	for x := 0; x < g.size.Width; x++ {
-		nextUnits[x] = make([]T, g.size.Height)
-		for y := 0; y < g.size.Height; y++ {
-			coord := Coordinate{X: x, Y: y}
-			nextUnit := g.nextUnitGenerator(&coord, &(*g.units)[x][y], g.getAdjacentUnit)
-			nextUnits[x][y] = *nextUnit
-		}
+// Generate next units until a generation changes nothing.
+func (g *gameInfo[T]) GenerateNextUnitsUntilStable(maxGenerationsCount int, areUnitsEqual UnitsEqualityChecker[T]) (*StabilizationReport, error) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if maxGenerationsCount < 1 {
+		return nil, &ErrMaxGenerationsCountIsInvalid{maxGenerationsCount}
 	}
 
-	for x := 0; x < g.size.Width; x++ {
-		for y := 0; y < g.size.Height; y++ {
-			(*g.units)[x][y] = nextUnits[x][y]
+	report := StabilizationReport{}
+	for i := 0; i < maxGenerationsCount; i++ {
+		g.generateNextUnits()
+		changedUnitsCount := report.ChangedUnitsCount
+		// Units of the last generation are in the buffer after swapping.
+		for x := 0; x < g.size.Width; x++ {
+			for y := 0; y < g.size.Height; y++ {
+				if !areUnitsEqual(&(*g.nextUnits)[x][y], &(*g.units)[x][y]) {
+					report.addChangedUnit(x, y)
+				}
+			}
+		}
+		if report.ChangedUnitsCount == changedUnitsCount {
+			return &report, nil
 		}
+		report.GenerationsCount += 1
 	}
-
-	return g.units
+	return &report, &ErrUnitsAreNotStable{maxGenerationsCount}
 }
 
 func (g *gameInfo[T]) SetNextUnitGenerator(iterator NextUnitGenerator[T]) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
 	g.nextUnitGenerator = iterator
+	g.nextUnitGeneratorWithContext = nil
+	g.pipeline = nil
+	g.neighborhood = nil
+	g.neighborhoodNextUnitGenerator = nil
+	g.neighborhoodPlan = nil
+}
+
+// Set NeighborhoodNextUnitGenerator with the neighborhood.
+func (g *gameInfo[T]) SetNeighborhoodNextUnitGenerator(neighborhood *Neighborhood, nextUnitGenerator NeighborhoodNextUnitGenerator[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if err := validateNeighborhood(neighborhood, g.topology); err != nil {
+		return err
+	}
+	g.activateAllUnits()
+
+	g.nextUnitGeneratorWithContext = nil
+	g.pipeline = nil
+	g.neighborhood = neighborhood
+	g.neighborhoodNextUnitGenerator = nextUnitGenerator
+	g.neighborhoodPlan = nil
+	g.resetWorkerNeighbors()
+	return nil
+}
+
+// Set NextUnitGeneratorWithContext.
+func (g *gameInfo[T]) SetNextUnitGeneratorWithContext(nextUnitGenerator NextUnitGeneratorWithContext[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if nextUnitGenerator != nil && g.areUnitsEqual != nil {
+		return &ErrActiveRegionTrackingIsIncompatible{}
+	}
+	g.activateAllUnits()
+
+	g.nextUnitGeneratorWithContext = nextUnitGenerator
+	g.pipeline = nil
+	g.neighborhood = nil
+	g.neighborhoodNextUnitGenerator = nil
+	g.neighborhoodPlan = nil
+	return nil
+}
+
+// Set the seed of random numbers in GenerationContext.
+func (g *gameInfo[T]) SetSeed(seed int64) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	g.seed = seed
+	return nil
+}
+
+// Set params shared by all units in GenerationContext.
+func (g *gameInfo[T]) SetParams(params any) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	g.params = params
+	return nil
+}
+
+// Set GlobalState.
+func (g *gameInfo[T]) SetGlobalState(globalState GlobalStateHolder) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if globalState != nil && g.areUnitsEqual != nil {
+		return &ErrActiveRegionTrackingIsIncompatible{}
+	}
+	g.activateAllUnits()
+
+	g.globalState = globalState
+	return nil
+}
+
+// Set GenerationPreparer.
+func (g *gameInfo[T]) SetGenerationPreparer(generationPreparer GenerationPreparer[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	if generationPreparer != nil && g.updateOrder != UpdateOrderSynchronous {
+		return &ErrUpdateOrderIsNotSupported{g.updateOrder}
+	}
+	g.generationPreparer = generationPreparer
+	return nil
+}
+
+func (g *gameInfo[T]) resetWorkerNeighbors() {
+	g.workerNeighbors = make([][]*T, g.concurrency)
+	if g.neighborhood == nil {
+		return
+	}
+	for worker := range g.workerNeighbors {
+		g.workerNeighbors[worker] = make([]*T, g.neighborhood.getMaxNeighborsCount())
+	}
+}
+
+// Set the max count of goroutines used to generate next units.
+func (g *gameInfo[T]) SetConcurrency(concurrency int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if concurrency < 1 {
+		return &ErrConcurrencyIsInvalid{concurrency}
+	}
+	g.concurrency = concurrency
+	g.workerCoords = make([]Coordinate, concurrency)
+	g.workerContexts = make([]GenerationContext, concurrency)
+	g.resetWorkerNeighbors()
+
+	return nil
+}
+
+// Set the update order with the seed of random orders.
+func (g *gameInfo[T]) SetUpdateOrder(order UpdateOrder, seed int64) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if !order.isValid() {
+		return &ErrUpdateOrderIsInvalid{order}
+	}
+	if g.generationPreparer != nil && order != UpdateOrderSynchronous {
+		return &ErrUpdateOrderIsNotSupported{order}
+	}
+	g.updateOrder = order
+	g.random = rand.New(rand.NewSource(seed))
+	g.updateOrderCoords = nil
+
+	return nil
+}
+
+// Set the boundary policy.
+func (g *gameInfo[T]) SetBoundaryPolicy(policy BoundaryPolicy) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	if !policy.isValid() {
+		return &ErrBoundaryPolicyIsInvalid{policy}
+	}
+	if !g.topology.isSizeSupported(g.size, policy) {
+		return &ErrSizeIsNotSupported{Size: g.size, Topology: g.topology, Policy: policy}
+	}
+	g.boundaryPolicy = policy
+	g.neighborhoodPlan = nil
+
+	return nil
+}
+
+// Set the unit outside the border for BoundaryPolicyConstant.
+func (g *gameInfo[T]) SetBoundaryUnit(unit *T) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	g.boundaryUnit = *unit
+	return nil
+}
+
+// Set the topology.
+func (g *gameInfo[T]) SetTopology(topology Topology) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	if !topology.isValid() {
+		return &ErrTopologyIsInvalid{topology}
+	}
+	if g.neighborhood != nil && g.neighborhood.topology != topology {
+		return &ErrNeighborhoodTopologyIsMismatched{NeighborhoodTopology: g.neighborhood.topology, GameTopology: topology}
+	}
+	if !topology.isSizeSupported(g.size, g.boundaryPolicy) {
+		return &ErrSizeIsNotSupported{Size: g.size, Topology: topology, Policy: g.boundaryPolicy}
+	}
+	g.topology = topology
+
+	return nil
+}
+
+// Get the topology.
+func (g *gameInfo[T]) GetTopology() Topology {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.topology
 }
 
 // Update the unit at the given coordinate.
@@ -142,6 +613,9 @@ func (g *gameInfo[T]) SetUnit(c *Coordinate, unit *T) error {
 		return &ErrCoordinateIsInvalid{c}
 	}
 	(*g.units)[c.X][c.Y] = *unit
+	if g.areUnitsEqual != nil && !g.areAllUnitsActive {
+		g.changedCoords = append(g.changedCoords, *c)
+	}
 
 	return nil
 }
@@ -232,3 +706,26 @@ func (g *gameInfo[T]) IterateUnitsInArea(area *Area, callback UnitsIteratorCallb
 	}
 	return nil
 }
+
+// We will iterate all units within the distance from the center and call the callbacks with coordiante and unit.
+func (g *gameInfo[T]) IterateUnitsWithinDistance(center *Coordinate, distance int, callback UnitsIteratorCallback[T]) error {
+	if g.isCoordinateInvalid(center) {
+		return &ErrCoordinateIsInvalid{center}
+	}
+
+	if distance < 0 {
+		return &ErrDistanceIsInvalid{distance}
+	}
+
+	resolve := func(coord *Coordinate) (Coordinate, bool) {
+		if !g.isCoordinateInvalid(coord) {
+			return *coord, true
+		}
+		x, y, isResolved := resolveCoordinateWithBoundaryPolicy(g.boundaryPolicy, g.size, coord.X, coord.Y)
+		return Coordinate{X: x, Y: y}, isResolved
+	}
+	iterateCoordinatesWithinDistance(g.topology, center, distance, resolve, func(coord *Coordinate) {
+		callback(coord, &(*g.units)[coord.X][coord.Y])
+	})
+	return nil
+}
@@ -30,6 +30,139 @@ func (e *ErrAreaIsInvalid) Error() string {
 	return fmt.Sprintf("Area with from coordinate (%v, %v) and end coordiante (%v, %v) is not valid.", e.Area.From.X, e.Area.From.Y, e.Area.To.X, e.Area.To.Y)
 }
 
+// This error will be thrown when you try to set concurrency that is less than 1.
+type ErrConcurrencyIsInvalid struct {
+	Concurrency int
+}
+
+// Tell you that the concurrency is invalid.
+func (e *ErrConcurrencyIsInvalid) Error() string {
+	return fmt.Sprintf("Concurrency %v is not valid, it should be greater than 0.", e.Concurrency)
+}
+
+// This error will be thrown when you try to set concurrency greater than 1 to a game that always generates units in one goroutine.
+type ErrConcurrencyIsNotSupported struct {
+	Concurrency int
+}
+
+// Tell you that the game doesn't support the concurrency.
+func (e *ErrConcurrencyIsNotSupported) Error() string {
+	return fmt.Sprintf("Concurrency %v is not supported, the game always generates units in one goroutine.", e.Concurrency)
+}
+
+// This error will be thrown when you try to set a boundary policy that doesn't exist.
+type ErrBoundaryPolicyIsInvalid struct {
+	Policy BoundaryPolicy
+}
+
+// Tell you that the boundary policy is invalid.
+func (e *ErrBoundaryPolicyIsInvalid) Error() string {
+	return fmt.Sprintf("Boundary policy %v is not valid.", e.Policy)
+}
+
+// This error will be thrown when you try to set a boundary policy to a game without border.
+type ErrBoundaryPolicyIsNotSupported struct {
+}
+
+// Tell you that the game has no border.
+func (e *ErrBoundaryPolicyIsNotSupported) Error() string {
+	return fmt.Sprintf("The game has no border, so boundary policy is not supported.")
+}
+
+// This error will be thrown when you try to set a boundary unit to a game without border.
+type ErrBoundaryUnitIsNotSupported struct {
+}
+
+// Tell you that the game has no border.
+func (e *ErrBoundaryUnitIsNotSupported) Error() string {
+	return fmt.Sprintf("The game has no border, so boundary unit is not supported.")
+}
+
+// This error will be thrown when you try to set an update order that doesn't exist.
+type ErrUpdateOrderIsInvalid struct {
+	Order UpdateOrder
+}
+
+// Tell you that the update order is invalid.
+func (e *ErrUpdateOrderIsInvalid) Error() string {
+	return fmt.Sprintf("Update order %v is not valid.", e.Order)
+}
+
+// This error will be thrown when you try to set an update order that the game doesn't support,
+// or when you try to use GenerationPreparer with an update order other than UpdateOrderSynchronous.
+type ErrUpdateOrderIsNotSupported struct {
+	Order UpdateOrder
+}
+
+// Tell you that the game doesn't support the update order.
+func (e *ErrUpdateOrderIsNotSupported) Error() string {
+	return fmt.Sprintf("Update order %v is not supported by the game.", e.Order)
+}
+
+// This error will be thrown when you try to set a topology that doesn't exist.
+type ErrTopologyIsInvalid struct {
+	Topology Topology
+}
+
+// Tell you that the topology is invalid.
+func (e *ErrTopologyIsInvalid) Error() string {
+	return fmt.Sprintf("Topology %v is not valid.", e.Topology)
+}
+
+// This error will be thrown when you try to set a topology or a boundary policy, and the map can't wrap around with them.
+// Hexagonal maps need even height, and triangular maps need even width and height under BoundaryPolicyWrap,
+// BoundaryPolicyKleinBottle and BoundaryPolicyProjectivePlane.
+type ErrSizeIsNotSupported struct {
+	Size     *Size
+	Topology Topology
+	Policy   BoundaryPolicy
+}
+
+// Tell you that the size of the map doesn't support the topology and the boundary policy.
+func (e *ErrSizeIsNotSupported) Error() string {
+	return fmt.Sprintf("Size %vx%v is not supported by topology %v with boundary policy %v.", e.Size.Width, e.Size.Height, e.Topology, e.Policy)
+}
+
+// This error will be thrown when the topology of the neighborhood is different from the topology of the game.
+type ErrNeighborhoodTopologyIsMismatched struct {
+	NeighborhoodTopology Topology
+	GameTopology         Topology
+}
+
+// Tell you that the neighborhood doesn't match the game.
+func (e *ErrNeighborhoodTopologyIsMismatched) Error() string {
+	return fmt.Sprintf("Neighborhood of topology %v can't be used in the game of topology %v.", e.NeighborhoodTopology, e.GameTopology)
+}
+
+// This error will be thrown when the given distance is negative.
+type ErrDistanceIsInvalid struct {
+	Distance int
+}
+
+// Tell you that the distance is invalid.
+func (e *ErrDistanceIsInvalid) Error() string {
+	return fmt.Sprintf("Distance %v is not valid, it should not be negative.", e.Distance)
+}
+
+// This error will be thrown when the given radius is less than 1.
+type ErrRadiusIsInvalid struct {
+	Radius int
+}
+
+// Tell you that the radius is invalid.
+func (e *ErrRadiusIsInvalid) Error() string {
+	return fmt.Sprintf("Radius %v is not valid, it should be greater than 0.", e.Radius)
+}
+
+// This error will be thrown when you try to create a neighborhood without any relative coordinate, or set a nil neighborhood.
+type ErrNeighborhoodIsInvalid struct {
+}
+
+// Tell you that the neighborhood is invalid.
+func (e *ErrNeighborhoodIsInvalid) Error() string {
+	return fmt.Sprintf("Neighborhood should not be nil and should have at least one relative coordinate.")
+}
+
 // Coordniate tells you the position of an unit in the game.
 type Coordinate struct {
 	X int
@@ -49,11 +182,177 @@ type Size struct {
 }
 
 // This function will be passed into NextUnitGenerator, this is how you can adajcent units in NextUnitGenerator.
-// Also, 2nd argument "isCrossBorder" tells you if the adjacent unit is on ohter side of the map.
+// Also, 2nd argument "isCrossBorder" tells you if the adjacent unit is outside the border, the unit you get
+// is decided by the BoundaryPolicy of the game.
 type AdjacentUnitGetter[T any] func(originCoord *Coordinate, relativeCoord *Coordinate) (unit *T, isCrossBorder bool)
 
 // NextUnitGenerator tells the game how you're gonna generate next status of the given unit.
+// The "coord" and "unit" are reused by the game, so don't keep them after the generator returns.
 type NextUnitGenerator[T any] func(coord *Coordinate, unit *T, getAdjacentUnit AdjacentUnitGetter[T]) (nextUnit *T)
 
+// NextUnitGeneratorWithContext works like NextUnitGenerator, and the game passes the GenerationContext of the unit into it,
+// so it can get random numbers that are the same every time you replay the game with the same seed.
+type NextUnitGeneratorWithContext[T any] func(context *GenerationContext, coord *Coordinate, unit *T, getAdjacentUnit AdjacentUnitGetter[T]) (nextUnit *T)
+
+// GenerationPreparer is called once before every generation, so you can precompute things for NextUnitGenerator,
+// like summed-area tables. "area" covers all units that will be generated, and "getAdjacentUnit" gets any unit
+// relative to coordinate (0, 0), with the BoundaryPolicy of the game. Don't keep "area" after the preparer returns.
+type GenerationPreparer[T any] func(area *Area, getAdjacentUnit AdjacentUnitGetter[T])
+
 // UnitsIteratorCallback will be called when iterating through units.
 type UnitsIteratorCallback[T any] func(coord *Coordinate, unit *T)
+
+// This error will be thrown when you set a GenerationPreparer to a game that doesn't generate units one generation at a time.
+type ErrGenerationPreparerIsNotSupported struct {
+}
+
+// Tell you that the game doesn't support GenerationPreparer.
+func (e *ErrGenerationPreparerIsNotSupported) Error() string {
+	return fmt.Sprintf("The game doesn't generate units one generation at a time, so generation preparer is not supported.")
+}
+
+// This error will be thrown when you set the seed to a game that never gives you GenerationContext.
+type ErrSeedIsNotSupported struct {
+}
+
+// Tell you that the game doesn't support the seed.
+func (e *ErrSeedIsNotSupported) Error() string {
+	return fmt.Sprintf("The game never gives you generation context, so the seed is not supported.")
+}
+
+// This error will be thrown when you set params to a game that never gives you GenerationContext.
+type ErrParamsAreNotSupported struct {
+}
+
+// Tell you that the game doesn't support params.
+func (e *ErrParamsAreNotSupported) Error() string {
+	return fmt.Sprintf("The game never gives you generation context, so params are not supported.")
+}
+
+// This error will be thrown when you set a NextUnitGeneratorWithContext to a game that reuses generated units.
+type ErrNextUnitGeneratorWithContextIsNotSupported struct {
+}
+
+// Tell you that the game doesn't support NextUnitGeneratorWithContext.
+func (e *ErrNextUnitGeneratorWithContextIsNotSupported) Error() string {
+	return fmt.Sprintf("The game reuses generated units, so next unit generator with context is not supported.")
+}
+
+// This error will be thrown when you set a pipeline without any NextUnitGeneratorWithContext.
+type ErrNextUnitGeneratorPipelineIsEmpty struct {
+}
+
+// Tell you that the pipeline has no phase.
+func (e *ErrNextUnitGeneratorPipelineIsEmpty) Error() string {
+	return fmt.Sprintf("The pipeline needs at least one next unit generator.")
+}
+
+// This error will be thrown when you set a pipeline to a game that doesn't support it.
+type ErrNextUnitGeneratorPipelineIsNotSupported struct {
+}
+
+// Tell you that the game doesn't support pipelines.
+func (e *ErrNextUnitGeneratorPipelineIsNotSupported) Error() string {
+	return fmt.Sprintf("The game doesn't support next unit generator pipelines.")
+}
+
+// This error will be thrown when the zone has no NextUnitGenerator, has both or neither of Area and Mask,
+// or has a mask of another size.
+type ErrZoneIsInvalid struct {
+	Name string
+}
+
+// Tell you that the zone is invalid.
+func (e *ErrZoneIsInvalid) Error() string {
+	return fmt.Sprintf("Zone %v should have a next unit generator and either an area or a mask of the game size.", e.Name)
+}
+
+// This error will be thrown when you add a zone with the name of another zone.
+type ErrZoneAlreadyExists struct {
+	Name string
+}
+
+// Tell you that the name is taken.
+func (e *ErrZoneAlreadyExists) Error() string {
+	return fmt.Sprintf("Zone %v already exists.", e.Name)
+}
+
+// This error will be thrown when you remove a zone that doesn't exist.
+type ErrZoneIsNotFound struct {
+	Name string
+}
+
+// Tell you that the zone doesn't exist.
+func (e *ErrZoneIsNotFound) Error() string {
+	return fmt.Sprintf("Zone %v is not found.", e.Name)
+}
+
+// This error will be thrown when you add a zone to a game that doesn't support zones.
+type ErrZoneIsNotSupported struct {
+}
+
+// Tell you that the game doesn't support zones.
+func (e *ErrZoneIsNotSupported) Error() string {
+	return fmt.Sprintf("The game doesn't support zones.")
+}
+
+// This error will be thrown when you set active region tracking to a game that doesn't support it.
+type ErrActiveRegionTrackingIsNotSupported struct {
+}
+
+// Tell you that the game doesn't support active region tracking.
+func (e *ErrActiveRegionTrackingIsNotSupported) Error() string {
+	return fmt.Sprintf("The game doesn't support active region tracking.")
+}
+
+// This error will be thrown when you combine active region tracking with GlobalState, NextUnitGeneratorWithContext
+// or a pipeline, in either order, because units they generate change even when units around them don't.
+type ErrActiveRegionTrackingIsIncompatible struct {
+}
+
+// Tell you that active region tracking can't be combined with the generator or GlobalState.
+func (e *ErrActiveRegionTrackingIsIncompatible) Error() string {
+	return fmt.Sprintf("Active region tracking can't be used with GlobalState, NextUnitGeneratorWithContext or pipelines.")
+}
+
+// This error will be thrown when you set a GlobalState to a game that reuses generated units.
+type ErrGlobalStateIsNotSupported struct {
+}
+
+// Tell you that the game doesn't support GlobalState.
+func (e *ErrGlobalStateIsNotSupported) Error() string {
+	return fmt.Sprintf("The game reuses generated units, so global state is not supported.")
+}
+
+// UnitsEqualityChecker tells whether two units are the same, it's used to find out whether units are stable.
+type UnitsEqualityChecker[T any] func(unit *T, anotherUnit *T) (isEqual bool)
+
+// StabilizationReport tells you how units changed before they became stable, like the size of an avalanche.
+type StabilizationReport struct {
+	// The count of generations that changed at least one unit.
+	GenerationsCount int
+	// The total count of changes, a unit is counted every time it changes.
+	ChangedUnitsCount int
+	// The smallest area that covers all changed units, it's nil when nothing changed.
+	ChangedArea *Area
+}
+
+// This error will be thrown when the max count of generations is less than 1.
+type ErrMaxGenerationsCountIsInvalid struct {
+	MaxGenerationsCount int
+}
+
+// Tell you that the max count of generations is invalid.
+func (e *ErrMaxGenerationsCountIsInvalid) Error() string {
+	return fmt.Sprintf("Max generations count %v is not valid, it should be greater than 0.", e.MaxGenerationsCount)
+}
+
+// This error will be thrown when units are still changing after the max count of generations.
+type ErrUnitsAreNotStable struct {
+	MaxGenerationsCount int
+}
+
+// Tell you that units are not stable.
+func (e *ErrUnitsAreNotStable) Error() string {
+	return fmt.Sprintf("Units are still changing after %v generations.", e.MaxGenerationsCount)
+}
@@ -259,11 +259,333 @@ func testGliderPatternWithConcurrency(t *testing.T) {
 	t.Log("Passed")
 }
 
+func testBlinkerPatternInParallel(t *testing.T) {
+	width := 3
+	height := 3
+	serialGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	serialGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	parallelGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame.SetConcurrency(3)
+
+	// Make a blinker pattern in both games
+	for _, g := range []Game[unitForTest]{serialGame, parallelGame} {
+		g.SetUnit(&Coordinate{X: 1, Y: 0}, &unitForTest{hasLiveCell: true})
+		g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+		g.SetUnit(&Coordinate{X: 1, Y: 2}, &unitForTest{hasLiveCell: true})
+	}
+
+	for i := 0; i < 4; i++ {
+		serialUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(serialGame.GenerateNextUnits())
+		parallelUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(parallelGame.GenerateNextUnits())
+		if !areTwoUnitsHavingLiveCellForTestEqual(serialUnitLiveMap, parallelUnitLiveMap) {
+			t.Fatalf("Generation %v of a blinker should be %v in parallel, but got %v.", i+1, serialUnitLiveMap, parallelUnitLiveMap)
+		}
+	}
+
+	t.Log("Passed")
+}
+
+func testGliderPatternInParallel(t *testing.T) {
+	width := 5
+	height := 5
+	serialGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	serialGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	parallelGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame.SetConcurrency(2)
+
+	// Make a glider pattern in both games
+	for _, g := range []Game[unitForTest]{serialGame, parallelGame} {
+		g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+		g.SetUnit(&Coordinate{X: 2, Y: 2}, &unitForTest{hasLiveCell: true})
+		g.SetUnit(&Coordinate{X: 3, Y: 2}, &unitForTest{hasLiveCell: true})
+		g.SetUnit(&Coordinate{X: 1, Y: 3}, &unitForTest{hasLiveCell: true})
+		g.SetUnit(&Coordinate{X: 2, Y: 3}, &unitForTest{hasLiveCell: true})
+	}
+
+	for i := 0; i < 8; i++ {
+		serialUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(serialGame.GenerateNextUnits())
+		parallelUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(parallelGame.GenerateNextUnits())
+		if !areTwoUnitsHavingLiveCellForTestEqual(serialUnitLiveMap, parallelUnitLiveMap) {
+			t.Fatalf("Generation %v of a glider should be %v in parallel, but got %v.", i+1, serialUnitLiveMap, parallelUnitLiveMap)
+		}
+	}
+
+	t.Log("Passed")
+}
+
+func testSoupInParallel(t *testing.T) {
+	width := 67
+	height := 41
+	serialGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	serialGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	parallelGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame.SetConcurrency(8)
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			unit := unitForTest{hasLiveCell: (x*7+y*13)%5 == 0}
+			serialGame.SetUnit(&Coordinate{X: x, Y: y}, &unit)
+			parallelGame.SetUnit(&Coordinate{X: x, Y: y}, &unit)
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		serialUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(serialGame.GenerateNextUnits())
+		parallelUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(parallelGame.GenerateNextUnits())
+		if !areTwoUnitsHavingLiveCellForTestEqual(serialUnitLiveMap, parallelUnitLiveMap) {
+			t.Fatalf("Generation %v of the soup in parallel is not identical to the serial one.", i+1)
+		}
+	}
+
+	t.Log("Passed")
+}
+
+func testGenerateNextUnitsWithoutAllocation(t *testing.T) {
+	width := 64
+	height := 64
+	uniMatrix := generateInitialUnitMatrixForTest(width, height, initialUnitForTest)
+	g, _ := NewGame(uniMatrix)
+	g.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+	g.SetUnit(&Coordinate{X: 1, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 2}, &unitForTest{hasLiveCell: true})
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+
+	t.Log("Passed")
+}
+
+func testUnitsAfterSwappingBuffers(t *testing.T) {
+	width := 3
+	height := 3
+	uniMatrix := generateInitialUnitMatrixForTest(width, height, initialUnitForTest)
+	g, _ := NewGame(uniMatrix)
+	g.SetNextUnitGenerator(defauUnitForTestIterator)
+	g.SetUnit(&Coordinate{X: 1, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 2}, &unitForTest{hasLiveCell: true})
+
+	expectedUnitLiveMaps := []unitsHavingLiveCellForTest{
+		{{false, true, false}, {false, true, false}, {false, true, false}},
+		{{false, false, false}, {true, true, true}, {false, false, false}},
+	}
+	for i := 0; i < 6; i++ {
+		latestUnits := g.GenerateNextUnits()
+		expectedUnitLiveMap := expectedUnitLiveMaps[i%2]
+		unitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(latestUnits)
+		if !areTwoUnitsHavingLiveCellForTestEqual(unitLiveMap, expectedUnitLiveMap) {
+			t.Fatalf("Generation %v should return %v, but got %v.", i+1, expectedUnitLiveMap, unitLiveMap)
+		}
+		unitLiveMap = *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(g.GetUnits())
+		if !areTwoUnitsHavingLiveCellForTestEqual(unitLiveMap, expectedUnitLiveMap) {
+			t.Fatalf("GetUnits in generation %v should return %v, but got %v.", i+1, expectedUnitLiveMap, unitLiveMap)
+		}
+		g.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+			gotUnit, _ := g.GetUnit(coord)
+			if gotUnit.hasLiveCell != expectedUnitLiveMap[coord.X][coord.Y] {
+				t.Fatalf("GetUnit at (%v, %v) in generation %v is not correct.", coord.X, coord.Y, i+1)
+			}
+		})
+	}
+
+	t.Log("Passed")
+}
+
 func TestGenerateNextUnits(t *testing.T) {
 	testBlockPattern(t)
 	testBlinkerPattern(t)
 	testGliderPattern(t)
 	testGliderPatternWithConcurrency(t)
+	testBlinkerPatternInParallel(t)
+	testGliderPatternInParallel(t)
+	testSoupInParallel(t)
+	testGenerateNextUnitsWithoutAllocation(t)
+	testUnitsAfterSwappingBuffers(t)
+}
+
+func benchmarkGenerateNextUnits(b *testing.B, width int, height int, concurrency int) {
+	uniMatrix := generateInitialUnitMatrixForTest(width, height, initialUnitForTest)
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			(*uniMatrix)[x][y].hasLiveCell = (x*7+y*13)%5 == 0
+		}
+	}
+	g, _ := NewGame(uniMatrix)
+	g.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+	g.SetConcurrency(concurrency)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.GenerateNextUnits()
+	}
+}
+
+// One pass of the generator over all units, a tick should cost the same since buffers are swapped without copying.
+func BenchmarkGenerationPass1000x1000(b *testing.B) {
+	uniMatrix := generateInitialUnitMatrixForTest(1000, 1000, initialUnitForTest)
+	for x := 0; x < 1000; x++ {
+		for y := 0; y < 1000; y++ {
+			(*uniMatrix)[x][y].hasLiveCell = (x*7+y*13)%5 == 0
+		}
+	}
+	g, _ := NewGame(uniMatrix)
+	g.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.(*gameInfo[unitForTest]).generateNextUnitsInStripes(-1)
+	}
+}
+
+func BenchmarkGenerateNextUnits100x100(b *testing.B) {
+	benchmarkGenerateNextUnits(b, 100, 100, 1)
+}
+
+func BenchmarkGenerateNextUnits1000x1000(b *testing.B) {
+	benchmarkGenerateNextUnits(b, 1000, 1000, 1)
+}
+
+func BenchmarkGenerateNextUnits1000x1000InParallel(b *testing.B) {
+	benchmarkGenerateNextUnits(b, 1000, 1000, 8)
+}
+
+func testGenerateNextUnitsUntilStableCaseOne(t *testing.T) {
+	units := [][]int{{4}, {0}, {0}, {0}, {0}, {0}}
+	g, _ := NewGame(&units)
+	g.SetNextUnitGenerator(decayingUnitForTestIterator)
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+
+	report, err := g.GenerateNextUnitsUntilStable(10, AreUnitsEqual[int])
+	if err != nil {
+		t.Fatalf("Units should be stable, but got error: %v.", err)
+	}
+	expectedArea := Area{From: Coordinate{X: 1, Y: 0}, To: Coordinate{X: 3, Y: 0}}
+	if report.GenerationsCount != 3 || report.ChangedUnitsCount != 3 || *report.ChangedArea != expectedArea {
+		t.Fatalf("Should change 3 units in 3 generations within %v, but got %v and %v.", expectedArea, report, report.ChangedArea)
+	}
+	expectedUnits := [][]int{{4}, {3}, {2}, {1}, {0}, {0}}
+	for x := range expectedUnits {
+		if unit, _ := g.GetUnit(&Coordinate{X: x, Y: 0}); *unit != expectedUnits[x][0] {
+			t.Fatalf("Unit at (%v, 0) should be %v, but got %v.", x, expectedUnits[x][0], *unit)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGenerateNextUnitsUntilStableCaseTwo(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(5, 5, initialUnitForTest))
+	g.SetNextUnitGenerator(defauUnitForTestIterator)
+	g.SetConcurrency(2)
+
+	// A block is stable already.
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 2}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 2, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 2, Y: 2}, &unitForTest{hasLiveCell: true})
+	report, err := g.GenerateNextUnitsUntilStable(1, AreUnitsEqual[unitForTest])
+	if err != nil || report.GenerationsCount != 0 || report.ChangedUnitsCount != 0 || report.ChangedArea != nil {
+		t.Fatalf("Block should be stable without any change, but got %v and error: %v.", report, err)
+	}
+
+	// A blinker never becomes stable.
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: false})
+	g.SetUnit(&Coordinate{X: 2, Y: 1}, &unitForTest{hasLiveCell: false})
+	g.SetUnit(&Coordinate{X: 0, Y: 2}, &unitForTest{hasLiveCell: true})
+	report, err = g.GenerateNextUnitsUntilStable(5, AreUnitsEqual[unitForTest])
+	if _, ok := err.(*ErrUnitsAreNotStable); !ok {
+		t.Fatalf("Blinker should not be stable, but got error: %v.", err)
+	}
+	if report.GenerationsCount != 5 || report.ChangedUnitsCount != 20 {
+		t.Fatalf("Blinker should change 4 units in every generation, but got %v.", report)
+	}
+	t.Log("Passed")
+}
+
+func testGenerateNextUnitsUntilStableCaseThree(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(3, 3, initialUnitForTest))
+	if _, err := g.GenerateNextUnitsUntilStable(0, AreUnitsEqual[unitForTest]); err == nil {
+		t.Fatalf("Should get error when max generations count is 0.")
+	} else if _, ok := err.(*ErrMaxGenerationsCountIsInvalid); !ok {
+		t.Fatalf("Should get ErrMaxGenerationsCountIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestGenerateNextUnitsUntilStable(t *testing.T) {
+	testGenerateNextUnitsUntilStableCaseOne(t)
+	testGenerateNextUnitsUntilStableCaseTwo(t)
+	testGenerateNextUnitsUntilStableCaseThree(t)
+}
+
+func testSetConcurrencyCaseOne(t *testing.T) {
+	width := 3
+	height := 3
+	uniMatrix := generateInitialUnitMatrixForTest(width, height, initialUnitForTest)
+	g, _ := NewGame(uniMatrix)
+
+	if err := g.SetConcurrency(0); err == nil {
+		t.Fatalf("Should get error when concurrency is less than 1.")
+	}
+	if err := g.SetConcurrency(4); err != nil {
+		t.Fatalf("Should not get error when concurrency is valid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestSetConcurrency(t *testing.T) {
+	testSetConcurrencyCaseOne(t)
+}
+
+func testSetGenerationPreparerCaseOne(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(4, 3, initialUnitForTest))
+	g.SetUnit(&Coordinate{X: 3, Y: 2}, &unitForTest{hasLiveCell: true})
+
+	preparedCount := 0
+	liveUnitsCountOfLastGeneration := 0
+	g.SetGenerationPreparer(func(area *Area, getAdjacentUnit AdjacentUnitGetter[unitForTest]) {
+		preparedCount += 1
+		if area.From.X != 0 || area.From.Y != 0 || area.To.X != 3 || area.To.Y != 2 {
+			t.Fatalf("Area should cover the whole map, but got %v.", area)
+		}
+		liveUnitsCountOfLastGeneration = 0
+		for x := area.From.X; x <= area.To.X; x++ {
+			for y := area.From.Y; y <= area.To.Y; y++ {
+				if unit, _ := getAdjacentUnit(&Coordinate{X: 0, Y: 0}, &Coordinate{X: x, Y: y}); unit.hasLiveCell {
+					liveUnitsCountOfLastGeneration += 1
+				}
+			}
+		}
+		// Units outside the border are resolved with the boundary policy.
+		if unit, isCrossBorder := getAdjacentUnit(&Coordinate{X: 0, Y: 0}, &Coordinate{X: -1, Y: -1}); !unit.hasLiveCell || !isCrossBorder {
+			t.Fatalf("Unit at (-1, -1) should be wrapped to (3, 2).")
+		}
+	})
+	g.GenerateNextUnits()
+	g.GenerateNextUnits()
+	if preparedCount != 2 || liveUnitsCountOfLastGeneration != 1 {
+		t.Fatalf("Preparer should be called once in every generation, but got %v calls and %v live units.", preparedCount, liveUnitsCountOfLastGeneration)
+	}
+
+	g.SetGenerationPreparer(nil)
+	g.GenerateNextUnits()
+	if preparedCount != 2 {
+		t.Fatalf("Preparer should not be called after it's removed.")
+	}
+	t.Log("Passed")
+}
+
+func TestSetGenerationPreparer(t *testing.T) {
+	testSetGenerationPreparerCaseOne(t)
 }
 
 func testGetSizeCaseOne(t *testing.T) {
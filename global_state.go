@@ -0,0 +1,99 @@
+package ggol
+
+import "sync"
+
+// GlobalStateReducer folds contributions of units in a generation into the next global state.
+// Contributions are always in the same order no matter how many goroutines generate units,
+// it's the order units are generated serially, so the reducer doesn't need to be commutative.
+// The contributions slice is reused in every generation, so don't keep it after the reducer returns.
+type GlobalStateReducer[G any, C any] func(state G, contributions []C) (nextState G)
+
+// GlobalStateHolder is a GlobalState of any type, you can set it to a game with SetGlobalState.
+type GlobalStateHolder interface {
+	// Freeze the state and prepare buffers of contributions for every worker before a generation.
+	prepareGeneration(workersCount int)
+	// Move contributions of all workers into one list, in the order of workers.
+	collectContributions()
+	// Fold collected contributions into the next state after a generation.
+	reduceContributions()
+}
+
+// GlobalState is a state "G" shared by all units, like the count of something in the whole game.
+// In a generation, generators read the state frozen before the generation and contribute "C" to it,
+// after the generation, the reducer folds all contributions into the next state.
+type GlobalState[G any, C any] struct {
+	state G
+	// The state generators read in the generation, it's copied from the state before every generation.
+	frozenState         G
+	reducer             GlobalStateReducer[G, C]
+	workerContributions [][]C
+	contributions       []C
+	locker              sync.RWMutex
+}
+
+// Return a new GlobalState with the initial state and the reducer.
+func NewGlobalState[G any, C any](initialState G, reducer GlobalStateReducer[G, C]) *GlobalState[G, C] {
+	return &GlobalState[G, C]{
+		state:       initialState,
+		frozenState: initialState,
+		reducer:     reducer,
+		locker:      sync.RWMutex{},
+	}
+}
+
+func (s *GlobalState[G, C]) prepareGeneration(workersCount int) {
+	s.locker.RLock()
+	s.frozenState = s.state
+	s.locker.RUnlock()
+
+	if len(s.workerContributions) < workersCount {
+		s.workerContributions = make([][]C, workersCount)
+	}
+	for worker := range s.workerContributions {
+		s.workerContributions[worker] = s.workerContributions[worker][:0]
+	}
+	s.contributions = s.contributions[:0]
+}
+
+func (s *GlobalState[G, C]) collectContributions() {
+	for worker := range s.workerContributions {
+		s.contributions = append(s.contributions, s.workerContributions[worker]...)
+		s.workerContributions[worker] = s.workerContributions[worker][:0]
+	}
+}
+
+func (s *GlobalState[G, C]) reduceContributions() {
+	s.collectContributions()
+	nextState := s.reducer(s.frozenState, s.contributions)
+
+	s.locker.Lock()
+	s.state = nextState
+	s.locker.Unlock()
+}
+
+// Get the state frozen before the current generation, generators should read the state with it.
+// It's shared by all units, so don't change it.
+func (s *GlobalState[G, C]) GetFrozenState() *G {
+	return &s.frozenState
+}
+
+// Contribute to the next state in a generator, the context tells which worker is generating the unit.
+func (s *GlobalState[G, C]) Contribute(context *GenerationContext, contribution C) {
+	s.workerContributions[context.worker] = append(s.workerContributions[context.worker], contribution)
+}
+
+// Get the current state, it's safe to call it while the game is generating units.
+func (s *GlobalState[G, C]) GetState() G {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	return s.state
+}
+
+// Set the current state, it takes effect from the next generation.
+func (s *GlobalState[G, C]) SetState(state G) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.state = state
+}
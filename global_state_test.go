@@ -0,0 +1,160 @@
+package ggol
+
+import (
+	"testing"
+)
+
+// The state is a hash of all contributions in order, so any change in the order changes the state.
+func hashContributionsForTest(state int, contributions []int) int {
+	for _, contribution := range contributions {
+		state = (state*31 + contribution) % 1000000007
+	}
+	return state
+}
+
+// Every unit contributes itself and becomes the frozen state plus itself.
+func contributingUnitForTestIterator(globalState *GlobalState[int, int]) NextUnitGeneratorWithContext[int] {
+	return func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		globalState.Contribute(context, *unit)
+		nextUnit := (*globalState.GetFrozenState() + *unit) % 1000
+		return &nextUnit
+	}
+}
+
+func generateGlobalStateForTest(concurrency int, order UpdateOrder) (int, *[][]int) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(20, 20))
+	globalState := NewGlobalState(0, hashContributionsForTest)
+	g.SetGlobalState(globalState)
+	g.SetNextUnitGeneratorWithContext(contributingUnitForTestIterator(globalState))
+	g.SetConcurrency(concurrency)
+	g.SetUpdateOrder(order, 5)
+	for i := 0; i < 5; i++ {
+		g.GenerateNextUnits()
+	}
+	return globalState.GetState(), g.GetUnits()
+}
+
+func testSetGlobalStateCaseOne(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(3, 3))
+	globalState := NewGlobalState(0, func(state int, contributions []int) int {
+		for _, contribution := range contributions {
+			state += contribution
+		}
+		return state
+	})
+	g.SetGlobalState(globalState)
+	g.SetNextUnitGeneratorWithContext(contributingUnitForTestIterator(globalState))
+
+	units := g.GenerateNextUnits()
+	// The sum of all coordinate units, 0 + 1 + 2 + 10 + 11 + 12 + 20 + 21 + 22.
+	if state := globalState.GetState(); state != 99 {
+		t.Fatalf("State should be 99 after the first generation, but got %v.", state)
+	}
+	if (*units)[2][1] != 21 {
+		t.Fatalf("Units should read the frozen state 0 in the first generation, but got %v.", (*units)[2][1])
+	}
+
+	units = g.GenerateNextUnits()
+	if (*units)[2][1] != 120 {
+		t.Fatalf("Units should read the frozen state 99 in the second generation, but got %v.", (*units)[2][1])
+	}
+	t.Log("Passed")
+}
+
+func testSetGlobalStateCaseTwo(t *testing.T) {
+	for _, order := range []UpdateOrder{UpdateOrderSynchronous, UpdateOrderCheckerboard, UpdateOrderRandom, UpdateOrderPoisson} {
+		state, units := generateGlobalStateForTest(1, order)
+		for _, concurrency := range []int{2, 3, 7} {
+			anotherState, anotherUnits := generateGlobalStateForTest(concurrency, order)
+			if state != anotherState || !areIntUnitsEqualForTest(units, anotherUnits) {
+				t.Fatalf("State should be %v with update order %v and concurrency %v, but got %v.", state, order, concurrency, anotherState)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetGlobalStateCaseThree(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(2, 2))
+	globalState := NewGlobalState(0, hashContributionsForTest)
+	g.SetGlobalState(globalState)
+	g.SetNextUnitGeneratorWithContext(contributingUnitForTestIterator(globalState))
+	g.GenerateNextUnits()
+
+	// The state set by you is frozen in the next generation.
+	globalState.SetState(1)
+	units := g.GenerateNextUnits()
+	if (*units)[1][1] != 1+11 {
+		t.Fatalf("Unit should read the state 1 set before, but got %v.", (*units)[1][1])
+	}
+
+	// Contributions are not reduced after GlobalState is removed.
+	g.SetGlobalState(nil)
+	state := globalState.GetState()
+	g.SetNextUnitGenerator(countingUnitForTestIterator)
+	g.GenerateNextUnits()
+	if anotherState := globalState.GetState(); anotherState != state {
+		t.Fatalf("State should stay %v after GlobalState is removed, but got %v.", state, anotherState)
+	}
+	t.Log("Passed")
+}
+
+func generateGlobalStateOfPipelineForTest(concurrency int, order UpdateOrder) (int, *[][]int) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(17, 13))
+	globalState := NewGlobalState(0, hashContributionsForTest)
+	g.SetGlobalState(globalState)
+	g.SetNextUnitGeneratorPipeline(contributingUnitForTestIterator(globalState), contributingUnitForTestIterator(globalState))
+	// Units in the zone don't contribute, so the reducer gets fewer contributions than units.
+	g.AddZone("still", &Zone[int]{
+		Area:              &Area{From: Coordinate{X: 3, Y: 2}, To: Coordinate{X: 9, Y: 6}},
+		NextUnitGenerator: func(coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int { return unit },
+	})
+	g.SetConcurrency(concurrency)
+	g.SetUpdateOrder(order, 3)
+	for i := 0; i < 4; i++ {
+		g.GenerateNextUnits()
+	}
+	return globalState.GetState(), g.GetUnits()
+}
+
+func testSetGlobalStateCaseFour(t *testing.T) {
+	// Contributions of all phases and zones reach the reducer in the same order with any count of workers.
+	for _, order := range []UpdateOrder{UpdateOrderSynchronous, UpdateOrderCheckerboard} {
+		state, units := generateGlobalStateOfPipelineForTest(1, order)
+		for _, concurrency := range []int{2, 4, 5, 16} {
+			anotherState, anotherUnits := generateGlobalStateOfPipelineForTest(concurrency, order)
+			if state != anotherState || !areIntUnitsEqualForTest(units, anotherUnits) {
+				t.Fatalf("State should be %v with pipeline, update order %v and concurrency %v, but got %v.", state, order, concurrency, anotherState)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetGlobalStateWithoutAllocation(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(64, 64))
+	globalState := NewGlobalState(0, hashContributionsForTest)
+	g.SetGlobalState(globalState)
+	g.SetNextUnitGeneratorWithContext(func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		globalState.Contribute(context, *unit+*globalState.GetFrozenState())
+		return unit
+	})
+	// Buffers of contributions grow in the first generation, and they're reused afterwards.
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestSetGlobalState(t *testing.T) {
+	testSetGlobalStateCaseOne(t)
+	testSetGlobalStateCaseTwo(t)
+	testSetGlobalStateCaseThree(t)
+	testSetGlobalStateCaseFour(t)
+	testSetGlobalStateWithoutAllocation(t)
+}
@@ -0,0 +1,758 @@
+package ggol
+
+import (
+	"fmt"
+	"sync"
+)
+
+// This error will be thrown when you try to jump generations with a negative exponent.
+type ErrExponentIsInvalid struct {
+	Exponent int
+}
+
+// Tell you that the exponent is invalid.
+func (e *ErrExponentIsInvalid) Error() string {
+	return fmt.Sprintf("Exponent %v is not valid, it should not be negative.", e.Exponent)
+}
+
+// HashLifeGame is an InfiniteGame powered by the HashLife algorithm, it builds a canonical quadtree of the world
+// and memoizes the future of every node, so it can jump 2^k generations at once.
+type HashLifeGame[T comparable] interface {
+	InfiniteGame[T]
+	// Jump 2^exponent generations at once. Unlike GenerateNextUnits, it doesn't build units in the boundary,
+	// which costs as much as the area of the boundary, so read units you need with GetUnit or GetUnitsInArea afterwards.
+	JumpGenerations(exponent int) (err error)
+}
+
+// The count of nodes the game keeps at most before it collects nodes that are no longer used.
+const defaultHashLifeMaxNodesCount = 1 << 20
+
+type hashLifeNode[T comparable] struct {
+	// The node covers 2^level x 2^level units, a node of level 0 is a single unit.
+	level int
+	unit  T
+	// Children are indexed by [x][y], children[1][0] is the half with greater X and smaller Y.
+	children [2][2]*hashLifeNode[T]
+	// Tell you that all units in this node are the default unit.
+	isEmpty bool
+	// Memoized future of the center of this node, keyed by the exponent of generations.
+	results map[int]*hashLifeNode[T]
+}
+
+type hashLifeGameInfo[T comparable] struct {
+	defaultUnit T
+	radius      int
+	// A node of this level is the smallest node we can generate the future of its center by one generation.
+	baseLevel  int
+	leaves     map[T]*hashLifeNode[T]
+	nodes      map[[2][2]*hashLifeNode[T]]*hashLifeNode[T]
+	emptyNodes []*hashLifeNode[T]
+	root       *hashLifeNode[T]
+	// The coordinate of the first unit in root.
+	origin            Coordinate
+	nextUnitGenerator NextUnitGenerator[T]
+	// The neighborhood of nextUnitGenerator when it's set with SetNeighborhoodNextUnitGenerator.
+	neighborhood *Neighborhood
+	topology     Topology
+	// When there are more nodes than it, nodes not in root and all memoized futures are forgotten.
+	maxNodesCount int
+	locker        sync.RWMutex
+}
+
+// Return a new HashLifeGame, all units are "defaultUnit" at the beginning.
+// "radius" tells the game how far your NextUnitGenerator looks at adjacent units.
+// Since futures are memoized, your NextUnitGenerator must only depend on the unit and its adjacent units,
+// the coordinate given to it is not the real one. Also it has to keep a default unit default when all units around it are default.
+func NewHashLifeGame[T comparable](defaultUnit T, radius int) (HashLifeGame[T], error) {
+	if radius < 1 {
+		return nil, &ErrRadiusIsInvalid{radius}
+	}
+
+	baseLevel := 2
+	for 1<<baseLevel < 4*radius {
+		baseLevel += 1
+	}
+
+	newG := hashLifeGameInfo[T]{
+		defaultUnit:       defaultUnit,
+		radius:            radius,
+		baseLevel:         baseLevel,
+		leaves:            make(map[T]*hashLifeNode[T]),
+		nodes:             make(map[[2][2]*hashLifeNode[T]]*hashLifeNode[T]),
+		emptyNodes:        make([]*hashLifeNode[T], 0),
+		nextUnitGenerator: defaultNextUnitGenerator[T],
+		maxNodesCount:     defaultHashLifeMaxNodesCount,
+		locker:            sync.RWMutex{},
+	}
+	newG.root = newG.getEmptyNode(baseLevel)
+	newG.origin = Coordinate{X: -(1 << (baseLevel - 1)), Y: -(1 << (baseLevel - 1))}
+
+	return &newG, nil
+}
+
+func (g *hashLifeGameInfo[T]) getLeaf(unit T) *hashLifeNode[T] {
+	leaf, exists := g.leaves[unit]
+	if !exists {
+		leaf = &hashLifeNode[T]{level: 0, unit: unit, isEmpty: unit == g.defaultUnit}
+		g.leaves[unit] = leaf
+	}
+	return leaf
+}
+
+// Get the canonical node that has the given children.
+func (g *hashLifeGameInfo[T]) joinNodes(children [2][2]*hashLifeNode[T]) *hashLifeNode[T] {
+	node, exists := g.nodes[children]
+	if !exists {
+		node = &hashLifeNode[T]{
+			level:    children[0][0].level + 1,
+			children: children,
+			isEmpty:  children[0][0].isEmpty && children[0][1].isEmpty && children[1][0].isEmpty && children[1][1].isEmpty,
+		}
+		g.nodes[children] = node
+	}
+	return node
+}
+
+func (g *hashLifeGameInfo[T]) getEmptyNode(level int) *hashLifeNode[T] {
+	if len(g.emptyNodes) == 0 {
+		g.emptyNodes = append(g.emptyNodes, g.getLeaf(g.defaultUnit))
+	}
+	for len(g.emptyNodes) <= level {
+		child := g.emptyNodes[len(g.emptyNodes)-1]
+		g.emptyNodes = append(g.emptyNodes, g.joinNodes([2][2]*hashLifeNode[T]{{child, child}, {child, child}}))
+	}
+	return g.emptyNodes[level]
+}
+
+// Build a node of the given level from the units starting at (fromX, fromY).
+func (g *hashLifeGameInfo[T]) buildNode(units [][]T, fromX int, fromY int, level int) *hashLifeNode[T] {
+	if level == 0 {
+		return g.getLeaf(units[fromX][fromY])
+	}
+	half := 1 << (level - 1)
+	return g.joinNodes([2][2]*hashLifeNode[T]{
+		{g.buildNode(units, fromX, fromY, level-1), g.buildNode(units, fromX, fromY+half, level-1)},
+		{g.buildNode(units, fromX+half, fromY, level-1), g.buildNode(units, fromX+half, fromY+half, level-1)},
+	})
+}
+
+// Put all units of the node into "units" starting at (fromX, fromY).
+func (g *hashLifeGameInfo[T]) flattenNode(node *hashLifeNode[T], units [][]T, fromX int, fromY int) {
+	if node.level == 0 {
+		units[fromX][fromY] = node.unit
+		return
+	}
+	half := 1 << (node.level - 1)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			g.flattenNode(node.children[i][j], units, fromX+i*half, fromY+j*half)
+		}
+	}
+}
+
+// Return a new node that has the unit at (x, y) replaced, (x, y) is relative to the node.
+func (g *hashLifeGameInfo[T]) setUnitInNode(node *hashLifeNode[T], x int, y int, unit T) *hashLifeNode[T] {
+	if node.level == 0 {
+		return g.getLeaf(unit)
+	}
+	half := 1 << (node.level - 1)
+	children := node.children
+	children[x/half][y/half] = g.setUnitInNode(children[x/half][y/half], x%half, y%half, unit)
+	return g.joinNodes(children)
+}
+
+func (g *hashLifeGameInfo[T]) getUnitInNode(node *hashLifeNode[T], x int, y int) T {
+	for node.level > 0 && !node.isEmpty {
+		half := 1 << (node.level - 1)
+		node = node.children[x/half][y/half]
+		x, y = x%half, y%half
+	}
+	if node.isEmpty {
+		return g.defaultUnit
+	}
+	return node.unit
+}
+
+// Get the node of one level lower in the center of the given node.
+func (g *hashLifeGameInfo[T]) getCenterNode(node *hashLifeNode[T]) *hashLifeNode[T] {
+	return g.joinNodes([2][2]*hashLifeNode[T]{
+		{node.children[0][0].children[1][1], node.children[0][1].children[1][0]},
+		{node.children[1][0].children[0][1], node.children[1][1].children[0][0]},
+	})
+}
+
+// Tell you if all non-default units are in the center half of the node.
+func (g *hashLifeGameInfo[T]) isNodeCentered(node *hashLifeNode[T]) bool {
+	if node.isEmpty {
+		return true
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			for m := 0; m < 2; m++ {
+				for n := 0; n < 2; n++ {
+					if (m != 1-i || n != 1-j) && !node.children[i][j].children[m][n].isEmpty {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Get a node of one level higher with the given node in the center.
+func (g *hashLifeGameInfo[T]) expandNode(node *hashLifeNode[T]) *hashLifeNode[T] {
+	empty := g.getEmptyNode(node.level - 1)
+	children := node.children
+	return g.joinNodes([2][2]*hashLifeNode[T]{
+		{
+			g.joinNodes([2][2]*hashLifeNode[T]{{empty, empty}, {empty, children[0][0]}}),
+			g.joinNodes([2][2]*hashLifeNode[T]{{empty, empty}, {children[0][1], empty}}),
+		},
+		{
+			g.joinNodes([2][2]*hashLifeNode[T]{{empty, children[1][0]}, {empty, empty}}),
+			g.joinNodes([2][2]*hashLifeNode[T]{{children[1][1], empty}, {empty, empty}}),
+		},
+	})
+}
+
+// Double the size of root and keep it in the center.
+func (g *hashLifeGameInfo[T]) expandRoot() {
+	half := 1 << (g.root.level - 1)
+	g.root = g.expandNode(g.root)
+	g.origin.X -= half
+	g.origin.Y -= half
+}
+
+// Generate the center half of a base level node after one generation by calling NextUnitGenerator.
+func (g *hashLifeGameInfo[T]) generateBaseNodeResult(node *hashLifeNode[T]) *hashLifeNode[T] {
+	size := 1 << node.level
+	units := make([][]T, size)
+	for x := 0; x < size; x++ {
+		units[x] = make([]T, size)
+	}
+	g.flattenNode(node, units, 0, 0)
+
+	getAdjacentUnit := func(originCoord *Coordinate, relativeCoord *Coordinate) (*T, bool) {
+		targetX := originCoord.X + relativeCoord.X
+		targetY := originCoord.Y + relativeCoord.Y
+		if targetX < 0 || targetX >= size || targetY < 0 || targetY >= size {
+			return &g.defaultUnit, false
+		}
+		return &units[targetX][targetY], false
+	}
+
+	quarter := size / 4
+	nextUnits := make([][]T, size/2)
+	coord := Coordinate{}
+	for x := 0; x < size/2; x++ {
+		nextUnits[x] = make([]T, size/2)
+		for y := 0; y < size/2; y++ {
+			coord.X = x + quarter
+			coord.Y = y + quarter
+			nextUnits[x][y] = *g.nextUnitGenerator(&coord, &units[x+quarter][y+quarter], getAdjacentUnit)
+		}
+	}
+
+	return g.buildNode(nextUnits, 0, 0, node.level-1)
+}
+
+// Get the center half of the node after 2^exponent generations, the exponent can't exceed "node.level - baseLevel".
+func (g *hashLifeGameInfo[T]) generateNodeResult(node *hashLifeNode[T], exponent int) *hashLifeNode[T] {
+	if node.isEmpty {
+		return g.getEmptyNode(node.level - 1)
+	}
+	if result, exists := node.results[exponent]; exists {
+		return result
+	}
+
+	var result *hashLifeNode[T]
+	if node.level == g.baseLevel {
+		result = g.generateBaseNodeResult(node)
+	} else {
+		isFullStep := exponent == node.level-g.baseLevel
+
+		var grandchildren [4][4]*hashLifeNode[T]
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				grandchildren[i][j] = node.children[i/2][j/2].children[i%2][j%2]
+			}
+		}
+
+		// Get 9 overlapping sub nodes, step them forward by half of the generations only when it's a full step.
+		var subResults [3][3]*hashLifeNode[T]
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				subNode := g.joinNodes([2][2]*hashLifeNode[T]{
+					{grandchildren[i][j], grandchildren[i][j+1]},
+					{grandchildren[i+1][j], grandchildren[i+1][j+1]},
+				})
+				if isFullStep {
+					subResults[i][j] = g.generateNodeResult(subNode, exponent-1)
+				} else {
+					subResults[i][j] = g.getCenterNode(subNode)
+				}
+			}
+		}
+
+		nextExponent := exponent
+		if isFullStep {
+			nextExponent = exponent - 1
+		}
+		var children [2][2]*hashLifeNode[T]
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 2; j++ {
+				subNode := g.joinNodes([2][2]*hashLifeNode[T]{
+					{subResults[i][j], subResults[i][j+1]},
+					{subResults[i+1][j], subResults[i+1][j+1]},
+				})
+				children[i][j] = g.generateNodeResult(subNode, nextExponent)
+			}
+		}
+		result = g.joinNodes(children)
+	}
+
+	if node.results == nil {
+		node.results = make(map[int]*hashLifeNode[T])
+	}
+	node.results[exponent] = result
+	return result
+}
+
+func (g *hashLifeGameInfo[T]) jumpGenerations(exponent int) {
+	// Make sure all units are in the center quarter of root, so they can't travel outside of the result.
+	for g.root.level < exponent+g.baseLevel+1 || !g.isNodeCentered(g.root) {
+		g.expandRoot()
+	}
+	g.expandRoot()
+
+	quarter := 1 << (g.root.level - 2)
+	g.root = g.generateNodeResult(g.root, exponent)
+	g.origin.X += quarter
+	g.origin.Y += quarter
+
+	if len(g.nodes) > g.maxNodesCount {
+		g.collectNodes()
+	}
+}
+
+// Keep the node and all nodes in it in the new tables.
+func (g *hashLifeGameInfo[T]) keepNode(node *hashLifeNode[T], leaves map[T]*hashLifeNode[T], nodes map[[2][2]*hashLifeNode[T]]*hashLifeNode[T]) {
+	if node.level == 0 {
+		leaves[node.unit] = node
+		return
+	}
+	if _, exists := nodes[node.children]; exists {
+		return
+	}
+	node.results = nil
+	nodes[node.children] = node
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			g.keepNode(node.children[i][j], leaves, nodes)
+		}
+	}
+}
+
+// Rebuild tables with nodes in root and empty nodes only, memoized futures are forgotten since they point to other nodes.
+// If most nodes are still in root, the limit is doubled so we don't collect nodes in every generation.
+func (g *hashLifeGameInfo[T]) collectNodes() {
+	leaves := make(map[T]*hashLifeNode[T])
+	nodes := make(map[[2][2]*hashLifeNode[T]]*hashLifeNode[T])
+	g.keepNode(g.root, leaves, nodes)
+	for _, emptyNode := range g.emptyNodes {
+		g.keepNode(emptyNode, leaves, nodes)
+	}
+	g.leaves = leaves
+	g.nodes = nodes
+	if len(g.nodes) > g.maxNodesCount/2 {
+		g.maxNodesCount *= 2
+	}
+}
+
+// Jump 2^exponent generations at once.
+func (g *hashLifeGameInfo[T]) JumpGenerations(exponent int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if exponent < 0 {
+		return &ErrExponentIsInvalid{exponent}
+	}
+
+	g.jumpGenerations(exponent)
+	return nil
+}
+
+// Count units that are different in the two nodes of the same level starting at (fromX, fromY),
+// nodes are canonical, so the same parts of them are skipped at once.
+func (g *hashLifeGameInfo[T]) countChangedUnitsOfNodes(
+	node *hashLifeNode[T],
+	nextNode *hashLifeNode[T],
+	fromX int,
+	fromY int,
+	areUnitsEqual UnitsEqualityChecker[T],
+	report *StabilizationReport,
+) {
+	if node == nextNode {
+		return
+	}
+	if node.level == 0 {
+		if !areUnitsEqual(&node.unit, &nextNode.unit) {
+			report.addChangedUnit(fromX, fromY)
+		}
+		return
+	}
+	half := 1 << (node.level - 1)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			g.countChangedUnitsOfNodes(node.children[i][j], nextNode.children[i][j], fromX+i*half, fromY+j*half, areUnitsEqual, report)
+		}
+	}
+}
+
+// Generate next units, it's the same as JumpGenerations(0), but units in the boundary are built for you every time.
+func (g *hashLifeGameInfo[T]) GenerateNextUnits() *[][]T {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.jumpGenerations(0)
+	return g.getUnitsInBoundary()
+}
+
+// Generate next units one generation at a time until a generation changes nothing.
+func (g *hashLifeGameInfo[T]) GenerateNextUnitsUntilStable(maxGenerationsCount int, areUnitsEqual UnitsEqualityChecker[T]) (*StabilizationReport, error) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if maxGenerationsCount < 1 {
+		return nil, &ErrMaxGenerationsCountIsInvalid{maxGenerationsCount}
+	}
+
+	report := StabilizationReport{}
+	for i := 0; i < maxGenerationsCount; i++ {
+		root := g.root
+		g.jumpGenerations(0)
+		// Roots are always centered at (0, 0), so the smaller one is expanded until they are the same size.
+		nextRoot := g.root
+		for root.level < nextRoot.level {
+			root = g.expandNode(root)
+		}
+		for nextRoot.level < root.level {
+			nextRoot = g.expandNode(nextRoot)
+		}
+		origin := -(1 << (root.level - 1))
+
+		changedUnitsCount := report.ChangedUnitsCount
+		g.countChangedUnitsOfNodes(root, nextRoot, origin, origin, areUnitsEqual, &report)
+		if report.ChangedUnitsCount == changedUnitsCount {
+			return &report, nil
+		}
+		report.GenerationsCount += 1
+	}
+	return &report, &ErrUnitsAreNotStable{maxGenerationsCount}
+}
+
+// Set NextUnitGenerator, all memoized futures will be forgotten.
+func (g *hashLifeGameInfo[T]) SetNextUnitGenerator(nextUnitGenerator NextUnitGenerator[T]) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.setNextUnitGenerator(nextUnitGenerator, nil)
+}
+
+// Memoized futures are generated by the last NextUnitGenerator, so they are all forgotten.
+func (g *hashLifeGameInfo[T]) setNextUnitGenerator(nextUnitGenerator NextUnitGenerator[T], neighborhood *Neighborhood) {
+	g.nextUnitGenerator = nextUnitGenerator
+	g.neighborhood = neighborhood
+	for _, node := range g.nodes {
+		node.results = nil
+	}
+}
+
+// Set NeighborhoodNextUnitGenerator, neighbors are collected with AdjacentUnitGetter.
+func (g *hashLifeGameInfo[T]) SetNeighborhoodNextUnitGenerator(neighborhood *Neighborhood, nextUnitGenerator NeighborhoodNextUnitGenerator[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if err := validateNeighborhood(neighborhood, g.topology); err != nil {
+		return err
+	}
+	g.setNextUnitGenerator(convertNeighborhoodNextUnitGenerator(neighborhood, nextUnitGenerator), neighborhood)
+	return nil
+}
+
+// HashLife always generates units in one goroutine, so concurrency greater than 1 is not supported.
+func (g *hashLifeGameInfo[T]) SetConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return &ErrConcurrencyIsInvalid{concurrency}
+	}
+	if concurrency > 1 {
+		return &ErrConcurrencyIsNotSupported{concurrency}
+	}
+	return nil
+}
+
+// HashLife reuses generated futures, so only UpdateOrderSynchronous is supported.
+func (g *hashLifeGameInfo[T]) SetUpdateOrder(order UpdateOrder, seed int64) error {
+	if !order.isValid() {
+		return &ErrUpdateOrderIsInvalid{order}
+	}
+	if order != UpdateOrderSynchronous {
+		return &ErrUpdateOrderIsNotSupported{order}
+	}
+	return nil
+}
+
+// The game has no border, so you can't set boundary policy.
+func (g *hashLifeGameInfo[T]) SetBoundaryPolicy(policy BoundaryPolicy) error {
+	return &ErrBoundaryPolicyIsNotSupported{}
+}
+
+// HashLife reuses generated futures, so units can't depend on the generation or random numbers.
+func (g *hashLifeGameInfo[T]) SetNextUnitGeneratorWithContext(nextUnitGenerator NextUnitGeneratorWithContext[T]) error {
+	return &ErrNextUnitGeneratorWithContextIsNotSupported{}
+}
+
+// HashLife reuses generated futures of one NextUnitGenerator, so pipelines are not supported.
+func (g *hashLifeGameInfo[T]) SetNextUnitGeneratorPipeline(nextUnitGenerators ...NextUnitGeneratorWithContext[T]) error {
+	return &ErrNextUnitGeneratorPipelineIsNotSupported{}
+}
+
+// HashLife reuses generated futures of one NextUnitGenerator, so zones are not supported.
+func (g *hashLifeGameInfo[T]) AddZone(name string, zone *Zone[T]) error {
+	return &ErrZoneIsNotSupported{}
+}
+
+// No zone can be added, so no zone can be found.
+func (g *hashLifeGameInfo[T]) RemoveZone(name string) error {
+	return &ErrZoneIsNotFound{name}
+}
+
+// HashLife already reuses futures of unchanged areas, so active region tracking is not supported.
+func (g *hashLifeGameInfo[T]) SetActiveRegionTracking(areUnitsEqual UnitsEqualityChecker[T], radius int) error {
+	return &ErrActiveRegionTrackingIsNotSupported{}
+}
+
+// HashLife never passes GenerationContext into generators, so the seed is not supported.
+func (g *hashLifeGameInfo[T]) SetSeed(seed int64) error {
+	return &ErrSeedIsNotSupported{}
+}
+
+// HashLife never passes GenerationContext into generators, so params are not supported.
+func (g *hashLifeGameInfo[T]) SetParams(params any) error {
+	return &ErrParamsAreNotSupported{}
+}
+
+// HashLife reuses generated futures, so units can't depend on global state.
+func (g *hashLifeGameInfo[T]) SetGlobalState(globalState GlobalStateHolder) error {
+	return &ErrGlobalStateIsNotSupported{}
+}
+
+// HashLife reuses generated futures, so units can't depend on anything but adjacent units.
+func (g *hashLifeGameInfo[T]) SetGenerationPreparer(generationPreparer GenerationPreparer[T]) error {
+	return &ErrGenerationPreparerIsNotSupported{}
+}
+
+// The game has no border, so the boundary unit is not supported.
+func (g *hashLifeGameInfo[T]) SetBoundaryUnit(unit *T) error {
+	return &ErrBoundaryUnitIsNotSupported{}
+}
+
+// Set the topology.
+func (g *hashLifeGameInfo[T]) SetTopology(topology Topology) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if !topology.isValid() {
+		return &ErrTopologyIsInvalid{topology}
+	}
+	if g.neighborhood != nil && g.neighborhood.topology != topology {
+		return &ErrNeighborhoodTopologyIsMismatched{NeighborhoodTopology: g.neighborhood.topology, GameTopology: topology}
+	}
+	g.topology = topology
+
+	return nil
+}
+
+// Get the topology.
+func (g *hashLifeGameInfo[T]) GetTopology() Topology {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.topology
+}
+
+// Update the unit at the given coordinate, any coordinate is valid.
+func (g *hashLifeGameInfo[T]) SetUnit(c *Coordinate, unit *T) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	for {
+		size := 1 << g.root.level
+		if c.X >= g.origin.X && c.X < g.origin.X+size && c.Y >= g.origin.Y && c.Y < g.origin.Y+size {
+			break
+		}
+		g.expandRoot()
+	}
+	g.root = g.setUnitInNode(g.root, c.X-g.origin.X, c.Y-g.origin.Y, *unit)
+
+	return nil
+}
+
+func (g *hashLifeGameInfo[T]) getUnit(x int, y int) T {
+	size := 1 << g.root.level
+	if x < g.origin.X || x >= g.origin.X+size || y < g.origin.Y || y >= g.origin.Y+size {
+		return g.defaultUnit
+	}
+	return g.getUnitInNode(g.root, x-g.origin.X, y-g.origin.Y)
+}
+
+// Extend the boundary so it covers all non-default units in the node starting at (fromX, fromY).
+func (g *hashLifeGameInfo[T]) extendBoundary(boundary **Area, node *hashLifeNode[T], fromX int, fromY int) {
+	if node.isEmpty {
+		return
+	}
+	if node.level == 0 {
+		if *boundary == nil {
+			*boundary = &Area{From: Coordinate{X: fromX, Y: fromY}, To: Coordinate{X: fromX, Y: fromY}}
+			return
+		}
+		if fromX < (*boundary).From.X {
+			(*boundary).From.X = fromX
+		}
+		if fromY < (*boundary).From.Y {
+			(*boundary).From.Y = fromY
+		}
+		if fromX > (*boundary).To.X {
+			(*boundary).To.X = fromX
+		}
+		if fromY > (*boundary).To.Y {
+			(*boundary).To.Y = fromY
+		}
+		return
+	}
+	half := 1 << (node.level - 1)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			g.extendBoundary(boundary, node.children[i][j], fromX+i*half, fromY+j*half)
+		}
+	}
+}
+
+func (g *hashLifeGameInfo[T]) getBoundary() *Area {
+	var boundary *Area
+	g.extendBoundary(&boundary, g.root, g.origin.X, g.origin.Y)
+	return boundary
+}
+
+// Get the smallest area that covers all non-default units.
+func (g *hashLifeGameInfo[T]) GetBoundary() *Area {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.getBoundary()
+}
+
+// Get the size of the boundary.
+func (g *hashLifeGameInfo[T]) GetSize() *Size {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	boundary := g.getBoundary()
+	if boundary == nil {
+		return &Size{Width: 0, Height: 0}
+	}
+	return &Size{
+		Width:  boundary.To.X - boundary.From.X + 1,
+		Height: boundary.To.Y - boundary.From.Y + 1,
+	}
+}
+
+// Get the unit at the coordinate, any coordinate is valid.
+func (g *hashLifeGameInfo[T]) GetUnit(c *Coordinate) (*T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	unit := g.getUnit(c.X, c.Y)
+	return &unit, nil
+}
+
+func (g *hashLifeGameInfo[T]) getUnitsInArea(area *Area) *[][]T {
+	unitsInArea := make([][]T, 0)
+	for x := area.From.X; x <= area.To.X; x++ {
+		newRow := make([]T, 0)
+		for y := area.From.Y; y <= area.To.Y; y++ {
+			newRow = append(newRow, g.getUnit(x, y))
+		}
+		unitsInArea = append(unitsInArea, newRow)
+	}
+	return &unitsInArea
+}
+
+func (g *hashLifeGameInfo[T]) getUnitsInBoundary() *[][]T {
+	boundary := g.getBoundary()
+	if boundary == nil {
+		return &[][]T{}
+	}
+	return g.getUnitsInArea(boundary)
+}
+
+// Get all units in the boundary, units[0][0] is the unit at "From" of the boundary.
+func (g *hashLifeGameInfo[T]) GetUnits() *[][]T {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.getUnitsInBoundary()
+}
+
+// Get all units in the given area, the area can be anywhere.
+func (g *hashLifeGameInfo[T]) GetUnitsInArea(area *Area) (*[][]T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	if area.From.X > area.To.X || area.From.Y > area.To.Y {
+		return nil, &ErrAreaIsInvalid{area}
+	}
+
+	return g.getUnitsInArea(area), nil
+}
+
+// We will iterate all units in the boundary and call the callbacks with coordiante and unit.
+func (g *hashLifeGameInfo[T]) IterateUnits(callback UnitsIteratorCallback[T]) {
+	boundary := g.getBoundary()
+	if boundary == nil {
+		return
+	}
+	g.IterateUnitsInArea(boundary, callback)
+}
+
+// We will iterate all units in the given area and call the callbacks with coordiante and unit.
+func (g *hashLifeGameInfo[T]) IterateUnitsInArea(area *Area, callback UnitsIteratorCallback[T]) error {
+	if area.From.X > area.To.X || area.From.Y > area.To.Y {
+		return &ErrAreaIsInvalid{area}
+	}
+
+	for x := area.From.X; x <= area.To.X; x++ {
+		for y := area.From.Y; y <= area.To.Y; y++ {
+			unit := g.getUnit(x, y)
+			callback(&Coordinate{X: x, Y: y}, &unit)
+		}
+	}
+	return nil
+}
+
+// We will iterate all units within the distance from the center and call the callbacks with coordiante and unit.
+func (g *hashLifeGameInfo[T]) IterateUnitsWithinDistance(center *Coordinate, distance int, callback UnitsIteratorCallback[T]) error {
+	if distance < 0 {
+		return &ErrDistanceIsInvalid{distance}
+	}
+
+	resolve := func(coord *Coordinate) (Coordinate, bool) {
+		return *coord, true
+	}
+	iterateCoordinatesWithinDistance(g.topology, center, distance, resolve, func(coord *Coordinate) {
+		unit := g.getUnit(coord.X, coord.Y)
+		callback(coord, &unit)
+	})
+	return nil
+}
@@ -0,0 +1,414 @@
+package ggol
+
+import (
+	"testing"
+)
+
+// A rule that looks at units within radius 2, it's only used to test HashLifeGame with a larger radius.
+func radiusTwoUnitForTestIterator(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+	var aliveAdjacentCellsCount int = 0
+	for i := -2; i < 3; i += 1 {
+		for j := -2; j < 3; j += 1 {
+			if !(i == 0 && j == 0) {
+				adjUnit, _ := getAdjacentUnit(coord, &Coordinate{X: i, Y: j})
+				if adjUnit.hasLiveCell {
+					aliveAdjacentCellsCount += 1
+				}
+			}
+		}
+	}
+	if aliveAdjacentCellsCount >= 4 && aliveAdjacentCellsCount <= 6 || (unit.hasLiveCell && aliveAdjacentCellsCount == 3) {
+		return &liveUnitForTest
+	}
+	return &deadUnitForTest
+}
+
+func areHashLifeGameAndInfiniteGameEqualInArea(hashLifeGame HashLifeGame[unitForTest], infiniteGame InfiniteGame[unitForTest], area *Area) bool {
+	hashLifeUnits, _ := hashLifeGame.GetUnitsInArea(area)
+	infiniteUnits, _ := infiniteGame.GetUnitsInArea(area)
+	return areTwoUnitsHavingLiveCellForTestEqual(
+		*convertUnitForTestMatrixToUnitsHavingLiveCellForTest(hashLifeUnits),
+		*convertUnitForTestMatrixToUnitsHavingLiveCellForTest(infiniteUnits),
+	)
+}
+
+func testHashLifeGameGliderPattern(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	g.SetNextUnitGenerator(defauUnitForTestIterator)
+
+	// Make a glider pattern
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 2, Y: 2}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 3, Y: 2}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 3}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 2, Y: 3}, &unitForTest{hasLiveCell: true})
+
+	// The glider moves one unit diagonally every 4 generations, so it moves 2^18 units after 2^20 generations.
+	g.JumpGenerations(20)
+	step := 1 << 18
+
+	expectedLiveCoords := []Coordinate{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 2}, {X: 1, Y: 3}, {X: 2, Y: 3}}
+	for _, coord := range expectedLiveCoords {
+		unit, _ := g.GetUnit(&Coordinate{X: coord.X + step, Y: coord.Y + step})
+		if !unit.hasLiveCell {
+			t.Fatalf("Should still be a glider pattern, but (%v, %v) is dead.", coord.X+step, coord.Y+step)
+		}
+	}
+	boundary := g.GetBoundary()
+	if boundary.From.X != 1+step || boundary.From.Y != 1+step || boundary.To.X != 3+step || boundary.To.Y != 3+step {
+		t.Fatalf("Boundary should only cover the glider, but got %v.", boundary)
+	}
+
+	t.Log("Passed")
+}
+
+func testHashLifeGameSoupPattern(t *testing.T) {
+	hashLifeGame, _ := NewHashLifeGame(initialUnitForTest, 1)
+	hashLifeGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	infiniteGame := NewInfiniteGame(initialUnitForTest)
+	infiniteGame.SetNextUnitGenerator(defauUnitForTestIterator)
+
+	for x := -10; x < 10; x++ {
+		for y := -10; y < 10; y++ {
+			unit := unitForTest{hasLiveCell: (x*7+y*13+x*y)%3 == 0}
+			hashLifeGame.SetUnit(&Coordinate{X: x, Y: y}, &unit)
+			infiniteGame.SetUnit(&Coordinate{X: x, Y: y}, &unit)
+		}
+	}
+
+	area := Area{From: Coordinate{X: -60, Y: -60}, To: Coordinate{X: 60, Y: 60}}
+	for i := 0; i < 10; i++ {
+		hashLifeGame.GenerateNextUnits()
+		infiniteGame.GenerateNextUnits()
+		if !areHashLifeGameAndInfiniteGameEqualInArea(hashLifeGame, infiniteGame, &area) {
+			t.Fatalf("Generation %v of HashLifeGame is not identical to InfiniteGame.", i+1)
+		}
+	}
+
+	// Jump 2^5 generations and compare it with 32 generations of the infinite game.
+	hashLifeGame.JumpGenerations(5)
+	for i := 0; i < 32; i++ {
+		infiniteGame.GenerateNextUnits()
+	}
+	if !areHashLifeGameAndInfiniteGameEqualInArea(hashLifeGame, infiniteGame, &area) {
+		t.Fatalf("Jumping 32 generations of HashLifeGame is not identical to InfiniteGame.")
+	}
+
+	t.Log("Passed")
+}
+
+func testHashLifeGameWithRadiusTwo(t *testing.T) {
+	hashLifeGame, _ := NewHashLifeGame(initialUnitForTest, 2)
+	hashLifeGame.SetNextUnitGenerator(radiusTwoUnitForTestIterator)
+	infiniteGame := NewInfiniteGame(initialUnitForTest)
+	infiniteGame.SetNextUnitGenerator(radiusTwoUnitForTestIterator)
+
+	for x := -4; x < 4; x++ {
+		for y := -4; y < 4; y++ {
+			unit := unitForTest{hasLiveCell: (x*5+y*3)%4 == 0}
+			hashLifeGame.SetUnit(&Coordinate{X: x, Y: y}, &unit)
+			infiniteGame.SetUnit(&Coordinate{X: x, Y: y}, &unit)
+		}
+	}
+
+	hashLifeGame.JumpGenerations(3)
+	for i := 0; i < 8; i++ {
+		infiniteGame.GenerateNextUnits()
+	}
+	area := Area{From: Coordinate{X: -40, Y: -40}, To: Coordinate{X: 40, Y: 40}}
+	if !areHashLifeGameAndInfiniteGameEqualInArea(hashLifeGame, infiniteGame, &area) {
+		t.Fatalf("Jumping 8 generations of HashLifeGame with radius 2 is not identical to InfiniteGame.")
+	}
+
+	t.Log("Passed")
+}
+
+func testHashLifeGameCollectNodes(t *testing.T) {
+	hashLifeGame, _ := NewHashLifeGame(initialUnitForTest, 1)
+	hashLifeGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	hashLifeGameInfo := hashLifeGame.(*hashLifeGameInfo[unitForTest])
+	hashLifeGameInfo.maxNodesCount = 500
+	infiniteGame := NewInfiniteGame(initialUnitForTest)
+	infiniteGame.SetNextUnitGenerator(defauUnitForTestIterator)
+
+	// A glider and a blinker, so nodes keep changing.
+	for _, coord := range []Coordinate{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 2}, {X: 1, Y: 3}, {X: 2, Y: 3}, {X: -10, Y: 0}, {X: -10, Y: 1}, {X: -10, Y: 2}} {
+		hashLifeGame.SetUnit(&coord, &unitForTest{hasLiveCell: true})
+		infiniteGame.SetUnit(&coord, &unitForTest{hasLiveCell: true})
+	}
+
+	area := Area{From: Coordinate{X: -20, Y: -20}, To: Coordinate{X: 120, Y: 120}}
+	for i := 0; i < 400; i++ {
+		hashLifeGame.JumpGenerations(0)
+		infiniteGame.GenerateNextUnits()
+		if len(hashLifeGameInfo.nodes) > hashLifeGameInfo.maxNodesCount {
+			t.Fatalf("Generation %v: should keep at most %v nodes, but got %v.", i+1, hashLifeGameInfo.maxNodesCount, len(hashLifeGameInfo.nodes))
+		}
+	}
+	if hashLifeGameInfo.maxNodesCount != 500 {
+		t.Fatalf("Nodes in root are much fewer than the limit, so the limit should not grow, but got %v.", hashLifeGameInfo.maxNodesCount)
+	}
+	if !areHashLifeGameAndInfiniteGameEqualInArea(hashLifeGame, infiniteGame, &area) {
+		t.Fatalf("HashLifeGame collecting nodes is not identical to InfiniteGame.")
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameJumpGenerations(t *testing.T) {
+	testHashLifeGameCollectNodes(t)
+	testHashLifeGameGliderPattern(t)
+	testHashLifeGameSoupPattern(t)
+	testHashLifeGameWithRadiusTwo(t)
+}
+
+func testHashLifeGameGenerateNextUnitsUntilStableCaseOne(t *testing.T) {
+	hashLifeGame, _ := NewHashLifeGame(0, 1)
+	hashLifeGame.SetNextUnitGenerator(decayingUnitForTestIterator)
+	infiniteGame := NewInfiniteGame(0)
+	infiniteGame.SetNextUnitGenerator(decayingUnitForTestIterator)
+	units := []int{40, 3}
+	for _, g := range []Game[int]{hashLifeGame, infiniteGame} {
+		g.SetUnit(&Coordinate{X: -20, Y: -3}, &units[0])
+		g.SetUnit(&Coordinate{X: 5, Y: 9}, &units[1])
+	}
+
+	// Roots grow while units spread, so they are compared at different sizes.
+	hashLifeReport, err := hashLifeGame.GenerateNextUnitsUntilStable(100, AreUnitsEqual[int])
+	if err != nil {
+		t.Fatalf("Units should be stable, but got error: %v.", err)
+	}
+	infiniteReport, _ := infiniteGame.GenerateNextUnitsUntilStable(100, AreUnitsEqual[int])
+	if hashLifeReport.GenerationsCount != infiniteReport.GenerationsCount ||
+		hashLifeReport.ChangedUnitsCount != infiniteReport.ChangedUnitsCount ||
+		*hashLifeReport.ChangedArea != *infiniteReport.ChangedArea {
+		t.Fatalf("Report should be %v and %v, but got %v and %v.", infiniteReport, infiniteReport.ChangedArea, hashLifeReport, hashLifeReport.ChangedArea)
+	}
+	if unit, _ := hashLifeGame.GetUnit(&Coordinate{X: 19, Y: -3}); *unit != 1 {
+		t.Fatalf("Unit at (19, -3) should be 1, but got %v.", *unit)
+	}
+	t.Log("Passed")
+}
+
+func testHashLifeGameGenerateNextUnitsUntilStableCaseTwo(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	g.SetNextUnitGenerator(defauUnitForTestIterator)
+	for x := 0; x < 3; x++ {
+		g.SetUnit(&Coordinate{X: x, Y: 0}, &unitForTest{hasLiveCell: true})
+	}
+	report, err := g.GenerateNextUnitsUntilStable(4, AreUnitsEqual[unitForTest])
+	if _, ok := err.(*ErrUnitsAreNotStable); !ok {
+		t.Fatalf("Blinker should not be stable, but got error: %v.", err)
+	}
+	if report.GenerationsCount != 4 || report.ChangedUnitsCount != 16 {
+		t.Fatalf("Blinker should change 4 units in every generation, but got %v.", report)
+	}
+	if _, err := g.GenerateNextUnitsUntilStable(0, AreUnitsEqual[unitForTest]); err == nil {
+		t.Fatalf("Should get error when max generations count is 0.")
+	} else if _, ok := err.(*ErrMaxGenerationsCountIsInvalid); !ok {
+		t.Fatalf("Should get ErrMaxGenerationsCountIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameGenerateNextUnitsUntilStable(t *testing.T) {
+	testHashLifeGameGenerateNextUnitsUntilStableCaseOne(t)
+	testHashLifeGameGenerateNextUnitsUntilStableCaseTwo(t)
+}
+
+func testNewHashLifeGameCaseOne(t *testing.T) {
+	_, err := NewHashLifeGame(initialUnitForTest, 0)
+	if err == nil {
+		t.Fatalf("Should get error when radius is less than 1.")
+	}
+
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err = g.JumpGenerations(-1)
+	if err == nil {
+		t.Fatalf("Should get error when exponent is negative.")
+	}
+	t.Log("Passed")
+}
+
+func TestNewHashLifeGame(t *testing.T) {
+	testNewHashLifeGameCaseOne(t)
+}
+
+func testHashLifeGameSetUnitCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	c := Coordinate{X: -12345, Y: 678}
+	g.SetUnit(&c, &unitForTest{hasLiveCell: true})
+	unit, _ := g.GetUnit(&c)
+	if !unit.hasLiveCell {
+		t.Fatalf("Should correctly set unit at any coordinate.")
+	}
+
+	size := g.GetSize()
+	if size.Width != 1 || size.Height != 1 {
+		t.Fatalf("Size should be 1 x 1, but got %v x %v.", size.Width, size.Height)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetUnit(t *testing.T) {
+	testHashLifeGameSetUnitCaseOne(t)
+}
+
+func testHashLifeGameSetGenerationPreparerCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetGenerationPreparer(func(area *Area, getAdjacentUnit AdjacentUnitGetter[unitForTest]) {})
+	if _, ok := err.(*ErrGenerationPreparerIsNotSupported); !ok {
+		t.Fatalf("Should get ErrGenerationPreparerIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetGenerationPreparer(t *testing.T) {
+	testHashLifeGameSetGenerationPreparerCaseOne(t)
+}
+
+func testHashLifeGameSetUpdateOrderCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	if err := g.SetUpdateOrder(UpdateOrderSynchronous, 0); err != nil {
+		t.Fatalf("Should support UpdateOrderSynchronous, but got %v.", err)
+	}
+	if _, ok := g.SetUpdateOrder(UpdateOrderRandom, 0).(*ErrUpdateOrderIsNotSupported); !ok {
+		t.Fatalf("Should get ErrUpdateOrderIsNotSupported for UpdateOrderRandom.")
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetUpdateOrder(t *testing.T) {
+	testHashLifeGameSetUpdateOrderCaseOne(t)
+}
+
+func testHashLifeGameSetNextUnitGeneratorWithContextCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetNextUnitGeneratorWithContext(func(context *GenerationContext, coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+		return unit
+	})
+	if _, ok := err.(*ErrNextUnitGeneratorWithContextIsNotSupported); !ok {
+		t.Fatalf("Should get ErrNextUnitGeneratorWithContextIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetNextUnitGeneratorWithContext(t *testing.T) {
+	testHashLifeGameSetNextUnitGeneratorWithContextCaseOne(t)
+}
+
+func testHashLifeGameSetGlobalStateCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetGlobalState(NewGlobalState(0, func(state int, contributions []int) int {
+		return state
+	}))
+	if _, ok := err.(*ErrGlobalStateIsNotSupported); !ok {
+		t.Fatalf("Should get ErrGlobalStateIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetGlobalState(t *testing.T) {
+	testHashLifeGameSetGlobalStateCaseOne(t)
+}
+
+func testHashLifeGameSetNextUnitGeneratorPipelineCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetNextUnitGeneratorPipeline(func(context *GenerationContext, coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+		return unit
+	})
+	if _, ok := err.(*ErrNextUnitGeneratorPipelineIsNotSupported); !ok {
+		t.Fatalf("Should get ErrNextUnitGeneratorPipelineIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetNextUnitGeneratorPipeline(t *testing.T) {
+	testHashLifeGameSetNextUnitGeneratorPipelineCaseOne(t)
+}
+
+func testHashLifeGameAddZoneCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.AddZone("a", &Zone[unitForTest]{Area: &Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: 1, Y: 1}}, NextUnitGenerator: func(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+		return unit
+	}})
+	if _, ok := err.(*ErrZoneIsNotSupported); !ok {
+		t.Fatalf("Should get ErrZoneIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameAddZone(t *testing.T) {
+	testHashLifeGameAddZoneCaseOne(t)
+}
+
+func testHashLifeGameSetActiveRegionTrackingCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetActiveRegionTracking(AreUnitsEqual[unitForTest], 1)
+	if _, ok := err.(*ErrActiveRegionTrackingIsNotSupported); !ok {
+		t.Fatalf("Should get ErrActiveRegionTrackingIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetActiveRegionTracking(t *testing.T) {
+	testHashLifeGameSetActiveRegionTrackingCaseOne(t)
+}
+
+func testHashLifeGameSetConcurrencyCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	if err := g.SetConcurrency(1); err != nil {
+		t.Fatalf("Should be able to set concurrency 1, but got %v.", err)
+	}
+	err := g.SetConcurrency(4)
+	if _, ok := err.(*ErrConcurrencyIsNotSupported); !ok {
+		t.Fatalf("Should get ErrConcurrencyIsNotSupported, but got %v.", err)
+	}
+	err = g.SetConcurrency(0)
+	if _, ok := err.(*ErrConcurrencyIsInvalid); !ok {
+		t.Fatalf("Should get ErrConcurrencyIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetConcurrency(t *testing.T) {
+	testHashLifeGameSetConcurrencyCaseOne(t)
+}
+
+func testHashLifeGameSetSeedCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetSeed(42)
+	if _, ok := err.(*ErrSeedIsNotSupported); !ok {
+		t.Fatalf("Should get ErrSeedIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetSeed(t *testing.T) {
+	testHashLifeGameSetSeedCaseOne(t)
+}
+
+func testHashLifeGameSetParamsCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetParams(5)
+	if _, ok := err.(*ErrParamsAreNotSupported); !ok {
+		t.Fatalf("Should get ErrParamsAreNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetParams(t *testing.T) {
+	testHashLifeGameSetParamsCaseOne(t)
+}
+
+func testHashLifeGameSetBoundaryUnitCaseOne(t *testing.T) {
+	g, _ := NewHashLifeGame(initialUnitForTest, 1)
+	err := g.SetBoundaryUnit(&liveUnitForTest)
+	if _, ok := err.(*ErrBoundaryUnitIsNotSupported); !ok {
+		t.Fatalf("Should get ErrBoundaryUnitIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestHashLifeGameSetBoundaryUnit(t *testing.T) {
+	testHashLifeGameSetBoundaryUnitCaseOne(t)
+}
@@ -0,0 +1,596 @@
+package ggol
+
+import (
+	"sort"
+	"sync"
+)
+
+// The width and height of a chunk in the infinite game.
+const infiniteGameChunkSize = 16
+
+// "T" in the InfiniteGame interface represents the type of unit, it has to be comparable so we know which units are default units.
+// InfiniteGame is a Game without border, coordinates can be any signed integers.
+// Only chunks around non-default units are stored, all other units are the default unit.
+type InfiniteGame[T comparable] interface {
+	Game[T]
+	// Get an area that covers all non-default units, it's nil when all units are default units.
+	// GetSize, GetUnits and GenerateNextUnits are all based on this area.
+	GetBoundary() (area *Area)
+}
+
+type infiniteGameChunk[T comparable] struct {
+	units     [infiniteGameChunkSize][infiniteGameChunkSize]T
+	nextUnits [infiniteGameChunkSize][infiniteGameChunkSize]T
+}
+
+type infiniteGameInfo[T comparable] struct {
+	defaultUnit T
+	// Chunks are keyed by chunk coordinate, chunk (1, 0) covers units from (16, 0) to (31, 15).
+	chunks             map[Coordinate]*infiniteGameChunk[T]
+	nextUnitGenerator  NextUnitGenerator[T]
+	generationPreparer GenerationPreparer[T]
+	// When nextUnitGeneratorWithContext is set, it's used instead of nextUnitGenerator.
+	nextUnitGeneratorWithContext NextUnitGeneratorWithContext[T]
+	// The neighborhood of nextUnitGenerator when it's set with SetNeighborhoodNextUnitGenerator.
+	neighborhood     *Neighborhood
+	seed             int64
+	params           any
+	globalState      GlobalStateHolder
+	generationsCount int
+	concurrency      int
+	topology         Topology
+	locker           sync.RWMutex
+}
+
+// Return a new InfiniteGame, all units are "defaultUnit" at the beginning.
+// Your NextUnitGenerator has to keep a default unit default when all units around it are default,
+// and it can only look at adjacent units within 16 units, otherwise units far away from others will be missed.
+func NewInfiniteGame[T comparable](defaultUnit T) InfiniteGame[T] {
+	return &infiniteGameInfo[T]{
+		defaultUnit:       defaultUnit,
+		chunks:            make(map[Coordinate]*infiniteGameChunk[T]),
+		nextUnitGenerator: defaultNextUnitGenerator[T],
+		concurrency:       1,
+		locker:            sync.RWMutex{},
+	}
+}
+
+// Divide "a" by "b" and round it down, so negative coordinates are put into correct chunks.
+func floorDivide(a int, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q -= 1
+	}
+	return q
+}
+
+// Get the modulus of "a" by "b" that is always positive.
+func floorModulo(a int, b int) int {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
+
+func (g *infiniteGameInfo[T]) newChunk() *infiniteGameChunk[T] {
+	chunk := infiniteGameChunk[T]{}
+	for x := 0; x < infiniteGameChunkSize; x++ {
+		for y := 0; y < infiniteGameChunkSize; y++ {
+			chunk.units[x][y] = g.defaultUnit
+		}
+	}
+	return &chunk
+}
+
+func (g *infiniteGameInfo[T]) isChunkEmpty(chunk *infiniteGameChunk[T]) bool {
+	for x := 0; x < infiniteGameChunkSize; x++ {
+		for y := 0; y < infiniteGameChunkSize; y++ {
+			if chunk.units[x][y] != g.defaultUnit {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Get the pointer to the unit at (x, y), you will get the pointer of default unit if the chunk is not stored.
+func (g *infiniteGameInfo[T]) getUnitPointer(x int, y int) *T {
+	chunk, exists := g.chunks[Coordinate{X: floorDivide(x, infiniteGameChunkSize), Y: floorDivide(y, infiniteGameChunkSize)}]
+	if !exists {
+		return &g.defaultUnit
+	}
+	return &chunk.units[floorModulo(x, infiniteGameChunkSize)][floorModulo(y, infiniteGameChunkSize)]
+}
+
+func (g *infiniteGameInfo[T]) getAdjacentUnit(
+	originCoord *Coordinate,
+	relativeCoord *Coordinate,
+) (unit *T, crossBorder bool) {
+	return g.getUnitPointer(originCoord.X+relativeCoord.X, originCoord.Y+relativeCoord.Y), false
+}
+
+// Get stored chunk coordinates in order, so we always iterate chunks in the same way.
+func (g *infiniteGameInfo[T]) getSortedChunkCoords() []Coordinate {
+	chunkCoords := make([]Coordinate, 0, len(g.chunks))
+	for chunkCoord := range g.chunks {
+		chunkCoords = append(chunkCoords, chunkCoord)
+	}
+	sort.Slice(chunkCoords, func(i, j int) bool {
+		if chunkCoords[i].X != chunkCoords[j].X {
+			return chunkCoords[i].X < chunkCoords[j].X
+		}
+		return chunkCoords[i].Y < chunkCoords[j].Y
+	})
+	return chunkCoords
+}
+
+func (g *infiniteGameInfo[T]) generateNextUnitsOfChunks(worker int, chunkCoords []Coordinate, size *Size) {
+	coord := Coordinate{}
+	context := GenerationContext{}
+	context.prepare(worker, g.seed, g.generationsCount, size, g.params)
+	for _, chunkCoord := range chunkCoords {
+		chunk := g.chunks[chunkCoord]
+		for x := 0; x < infiniteGameChunkSize; x++ {
+			for y := 0; y < infiniteGameChunkSize; y++ {
+				coord.X = chunkCoord.X*infiniteGameChunkSize + x
+				coord.Y = chunkCoord.Y*infiniteGameChunkSize + y
+				var nextUnit *T
+				if g.nextUnitGeneratorWithContext != nil {
+					context.reset(&coord, 0)
+					nextUnit = g.nextUnitGeneratorWithContext(&context, &coord, &chunk.units[x][y], g.getAdjacentUnit)
+				} else {
+					nextUnit = g.nextUnitGenerator(&coord, &chunk.units[x][y], g.getAdjacentUnit)
+				}
+				chunk.nextUnits[x][y] = *nextUnit
+			}
+		}
+	}
+}
+
+// Get the area that covers all units of the given sorted chunks.
+func (g *infiniteGameInfo[T]) getAreaOfChunks(chunkCoords []Coordinate) *Area {
+	fromChunkCoord := chunkCoords[0]
+	toChunkCoord := chunkCoords[len(chunkCoords)-1]
+	for _, chunkCoord := range chunkCoords {
+		if chunkCoord.Y < fromChunkCoord.Y {
+			fromChunkCoord.Y = chunkCoord.Y
+		}
+		if chunkCoord.Y > toChunkCoord.Y {
+			toChunkCoord.Y = chunkCoord.Y
+		}
+	}
+	return &Area{
+		From: Coordinate{X: fromChunkCoord.X * infiniteGameChunkSize, Y: fromChunkCoord.Y * infiniteGameChunkSize},
+		To:   Coordinate{X: (toChunkCoord.X+1)*infiniteGameChunkSize - 1, Y: (toChunkCoord.Y+1)*infiniteGameChunkSize - 1},
+	}
+}
+
+// Generate next units of all stored chunks and the chunks around them, empty chunks will be dropped afterwards.
+// When "report" is not nil, changed units are counted into it with "areUnitsEqual".
+func (g *infiniteGameInfo[T]) generateNextUnits(areUnitsEqual UnitsEqualityChecker[T], report *StabilizationReport) {
+	// Units next to stored chunks might become non-default units, so we prepare the chunks around them.
+	for _, chunkCoord := range g.getSortedChunkCoords() {
+		for i := -1; i < 2; i += 1 {
+			for j := -1; j < 2; j += 1 {
+				adjChunkCoord := Coordinate{X: chunkCoord.X + i, Y: chunkCoord.Y + j}
+				if _, exists := g.chunks[adjChunkCoord]; !exists {
+					g.chunks[adjChunkCoord] = g.newChunk()
+				}
+			}
+		}
+	}
+
+	chunkCoords := g.getSortedChunkCoords()
+	size := &Size{}
+	if len(chunkCoords) > 0 {
+		area := g.getAreaOfChunks(chunkCoords)
+		size = &Size{Width: area.To.X - area.From.X + 1, Height: area.To.Y - area.From.Y + 1}
+		if g.generationPreparer != nil {
+			g.generationPreparer(area, g.getAdjacentUnit)
+		}
+	}
+	workersCount := g.concurrency
+	if workersCount > len(chunkCoords) {
+		workersCount = len(chunkCoords)
+	}
+	if g.globalState != nil {
+		g.globalState.prepareGeneration(g.concurrency)
+	}
+	if workersCount <= 1 {
+		g.generateNextUnitsOfChunks(0, chunkCoords, size)
+	} else {
+		chunksCountPerWorker := (len(chunkCoords) + workersCount - 1) / workersCount
+		wg := sync.WaitGroup{}
+		for worker := 0; worker*chunksCountPerWorker < len(chunkCoords); worker++ {
+			from := worker * chunksCountPerWorker
+			to := from + chunksCountPerWorker
+			if to > len(chunkCoords) {
+				to = len(chunkCoords)
+			}
+			wg.Add(1)
+			go func(worker int, chunkCoords []Coordinate) {
+				defer wg.Done()
+				g.generateNextUnitsOfChunks(worker, chunkCoords, size)
+			}(worker, chunkCoords[from:to])
+		}
+		wg.Wait()
+	}
+	// Chunks are sorted and every worker takes care of successive chunks,
+	// so contributions collected in the order of workers are in the same order as generating serially.
+	if g.globalState != nil {
+		g.globalState.reduceContributions()
+	}
+
+	for _, chunkCoord := range chunkCoords {
+		chunk := g.chunks[chunkCoord]
+		if report != nil {
+			g.countChangedUnitsOfChunk(chunkCoord, chunk, areUnitsEqual, report)
+		}
+		chunk.units, chunk.nextUnits = chunk.nextUnits, chunk.units
+		if g.isChunkEmpty(chunk) {
+			delete(g.chunks, chunkCoord)
+		}
+	}
+	g.generationsCount += 1
+}
+
+func (g *infiniteGameInfo[T]) countChangedUnitsOfChunk(
+	chunkCoord Coordinate,
+	chunk *infiniteGameChunk[T],
+	areUnitsEqual UnitsEqualityChecker[T],
+	report *StabilizationReport,
+) {
+	for x := 0; x < infiniteGameChunkSize; x++ {
+		for y := 0; y < infiniteGameChunkSize; y++ {
+			if !areUnitsEqual(&chunk.units[x][y], &chunk.nextUnits[x][y]) {
+				report.addChangedUnit(chunkCoord.X*infiniteGameChunkSize+x, chunkCoord.Y*infiniteGameChunkSize+y)
+			}
+		}
+	}
+}
+
+// Generate next units of all stored chunks and the chunks around them, empty chunks will be dropped afterwards.
+func (g *infiniteGameInfo[T]) GenerateNextUnits() *[][]T {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.generateNextUnits(nil, nil)
+	return g.getUnitsInBoundary()
+}
+
+// Generate next units until a generation changes nothing, only stored chunks and the chunks around them are compared.
+func (g *infiniteGameInfo[T]) GenerateNextUnitsUntilStable(maxGenerationsCount int, areUnitsEqual UnitsEqualityChecker[T]) (*StabilizationReport, error) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if maxGenerationsCount < 1 {
+		return nil, &ErrMaxGenerationsCountIsInvalid{maxGenerationsCount}
+	}
+
+	report := StabilizationReport{}
+	for i := 0; i < maxGenerationsCount; i++ {
+		changedUnitsCount := report.ChangedUnitsCount
+		g.generateNextUnits(areUnitsEqual, &report)
+		if report.ChangedUnitsCount == changedUnitsCount {
+			return &report, nil
+		}
+		report.GenerationsCount += 1
+	}
+	return &report, &ErrUnitsAreNotStable{maxGenerationsCount}
+}
+
+func (g *infiniteGameInfo[T]) SetNextUnitGenerator(nextUnitGenerator NextUnitGenerator[T]) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.nextUnitGenerator = nextUnitGenerator
+	g.nextUnitGeneratorWithContext = nil
+	g.neighborhood = nil
+}
+
+// Set NextUnitGeneratorWithContext, like NextUnitGenerator, it has to keep a default unit default when all units around it are default.
+func (g *infiniteGameInfo[T]) SetNextUnitGeneratorWithContext(nextUnitGenerator NextUnitGeneratorWithContext[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.nextUnitGeneratorWithContext = nextUnitGenerator
+	g.neighborhood = nil
+	return nil
+}
+
+// Chunks around non-default units are only added once a generation, so phases might miss units outside of them.
+func (g *infiniteGameInfo[T]) SetNextUnitGeneratorPipeline(nextUnitGenerators ...NextUnitGeneratorWithContext[T]) error {
+	return &ErrNextUnitGeneratorPipelineIsNotSupported{}
+}
+
+// Zones are decided by units of the game size, and the game has no size.
+func (g *infiniteGameInfo[T]) AddZone(name string, zone *Zone[T]) error {
+	return &ErrZoneIsNotSupported{}
+}
+
+// No zone can be added, so no zone can be found.
+func (g *infiniteGameInfo[T]) RemoveZone(name string) error {
+	return &ErrZoneIsNotFound{name}
+}
+
+// Chunks full of default units are already dropped, so active region tracking is not supported.
+func (g *infiniteGameInfo[T]) SetActiveRegionTracking(areUnitsEqual UnitsEqualityChecker[T], radius int) error {
+	return &ErrActiveRegionTrackingIsNotSupported{}
+}
+
+// Set the seed of random numbers in GenerationContext.
+func (g *infiniteGameInfo[T]) SetSeed(seed int64) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.seed = seed
+	return nil
+}
+
+// Set params shared by all units in GenerationContext.
+func (g *infiniteGameInfo[T]) SetParams(params any) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.params = params
+	return nil
+}
+
+// Set GlobalState, chunks are generated in order, so contributions are in the same order no matter how many goroutines generate units.
+func (g *infiniteGameInfo[T]) SetGlobalState(globalState GlobalStateHolder) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.globalState = globalState
+	return nil
+}
+
+// Set NeighborhoodNextUnitGenerator, neighbors are collected with AdjacentUnitGetter.
+func (g *infiniteGameInfo[T]) SetNeighborhoodNextUnitGenerator(neighborhood *Neighborhood, nextUnitGenerator NeighborhoodNextUnitGenerator[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if err := validateNeighborhood(neighborhood, g.topology); err != nil {
+		return err
+	}
+	g.nextUnitGenerator = convertNeighborhoodNextUnitGenerator(neighborhood, nextUnitGenerator)
+	g.nextUnitGeneratorWithContext = nil
+	g.neighborhood = neighborhood
+	return nil
+}
+
+// Set GenerationPreparer, "area" covers all chunks that will be generated.
+func (g *infiniteGameInfo[T]) SetGenerationPreparer(generationPreparer GenerationPreparer[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.generationPreparer = generationPreparer
+	return nil
+}
+
+// Set the max count of goroutines used to generate next units, chunks will be evenly distributed to them.
+func (g *infiniteGameInfo[T]) SetConcurrency(concurrency int) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if concurrency < 1 {
+		return &ErrConcurrencyIsInvalid{concurrency}
+	}
+	g.concurrency = concurrency
+
+	return nil
+}
+
+// Units are generated chunk by chunk, so only UpdateOrderSynchronous is supported.
+func (g *infiniteGameInfo[T]) SetUpdateOrder(order UpdateOrder, seed int64) error {
+	if !order.isValid() {
+		return &ErrUpdateOrderIsInvalid{order}
+	}
+	if order != UpdateOrderSynchronous {
+		return &ErrUpdateOrderIsNotSupported{order}
+	}
+	return nil
+}
+
+// The game has no border, so you can't set boundary policy.
+func (g *infiniteGameInfo[T]) SetBoundaryPolicy(policy BoundaryPolicy) error {
+	return &ErrBoundaryPolicyIsNotSupported{}
+}
+
+// The game has no border, so the boundary unit is not supported.
+func (g *infiniteGameInfo[T]) SetBoundaryUnit(unit *T) error {
+	return &ErrBoundaryUnitIsNotSupported{}
+}
+
+// Set the topology.
+func (g *infiniteGameInfo[T]) SetTopology(topology Topology) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if !topology.isValid() {
+		return &ErrTopologyIsInvalid{topology}
+	}
+	if g.neighborhood != nil && g.neighborhood.topology != topology {
+		return &ErrNeighborhoodTopologyIsMismatched{NeighborhoodTopology: g.neighborhood.topology, GameTopology: topology}
+	}
+	g.topology = topology
+
+	return nil
+}
+
+// Get the topology.
+func (g *infiniteGameInfo[T]) GetTopology() Topology {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.topology
+}
+
+// Update the unit at the given coordinate, any coordinate is valid.
+func (g *infiniteGameInfo[T]) SetUnit(c *Coordinate, unit *T) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	chunkCoord := Coordinate{X: floorDivide(c.X, infiniteGameChunkSize), Y: floorDivide(c.Y, infiniteGameChunkSize)}
+	chunk, exists := g.chunks[chunkCoord]
+	if !exists {
+		if *unit == g.defaultUnit {
+			return nil
+		}
+		chunk = g.newChunk()
+		g.chunks[chunkCoord] = chunk
+	}
+	chunk.units[floorModulo(c.X, infiniteGameChunkSize)][floorModulo(c.Y, infiniteGameChunkSize)] = *unit
+	if g.isChunkEmpty(chunk) {
+		delete(g.chunks, chunkCoord)
+	}
+
+	return nil
+}
+
+func (g *infiniteGameInfo[T]) getBoundary() *Area {
+	if len(g.chunks) == 0 {
+		return nil
+	}
+	var boundary *Area
+	for chunkCoord := range g.chunks {
+		from := Coordinate{X: chunkCoord.X * infiniteGameChunkSize, Y: chunkCoord.Y * infiniteGameChunkSize}
+		to := Coordinate{X: from.X + infiniteGameChunkSize - 1, Y: from.Y + infiniteGameChunkSize - 1}
+		if boundary == nil {
+			boundary = &Area{From: from, To: to}
+			continue
+		}
+		if from.X < boundary.From.X {
+			boundary.From.X = from.X
+		}
+		if from.Y < boundary.From.Y {
+			boundary.From.Y = from.Y
+		}
+		if to.X > boundary.To.X {
+			boundary.To.X = to.X
+		}
+		if to.Y > boundary.To.Y {
+			boundary.To.Y = to.Y
+		}
+	}
+	return boundary
+}
+
+// Get the smallest area that covers all stored chunks.
+func (g *infiniteGameInfo[T]) GetBoundary() *Area {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.getBoundary()
+}
+
+// Get the size of the boundary.
+func (g *infiniteGameInfo[T]) GetSize() *Size {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	boundary := g.getBoundary()
+	if boundary == nil {
+		return &Size{Width: 0, Height: 0}
+	}
+	return &Size{
+		Width:  boundary.To.X - boundary.From.X + 1,
+		Height: boundary.To.Y - boundary.From.Y + 1,
+	}
+}
+
+// Get the unit at the coordinate, any coordinate is valid.
+func (g *infiniteGameInfo[T]) GetUnit(c *Coordinate) (*T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	unit := *g.getUnitPointer(c.X, c.Y)
+	return &unit, nil
+}
+
+func (g *infiniteGameInfo[T]) getUnitsInArea(area *Area) *[][]T {
+	unitsInArea := make([][]T, 0)
+	for x := area.From.X; x <= area.To.X; x++ {
+		newRow := make([]T, 0)
+		for y := area.From.Y; y <= area.To.Y; y++ {
+			newRow = append(newRow, *g.getUnitPointer(x, y))
+		}
+		unitsInArea = append(unitsInArea, newRow)
+	}
+	return &unitsInArea
+}
+
+func (g *infiniteGameInfo[T]) getUnitsInBoundary() *[][]T {
+	boundary := g.getBoundary()
+	if boundary == nil {
+		return &[][]T{}
+	}
+	return g.getUnitsInArea(boundary)
+}
+
+// Get all units in the boundary, units[0][0] is the unit at "From" of the boundary.
+func (g *infiniteGameInfo[T]) GetUnits() *[][]T {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	return g.getUnitsInBoundary()
+}
+
+// Get all units in the given area, the area can be anywhere.
+func (g *infiniteGameInfo[T]) GetUnitsInArea(area *Area) (*[][]T, error) {
+	g.locker.RLock()
+	defer g.locker.RUnlock()
+
+	if area.From.X > area.To.X || area.From.Y > area.To.Y {
+		return nil, &ErrAreaIsInvalid{area}
+	}
+
+	return g.getUnitsInArea(area), nil
+}
+
+// We will iterate all units in stored chunks and call the callbacks with coordiante and unit.
+func (g *infiniteGameInfo[T]) IterateUnits(callback UnitsIteratorCallback[T]) {
+	for _, chunkCoord := range g.getSortedChunkCoords() {
+		chunk := g.chunks[chunkCoord]
+		for x := 0; x < infiniteGameChunkSize; x++ {
+			for y := 0; y < infiniteGameChunkSize; y++ {
+				callback(
+					&Coordinate{X: chunkCoord.X*infiniteGameChunkSize + x, Y: chunkCoord.Y*infiniteGameChunkSize + y},
+					&chunk.units[x][y],
+				)
+			}
+		}
+	}
+}
+
+// We will iterate all units in the given area and call the callbacks with coordiante and unit.
+func (g *infiniteGameInfo[T]) IterateUnitsInArea(area *Area, callback UnitsIteratorCallback[T]) error {
+	if area.From.X > area.To.X || area.From.Y > area.To.Y {
+		return &ErrAreaIsInvalid{area}
+	}
+
+	for x := area.From.X; x <= area.To.X; x++ {
+		for y := area.From.Y; y <= area.To.Y; y++ {
+			unit := *g.getUnitPointer(x, y)
+			callback(&Coordinate{X: x, Y: y}, &unit)
+		}
+	}
+	return nil
+}
+
+// We will iterate all units within the distance from the center and call the callbacks with coordiante and unit.
+func (g *infiniteGameInfo[T]) IterateUnitsWithinDistance(center *Coordinate, distance int, callback UnitsIteratorCallback[T]) error {
+	if distance < 0 {
+		return &ErrDistanceIsInvalid{distance}
+	}
+
+	resolve := func(coord *Coordinate) (Coordinate, bool) {
+		return *coord, true
+	}
+	iterateCoordinatesWithinDistance(g.topology, center, distance, resolve, func(coord *Coordinate) {
+		unit := *g.getUnitPointer(coord.X, coord.Y)
+		callback(coord, &unit)
+	})
+	return nil
+}
@@ -0,0 +1,440 @@
+package ggol
+
+import (
+	"testing"
+)
+
+func testInfiniteGameGliderPattern(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	g.SetNextUnitGenerator(defauUnitForTestIterator)
+
+	// Make a glider pattern that flies to the top-left, it will cross the origin and keep going.
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 0, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: -1, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: -1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 0, Y: -1}, &unitForTest{hasLiveCell: true})
+
+	step := 60
+	for i := 0; i < step*4; i++ {
+		g.GenerateNextUnits()
+	}
+
+	expectedLiveCoords := []Coordinate{{X: 1, Y: 1}, {X: 0, Y: 0}, {X: -1, Y: 0}, {X: 1, Y: -1}, {X: 0, Y: -1}}
+	liveCellsCount := 0
+	g.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+		if unit.hasLiveCell {
+			liveCellsCount += 1
+		}
+	})
+	if liveCellsCount != len(expectedLiveCoords) {
+		t.Fatalf("Should still have %v live cells, but got %v.", len(expectedLiveCoords), liveCellsCount)
+	}
+	for _, coord := range expectedLiveCoords {
+		unit, _ := g.GetUnit(&Coordinate{X: coord.X - step, Y: coord.Y - step})
+		if !unit.hasLiveCell {
+			t.Fatalf("Should still be a glider pattern, but (%v, %v) is dead.", coord.X-step, coord.Y-step)
+		}
+	}
+
+	boundary := g.GetBoundary()
+	if boundary.From.X > -step-1 || boundary.To.X < -step+1 || boundary.To.X-boundary.From.X > 3*infiniteGameChunkSize {
+		t.Fatalf("Boundary should only cover chunks around the glider, but got %v.", boundary)
+	}
+
+	t.Log("Passed")
+}
+
+func testInfiniteGameBlinkerPatternInParallel(t *testing.T) {
+	serialGame := NewInfiniteGame(initialUnitForTest)
+	serialGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame := NewInfiniteGame(initialUnitForTest)
+	parallelGame.SetNextUnitGenerator(defauUnitForTestIterator)
+	parallelGame.SetConcurrency(4)
+
+	// Make blinker patterns across chunks in both games
+	for _, g := range []Game[unitForTest]{serialGame, parallelGame} {
+		for _, origin := range []Coordinate{{X: -1, Y: 15}, {X: 40, Y: -33}} {
+			g.SetUnit(&Coordinate{X: origin.X, Y: origin.Y}, &unitForTest{hasLiveCell: true})
+			g.SetUnit(&Coordinate{X: origin.X + 1, Y: origin.Y}, &unitForTest{hasLiveCell: true})
+			g.SetUnit(&Coordinate{X: origin.X + 2, Y: origin.Y}, &unitForTest{hasLiveCell: true})
+		}
+	}
+
+	area := Area{From: Coordinate{X: -20, Y: -40}, To: Coordinate{X: 50, Y: 20}}
+	for i := 0; i < 5; i++ {
+		serialGame.GenerateNextUnits()
+		parallelGame.GenerateNextUnits()
+		serialUnits, _ := serialGame.GetUnitsInArea(&area)
+		parallelUnits, _ := parallelGame.GetUnitsInArea(&area)
+		serialUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(serialUnits)
+		parallelUnitLiveMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(parallelUnits)
+		if !areTwoUnitsHavingLiveCellForTestEqual(serialUnitLiveMap, parallelUnitLiveMap) {
+			t.Fatalf("Generation %v of blinkers in parallel is not identical to the serial one.", i+1)
+		}
+	}
+
+	unit, _ := serialGame.GetUnit(&Coordinate{X: 0, Y: 14})
+	if !unit.hasLiveCell {
+		t.Fatalf("Blinker crossing chunks should be vertical after odd generations.")
+	}
+
+	t.Log("Passed")
+}
+
+func TestInfiniteGameGenerateNextUnits(t *testing.T) {
+	testInfiniteGameGliderPattern(t)
+	testInfiniteGameBlinkerPatternInParallel(t)
+}
+
+func testInfiniteGameGenerateNextUnitsUntilStableCaseOne(t *testing.T) {
+	g := NewInfiniteGame(0)
+	g.SetNextUnitGenerator(decayingUnitForTestIterator)
+	unit := 40
+	g.SetUnit(&Coordinate{X: -20, Y: -3}, &unit)
+
+	// The unit spreads across chunks to the right.
+	report, err := g.GenerateNextUnitsUntilStable(100, AreUnitsEqual[int])
+	if err != nil {
+		t.Fatalf("Units should be stable, but got error: %v.", err)
+	}
+	expectedArea := Area{From: Coordinate{X: -19, Y: -3}, To: Coordinate{X: 19, Y: -3}}
+	if report.GenerationsCount != 39 || report.ChangedUnitsCount != 39 || *report.ChangedArea != expectedArea {
+		t.Fatalf("Should change 39 units in 39 generations within %v, but got %v and %v.", expectedArea, report, report.ChangedArea)
+	}
+	if unit, _ := g.GetUnit(&Coordinate{X: 19, Y: -3}); *unit != 1 {
+		t.Fatalf("Unit at (19, -3) should be 1, but got %v.", *unit)
+	}
+
+	if _, err := g.GenerateNextUnitsUntilStable(-1, AreUnitsEqual[int]); err == nil {
+		t.Fatalf("Should get error when max generations count is -1.")
+	} else if _, ok := err.(*ErrMaxGenerationsCountIsInvalid); !ok {
+		t.Fatalf("Should get ErrMaxGenerationsCountIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func testInfiniteGameGenerateNextUnitsUntilStableCaseTwo(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	g.SetNextUnitGenerator(defauUnitForTestIterator)
+
+	// A blinker dies out after losing one of its cells, units that die are counted as changed.
+	g.SetUnit(&Coordinate{X: 15, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 16, Y: 0}, &unitForTest{hasLiveCell: true})
+	report, err := g.GenerateNextUnitsUntilStable(3, AreUnitsEqual[unitForTest])
+	if err != nil || report.GenerationsCount != 1 || report.ChangedUnitsCount != 2 || g.GetBoundary() != nil {
+		t.Fatalf("Both cells should die in 1 generation, but got %v and error: %v.", report, err)
+	}
+
+	for x := 15; x < 18; x++ {
+		g.SetUnit(&Coordinate{X: x, Y: 0}, &unitForTest{hasLiveCell: true})
+	}
+	if _, err := g.GenerateNextUnitsUntilStable(3, AreUnitsEqual[unitForTest]); err == nil {
+		t.Fatalf("Blinker should not be stable.")
+	} else if _, ok := err.(*ErrUnitsAreNotStable); !ok {
+		t.Fatalf("Should get ErrUnitsAreNotStable, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameGenerateNextUnitsUntilStable(t *testing.T) {
+	testInfiniteGameGenerateNextUnitsUntilStableCaseOne(t)
+	testInfiniteGameGenerateNextUnitsUntilStableCaseTwo(t)
+}
+
+func testInfiniteGameSetUnitCaseOne(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	c := Coordinate{X: -1000000, Y: 999999}
+	g.SetUnit(&c, &unitForTest{hasLiveCell: true})
+	unit, err := g.GetUnit(&c)
+
+	if err != nil || !unit.hasLiveCell {
+		t.Fatalf("Should correctly set unit at any coordinate.")
+	}
+
+	g.SetUnit(&c, &unitForTest{hasLiveCell: false})
+	if g.GetBoundary() != nil {
+		t.Fatalf("Should drop the chunk when all units in it are default units.")
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetUnit(t *testing.T) {
+	testInfiniteGameSetUnitCaseOne(t)
+}
+
+func testInfiniteGameGetSizeCaseOne(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	if g.GetSize().Width != 0 || g.GetSize().Height != 0 {
+		t.Fatalf("Size of an empty infinite game should be 0 x 0.")
+	}
+
+	g.SetUnit(&Coordinate{X: -1, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 16, Y: 0}, &unitForTest{hasLiveCell: true})
+	size := g.GetSize()
+	if size.Width == 3*infiniteGameChunkSize && size.Height == infiniteGameChunkSize {
+		t.Log("Passed")
+	} else {
+		t.Fatalf("Size should cover all stored chunks, but got %v x %v.", size.Width, size.Height)
+	}
+}
+
+func TestInfiniteGameGetSize(t *testing.T) {
+	testInfiniteGameGetSizeCaseOne(t)
+}
+
+func testInfiniteGameGetUnitsInAreaCaseOne(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	g.SetUnit(&Coordinate{X: -5, Y: -5}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 100, Y: -4}, &unitForTest{hasLiveCell: true})
+
+	area := Area{
+		From: Coordinate{X: -5, Y: -5},
+		To:   Coordinate{X: 100, Y: -4},
+	}
+	unitsInArea, _ := g.GetUnitsInArea(&area)
+	aliveUnitsMap := *convertUnitForTestMatrixToUnitsHavingLiveCellForTest(unitsInArea)
+
+	if len(aliveUnitsMap) != 106 || len(aliveUnitsMap[0]) != 2 {
+		t.Fatalf("Should get units of the whole area, but got %v x %v.", len(aliveUnitsMap), len(aliveUnitsMap[0]))
+	}
+	if !aliveUnitsMap[0][0] || !aliveUnitsMap[105][1] || aliveUnitsMap[50][0] {
+		t.Fatalf("Did not get units in the given area correctly.")
+	}
+
+	_, err := g.GetUnitsInArea(&Area{From: Coordinate{X: 1, Y: 0}, To: Coordinate{X: 0, Y: 0}})
+	if err == nil {
+		t.Fatalf("Should get error when the area is invalid.")
+	}
+
+	t.Log("Passed")
+}
+
+func TestInfiniteGameGetUnitsInArea(t *testing.T) {
+	testInfiniteGameGetUnitsInAreaCaseOne(t)
+}
+
+func testInfiniteGameIterateUnitsInAreaCaseOne(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	g.SetUnit(&Coordinate{X: -1, Y: -1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+	sumsOfXCoord := 0
+	liveCellsCount := 0
+	area := Area{
+		From: Coordinate{X: -1, Y: -1},
+		To:   Coordinate{X: 1, Y: 1},
+	}
+
+	g.IterateUnitsInArea(&area, func(c *Coordinate, unit *unitForTest) {
+		sumsOfXCoord += c.X
+		if unit.hasLiveCell {
+			liveCellsCount += 1
+		}
+	})
+
+	if sumsOfXCoord == 0 && liveCellsCount == 2 {
+		t.Log("Passed")
+	} else {
+		t.Fatalf("Did not iterate through units in the given area correctly, sums of X: %v, count of live cells: %v.", sumsOfXCoord, liveCellsCount)
+	}
+}
+
+func TestInfiniteGameIterateUnitsInArea(t *testing.T) {
+	testInfiniteGameIterateUnitsInAreaCaseOne(t)
+}
+
+func testInfiniteGameSetGenerationPreparerCaseOne(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	g.SetUnit(&Coordinate{X: -1, Y: 20}, &unitForTest{hasLiveCell: true})
+
+	var preparedArea Area
+	g.SetGenerationPreparer(func(area *Area, getAdjacentUnit AdjacentUnitGetter[unitForTest]) {
+		preparedArea = *area
+		if unit, _ := getAdjacentUnit(&Coordinate{X: 0, Y: 0}, &Coordinate{X: -1, Y: 20}); !unit.hasLiveCell {
+			t.Fatalf("Should get units of current generation in preparer.")
+		}
+	})
+	g.GenerateNextUnits()
+
+	// The unit is in chunk (-1, 1), chunks around it are generated too.
+	expectedArea := Area{From: Coordinate{X: -32, Y: 0}, To: Coordinate{X: 15, Y: 47}}
+	if preparedArea != expectedArea {
+		t.Fatalf("Area should cover all generated chunks %v, but got %v.", expectedArea, preparedArea)
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetGenerationPreparer(t *testing.T) {
+	testInfiniteGameSetGenerationPreparerCaseOne(t)
+}
+
+func testInfiniteGameSetUpdateOrderCaseOne(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	if err := g.SetUpdateOrder(UpdateOrderSynchronous, 0); err != nil {
+		t.Fatalf("Should support UpdateOrderSynchronous, but got %v.", err)
+	}
+	if _, ok := g.SetUpdateOrder(UpdateOrderCheckerboard, 0).(*ErrUpdateOrderIsNotSupported); !ok {
+		t.Fatalf("Should get ErrUpdateOrderIsNotSupported for UpdateOrderCheckerboard.")
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetUpdateOrder(t *testing.T) {
+	testInfiniteGameSetUpdateOrderCaseOne(t)
+}
+
+func testInfiniteGameSetNextUnitGeneratorWithContextCaseOne(t *testing.T) {
+	// Units around (0, 0) get random numbers, others stay default.
+	nextUnitGenerator := func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		nextUnit := 0
+		if coord.X >= -20 && coord.X <= 20 && coord.Y >= -20 && coord.Y <= 20 {
+			nextUnit = 1 + context.Intn(100)
+		}
+		return &nextUnit
+	}
+	var unitsList []*[][]int
+	for _, concurrency := range []int{1, 4} {
+		g := NewInfiniteGame(0)
+		g.SetNextUnitGeneratorWithContext(nextUnitGenerator)
+		g.SetSeed(3)
+		g.SetConcurrency(concurrency)
+		g.SetUnit(&Coordinate{X: 0, Y: 0}, &[]int{1}[0])
+		for i := 0; i < 3; i++ {
+			g.GenerateNextUnits()
+		}
+		units, _ := g.GetUnitsInArea(&Area{From: Coordinate{X: -20, Y: -20}, To: Coordinate{X: 20, Y: 20}})
+		unitsList = append(unitsList, units)
+	}
+	if !areIntUnitsEqualForTest(unitsList[0], unitsList[1]) {
+		t.Fatalf("Units should be the same no matter how many goroutines generate them.")
+	}
+	t.Log("Passed")
+}
+
+func testInfiniteGameSetNextUnitGeneratorWithContextCaseTwo(t *testing.T) {
+	g := NewInfiniteGame(0)
+	g.SetParams(5)
+	var sizes []Size
+	g.SetNextUnitGeneratorWithContext(func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		if coord.X == 0 && coord.Y == 0 {
+			sizes = append(sizes, *context.GetSize())
+			nextUnit := context.GetGeneration() + context.GetParams().(int)
+			return &nextUnit
+		}
+		return unit
+	})
+	g.SetUnit(&Coordinate{X: 0, Y: 0}, &[]int{1}[0])
+	g.GenerateNextUnits()
+	g.GenerateNextUnits()
+
+	// The chunk of (0, 0) and 8 chunks around it are generated.
+	if len(sizes) != 2 || sizes[0] != (Size{Width: 48, Height: 48}) {
+		t.Fatalf("Size should be 48x48 in both generations, but got %v.", sizes)
+	}
+	if unit, _ := g.GetUnit(&Coordinate{X: 0, Y: 0}); *unit != 6 {
+		t.Fatalf("Unit should be generation 1 plus params 5, but got %v.", *unit)
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetNextUnitGeneratorWithContext(t *testing.T) {
+	testInfiniteGameSetNextUnitGeneratorWithContextCaseOne(t)
+	testInfiniteGameSetNextUnitGeneratorWithContextCaseTwo(t)
+}
+
+func testInfiniteGameSetGlobalStateCaseOne(t *testing.T) {
+	// The state is the count of non-default units, units are born next to a unit only when there are less than 50 of them.
+	reducer := func(state int, contributions []int) int {
+		for _, contribution := range contributions {
+			state = state*31 + contribution
+		}
+		return state % 1000000007
+	}
+	var states []int
+	for _, concurrency := range []int{1, 4} {
+		g := NewInfiniteGame(0)
+		globalState := NewGlobalState(0, reducer)
+		g.SetGlobalState(globalState)
+		g.SetNextUnitGeneratorWithContext(func(context *GenerationContext, coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+			leftUnit, _ := getAdjacentUnit(coord, &Coordinate{X: -1, Y: 0})
+			nextUnit := *unit
+			if *leftUnit != 0 {
+				nextUnit = 1
+			}
+			if nextUnit != 0 {
+				globalState.Contribute(context, coord.X*100+coord.Y)
+			}
+			return &nextUnit
+		})
+		g.SetConcurrency(concurrency)
+		g.SetUnit(&Coordinate{X: 0, Y: 0}, &[]int{1}[0])
+		g.SetUnit(&Coordinate{X: 0, Y: 30}, &[]int{1}[0])
+		for i := 0; i < 20; i++ {
+			g.GenerateNextUnits()
+		}
+		states = append(states, globalState.GetState())
+	}
+	if states[0] != states[1] {
+		t.Fatalf("State should be the same no matter how many goroutines generate units, but got %v.", states)
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetGlobalState(t *testing.T) {
+	testInfiniteGameSetGlobalStateCaseOne(t)
+}
+
+func testInfiniteGameSetNextUnitGeneratorPipelineCaseOne(t *testing.T) {
+	g := NewInfiniteGame(0)
+	err := g.SetNextUnitGeneratorPipeline(addingUnitForTestPhase, leftUnitForTestPhase)
+	if _, ok := err.(*ErrNextUnitGeneratorPipelineIsNotSupported); !ok {
+		t.Fatalf("Should get ErrNextUnitGeneratorPipelineIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetNextUnitGeneratorPipeline(t *testing.T) {
+	testInfiniteGameSetNextUnitGeneratorPipelineCaseOne(t)
+}
+
+func testInfiniteGameAddZoneCaseOne(t *testing.T) {
+	g := NewInfiniteGame(0)
+	err := g.AddZone("a", &Zone[int]{Area: &Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: 1, Y: 1}}, NextUnitGenerator: countingUnitForTestIterator})
+	if _, ok := err.(*ErrZoneIsNotSupported); !ok {
+		t.Fatalf("Should get ErrZoneIsNotSupported, but got %v.", err)
+	}
+	if _, ok := g.RemoveZone("a").(*ErrZoneIsNotFound); !ok {
+		t.Fatalf("Should get ErrZoneIsNotFound.")
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameAddZone(t *testing.T) {
+	testInfiniteGameAddZoneCaseOne(t)
+}
+
+func testInfiniteGameSetActiveRegionTrackingCaseOne(t *testing.T) {
+	g := NewInfiniteGame(0)
+	err := g.SetActiveRegionTracking(AreUnitsEqual[int], 1)
+	if _, ok := err.(*ErrActiveRegionTrackingIsNotSupported); !ok {
+		t.Fatalf("Should get ErrActiveRegionTrackingIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetActiveRegionTracking(t *testing.T) {
+	testInfiniteGameSetActiveRegionTrackingCaseOne(t)
+}
+
+func testInfiniteGameSetBoundaryUnitCaseOne(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	err := g.SetBoundaryUnit(&liveUnitForTest)
+	if _, ok := err.(*ErrBoundaryUnitIsNotSupported); !ok {
+		t.Fatalf("Should get ErrBoundaryUnitIsNotSupported, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestInfiniteGameSetBoundaryUnit(t *testing.T) {
+	testInfiniteGameSetBoundaryUnitCaseOne(t)
+}
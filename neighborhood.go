@@ -0,0 +1,227 @@
+package ggol
+
+// Neighborhood tells the game which units are the neighbors of a unit, so the game can collect them for you.
+// Games only take neighborhoods of their topology, like NewHexagonalNeighborhood for TopologyHexagonal
+// and NewTriangularNeighborhood for TopologyTriangular, other neighborhoods are for TopologySquare.
+type Neighborhood struct {
+	// Neighbors depend on the class of the unit in hexagonal and triangular topologies, see Topology.getClass.
+	topology       Topology
+	relativeCoords [2][]Coordinate
+	// The max distance between a unit and its neighbors in X or Y.
+	radius int
+}
+
+// NeighborhoodNextUnitGenerator works like NextUnitGenerator, but it gets all neighbors at once,
+// "neighbors" are in the same order as the relative coordinates of the Neighborhood.
+// The "neighbors" slice is reused by the game, so don't keep it after the generator returns.
+type NeighborhoodNextUnitGenerator[T any] func(coord *Coordinate, unit *T, neighbors []*T) (nextUnit *T)
+
+// Check whether the neighborhood can be set to the game of the topology.
+func validateNeighborhood(neighborhood *Neighborhood, topology Topology) error {
+	if neighborhood == nil {
+		return &ErrNeighborhoodIsInvalid{}
+	}
+	if neighborhood.topology != topology {
+		return &ErrNeighborhoodTopologyIsMismatched{NeighborhoodTopology: neighborhood.topology, GameTopology: topology}
+	}
+	return nil
+}
+
+func newNeighborhood(topology Topology, relativeCoordsOfClasses [2][]Coordinate) *Neighborhood {
+	radius := 0
+	for _, relativeCoords := range relativeCoordsOfClasses {
+		for _, relativeCoord := range relativeCoords {
+			for _, distance := range []int{relativeCoord.X, -relativeCoord.X, relativeCoord.Y, -relativeCoord.Y} {
+				if distance > radius {
+					radius = distance
+				}
+			}
+		}
+	}
+	return &Neighborhood{
+		topology:       topology,
+		relativeCoords: relativeCoordsOfClasses,
+		radius:         radius,
+	}
+}
+
+// Return the Moore neighborhood, all units within the square of the radius except the unit itself.
+func NewMooreNeighborhood(radius int) (*Neighborhood, error) {
+	if radius < 1 {
+		return nil, &ErrRadiusIsInvalid{radius}
+	}
+	relativeCoords := make([]Coordinate, 0)
+	for i := -radius; i <= radius; i += 1 {
+		for j := -radius; j <= radius; j += 1 {
+			if !(i == 0 && j == 0) {
+				relativeCoords = append(relativeCoords, Coordinate{X: i, Y: j})
+			}
+		}
+	}
+	return newNeighborhood(TopologySquare, [2][]Coordinate{relativeCoords, relativeCoords}), nil
+}
+
+// Return the von Neumann neighborhood, all units within the Manhattan distance of the radius except the unit itself.
+func NewVonNeumannNeighborhood(radius int) (*Neighborhood, error) {
+	if radius < 1 {
+		return nil, &ErrRadiusIsInvalid{radius}
+	}
+	relativeCoords := make([]Coordinate, 0)
+	for i := -radius; i <= radius; i += 1 {
+		for j := -radius; j <= radius; j += 1 {
+			if !(i == 0 && j == 0) && abs(i)+abs(j) <= radius {
+				relativeCoords = append(relativeCoords, Coordinate{X: i, Y: j})
+			}
+		}
+	}
+	return newNeighborhood(TopologySquare, [2][]Coordinate{relativeCoords, relativeCoords}), nil
+}
+
+// Walk through adjacent units of the topology from the origin of every class, so neighbors are units within "radius" steps.
+func newTopologyNeighborhood(topology Topology, radius int, originsOfClasses [2]Coordinate) *Neighborhood {
+	var relativeCoordsOfClasses [2][]Coordinate
+	for class, origin := range originsOfClasses {
+		relativeCoords := make([]Coordinate, 0)
+		resolve := func(coord *Coordinate) (Coordinate, bool) {
+			return *coord, true
+		}
+		iterateCoordinatesWithinDistance(topology, &origin, radius, resolve, func(coord *Coordinate) {
+			if *coord != origin {
+				relativeCoords = append(relativeCoords, Coordinate{X: coord.X - origin.X, Y: coord.Y - origin.Y})
+			}
+		})
+		relativeCoordsOfClasses[class] = relativeCoords
+	}
+	return newNeighborhood(topology, relativeCoordsOfClasses)
+}
+
+// Return the hexagonal neighborhood for TopologyHexagonal, all hexagons within the radius except the unit itself.
+func NewHexagonalNeighborhood(radius int) (*Neighborhood, error) {
+	if radius < 1 {
+		return nil, &ErrRadiusIsInvalid{radius}
+	}
+	// Unit (0, 0) is in an even row and unit (0, 1) is in an odd row.
+	return newTopologyNeighborhood(TopologyHexagonal, radius, [2]Coordinate{{X: 0, Y: 0}, {X: 0, Y: 1}}), nil
+}
+
+// Return the triangular neighborhood for TopologyTriangular, all triangles within the radius except the unit itself,
+// every step goes to one of the 12 triangles sharing an edge or a corner, so NewTriangularNeighborhood(1) has 12 neighbors.
+func NewTriangularNeighborhood(radius int) (*Neighborhood, error) {
+	if radius < 1 {
+		return nil, &ErrRadiusIsInvalid{radius}
+	}
+	// Unit (0, 0) points up and unit (1, 0) points down.
+	return newTopologyNeighborhood(TopologyTriangular, radius, [2]Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}}), nil
+}
+
+// Return a neighborhood with any relative coordinates you want.
+func NewCustomNeighborhood(relativeCoords []Coordinate) (*Neighborhood, error) {
+	if len(relativeCoords) == 0 {
+		return nil, &ErrNeighborhoodIsInvalid{}
+	}
+	copiedRelativeCoords := make([]Coordinate, len(relativeCoords))
+	copy(copiedRelativeCoords, relativeCoords)
+	return newNeighborhood(TopologySquare, [2][]Coordinate{copiedRelativeCoords, copiedRelativeCoords}), nil
+}
+
+// Get the relative coordinates of neighbors of the unit at the given coordinate. Don't modify the returned slice, it's shared.
+func (n *Neighborhood) GetRelativeCoordinates(coord *Coordinate) []Coordinate {
+	return n.relativeCoords[n.topology.getClass(coord)]
+}
+
+// Get the max distance between a unit and its neighbors in X or Y.
+func (n *Neighborhood) GetRadius() int {
+	return n.radius
+}
+
+// Get the max count of neighbors a unit can have.
+func (n *Neighborhood) getMaxNeighborsCount() int {
+	if len(n.relativeCoords[0]) > len(n.relativeCoords[1]) {
+		return len(n.relativeCoords[0])
+	}
+	return len(n.relativeCoords[1])
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Convert a NeighborhoodNextUnitGenerator into a NextUnitGenerator by collecting neighbors with AdjacentUnitGetter,
+// games without a precomputed neighborhood use this.
+func convertNeighborhoodNextUnitGenerator[T any](
+	neighborhood *Neighborhood,
+	nextUnitGenerator NeighborhoodNextUnitGenerator[T],
+) NextUnitGenerator[T] {
+	return func(coord *Coordinate, unit *T, getAdjacentUnit AdjacentUnitGetter[T]) *T {
+		relativeCoords := neighborhood.GetRelativeCoordinates(coord)
+		neighbors := make([]*T, len(relativeCoords))
+		for i := range relativeCoords {
+			neighbors[i], _ = getAdjacentUnit(coord, &relativeCoords[i])
+		}
+		return nextUnitGenerator(coord, unit, neighbors)
+	}
+}
+
+// neighborhoodPlan keeps where the neighbors of units near the border are, so we resolve the boundary only once.
+type neighborhoodPlan struct {
+	// Keyed by "x * height + y" of units near the border, the values are "x * height + y" of its neighbors,
+	// and -1 means the neighbor is the boundary unit.
+	borderNeighborIndexes map[int][]int
+}
+
+func (g *gameInfo[T]) isUnitNearBorder(x int, y int, radius int) bool {
+	return x < radius || x >= g.size.Width-radius || y < radius || y >= g.size.Height-radius
+}
+
+func (g *gameInfo[T]) buildNeighborhoodPlan() *neighborhoodPlan {
+	radius := g.neighborhood.GetRadius()
+	plan := neighborhoodPlan{borderNeighborIndexes: make(map[int][]int)}
+	for x := 0; x < g.size.Width; x++ {
+		for y := 0; y < g.size.Height; y++ {
+			if !g.isUnitNearBorder(x, y, radius) {
+				continue
+			}
+			relativeCoords := g.neighborhood.GetRelativeCoordinates(&Coordinate{X: x, Y: y})
+			neighborIndexes := make([]int, len(relativeCoords))
+			for i, relativeCoord := range relativeCoords {
+				targetX := x + relativeCoord.X
+				targetY := y + relativeCoord.Y
+				if (g.isCoordinateInvalid(&Coordinate{X: targetX, Y: targetY})) {
+					var isResolved bool
+					targetX, targetY, isResolved = resolveCoordinateWithBoundaryPolicy(g.boundaryPolicy, g.size, targetX, targetY)
+					if !isResolved {
+						neighborIndexes[i] = -1
+						continue
+					}
+				}
+				neighborIndexes[i] = targetX*g.size.Height + targetY
+			}
+			plan.borderNeighborIndexes[x*g.size.Height+y] = neighborIndexes
+		}
+	}
+	return &plan
+}
+
+// Collect neighbors of the unit at (x, y) into "neighbors" and return the used part of it.
+func (g *gameInfo[T]) collectNeighbors(x int, y int, neighbors []*T) []*T {
+	if g.isUnitNearBorder(x, y, g.neighborhood.radius) {
+		neighborIndexes := g.neighborhoodPlan.borderNeighborIndexes[x*g.size.Height+y]
+		for i, neighborIndex := range neighborIndexes {
+			if neighborIndex == -1 {
+				neighbors[i] = &g.boundaryUnit
+			} else {
+				neighbors[i] = &(*g.units)[neighborIndex/g.size.Height][neighborIndex%g.size.Height]
+			}
+		}
+		return neighbors[:len(neighborIndexes)]
+	}
+
+	relativeCoords := g.neighborhood.relativeCoords[g.neighborhood.topology.getClass(&Coordinate{X: x, Y: y})]
+	for i := range relativeCoords {
+		neighbors[i] = &(*g.units)[x+relativeCoords[i].X][y+relativeCoords[i].Y]
+	}
+	return neighbors[:len(relativeCoords)]
+}
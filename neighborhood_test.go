@@ -0,0 +1,367 @@
+package ggol
+
+import (
+	"testing"
+)
+
+// Same rules as defauUnitForTestIterator, neighbors are given by the game.
+func neighborhoodUnitForTestIterator(coord *Coordinate, unit *unitForTest, neighbors []*unitForTest) *unitForTest {
+	aliveNeighborsCount := 0
+	for _, neighbor := range neighbors {
+		if neighbor.hasLiveCell {
+			aliveNeighborsCount += 1
+		}
+	}
+	if aliveNeighborsCount == 3 || (unit.hasLiveCell && aliveNeighborsCount == 2) {
+		return &liveUnitForTest
+	}
+	return &deadUnitForTest
+}
+
+func testNewNeighborhoodCaseOne(t *testing.T) {
+	mooreOne, _ := NewMooreNeighborhood(1)
+	mooreTwo, _ := NewMooreNeighborhood(2)
+	vonNeumannOne, _ := NewVonNeumannNeighborhood(1)
+	vonNeumannTwo, _ := NewVonNeumannNeighborhood(2)
+	hexagonalOne, _ := NewHexagonalNeighborhood(1)
+	hexagonalTwo, _ := NewHexagonalNeighborhood(2)
+	triangularOne, _ := NewTriangularNeighborhood(1)
+	custom, _ := NewCustomNeighborhood([]Coordinate{{X: 0, Y: -3}, {X: 1, Y: 0}})
+
+	testCases := []struct {
+		name           string
+		neighborhood   *Neighborhood
+		expectedCount  int
+		expectedRadius int
+	}{
+		{"Moore(1)", mooreOne, 8, 1},
+		{"Moore(2)", mooreTwo, 24, 2},
+		{"von Neumann(1)", vonNeumannOne, 4, 1},
+		{"von Neumann(2)", vonNeumannTwo, 12, 2},
+		{"hexagonal(1)", hexagonalOne, 6, 1},
+		{"hexagonal(2)", hexagonalTwo, 18, 2},
+		{"triangular(1)", triangularOne, 12, 2},
+		{"custom", custom, 2, 3},
+	}
+	for _, testCase := range testCases {
+		for _, coord := range []Coordinate{{X: 0, Y: 0}, {X: 3, Y: 5}} {
+			if count := len(testCase.neighborhood.GetRelativeCoordinates(&coord)); count != testCase.expectedCount {
+				t.Fatalf("%v should have %v neighbors at %v, but got %v.", testCase.name, testCase.expectedCount, coord, count)
+			}
+		}
+		if radius := testCase.neighborhood.GetRadius(); radius != testCase.expectedRadius {
+			t.Fatalf("%v should have radius %v, but got %v.", testCase.name, testCase.expectedRadius, radius)
+		}
+	}
+
+	// Neighbors of hexagonal(1) are the same as adjacent units of TopologyHexagonal.
+	for _, coord := range []Coordinate{{X: 2, Y: 2}, {X: 2, Y: 3}} {
+		expectedRelativeCoords := make(map[Coordinate]bool)
+		for _, relativeCoord := range TopologyHexagonal.GetAdjacentRelativeCoordinates(&coord) {
+			expectedRelativeCoords[relativeCoord] = true
+		}
+		for _, relativeCoord := range hexagonalOne.GetRelativeCoordinates(&coord) {
+			if !expectedRelativeCoords[relativeCoord] {
+				t.Fatalf("%v should not be a neighbor of %v in hexagonal(1).", relativeCoord, coord)
+			}
+		}
+	}
+
+	// Neighbors of triangular(1) are the same as adjacent units of TopologyTriangular, for triangles pointing up and down.
+	for _, coord := range []Coordinate{{X: 2, Y: 2}, {X: 3, Y: 2}} {
+		expectedRelativeCoords := make(map[Coordinate]bool)
+		for _, relativeCoord := range TopologyTriangular.GetAdjacentRelativeCoordinates(&coord) {
+			expectedRelativeCoords[relativeCoord] = true
+		}
+		for _, relativeCoord := range triangularOne.GetRelativeCoordinates(&coord) {
+			if !expectedRelativeCoords[relativeCoord] {
+				t.Fatalf("%v should not be a neighbor of %v in triangular(1).", relativeCoord, coord)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewNeighborhoodCaseTwo(t *testing.T) {
+	if _, err := NewMooreNeighborhood(0); err == nil {
+		t.Fatalf("Should get error when radius is not positive.")
+	}
+	if _, err := NewVonNeumannNeighborhood(-1); err == nil {
+		t.Fatalf("Should get error when radius is not positive.")
+	}
+	if _, err := NewHexagonalNeighborhood(0); err == nil {
+		t.Fatalf("Should get error when radius is not positive.")
+	}
+	if _, err := NewTriangularNeighborhood(0); err == nil {
+		t.Fatalf("Should get error when radius is not positive.")
+	}
+	if _, err := NewCustomNeighborhood([]Coordinate{}); err == nil {
+		t.Fatalf("Should get error when no relative coordinates are given.")
+	}
+	t.Log("Passed")
+}
+
+func TestNewNeighborhood(t *testing.T) {
+	testNewNeighborhoodCaseOne(t)
+	testNewNeighborhoodCaseTwo(t)
+}
+
+func testNeighborhoodGameIsSameAsAdjacentUnitGetterGame(t *testing.T, policy BoundaryPolicy, concurrency int) {
+	width := 13
+	height := 11
+	mooreNeighborhood, _ := NewMooreNeighborhood(1)
+	getterGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	getterGame.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+	neighborhoodGame, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	neighborhoodGame.SetNeighborhoodNextUnitGenerator(mooreNeighborhood, neighborhoodUnitForTestIterator)
+	neighborhoodGame.SetConcurrency(concurrency)
+
+	for _, g := range []Game[unitForTest]{getterGame, neighborhoodGame} {
+		g.SetBoundaryPolicy(policy)
+		g.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+			unit.hasLiveCell = (coord.X*7+coord.Y*3)%5 < 2
+		})
+	}
+
+	for i := 0; i < 10; i++ {
+		getterUnits := *getterGame.GenerateNextUnits()
+		neighborhoodUnits := *neighborhoodGame.GenerateNextUnits()
+		for x := 0; x < width; x++ {
+			for y := 0; y < height; y++ {
+				if getterUnits[x][y] != neighborhoodUnits[x][y] {
+					t.Fatalf("Policy %v, generation %v: units at (%v, %v) are different.", policy, i+1, x, y)
+				}
+			}
+		}
+	}
+}
+
+func testNeighborhoodNextUnitGeneratorCaseOne(t *testing.T) {
+	// nonAllocatingUnitForTestIterator ignores units outside the border, only the constant policy with dead boundary unit matches it.
+	testNeighborhoodGameIsSameAsAdjacentUnitGetterGame(t, BoundaryPolicyConstant, 1)
+	testNeighborhoodGameIsSameAsAdjacentUnitGetterGame(t, BoundaryPolicyConstant, 3)
+	t.Log("Passed")
+}
+
+func testNeighborhoodNextUnitGeneratorCaseTwo(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(3, 3, initialUnitForTest))
+	neighborhood, _ := NewCustomNeighborhood([]Coordinate{{X: -1, Y: 0}, {X: 5, Y: 0}})
+	var collectedNeighbors [][]*unitForTest
+	g.SetNeighborhoodNextUnitGenerator(neighborhood, func(coord *Coordinate, unit *unitForTest, neighbors []*unitForTest) *unitForTest {
+		if *coord == (Coordinate{X: 0, Y: 1}) {
+			collectedNeighbors = append(collectedNeighbors, append([]*unitForTest{}, neighbors...))
+		}
+		return unit
+	})
+	g.SetUnit(&Coordinate{X: 2, Y: 1}, &unitForTest{hasLiveCell: true})
+
+	// Wrap by default, (-1, 1) is (2, 1) and (5, 1) is (2, 1).
+	g.GenerateNextUnits()
+	// Neighbors outside the border are the boundary unit.
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	g.SetBoundaryUnit(&unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+	// Clamp to (0, 1) and (2, 1).
+	g.SetBoundaryPolicy(BoundaryPolicyClamp)
+	g.GenerateNextUnits()
+
+	expectedLiveCells := [][]bool{{true, true}, {true, true}, {false, true}}
+	for i, neighbors := range collectedNeighbors {
+		for j, neighbor := range neighbors {
+			if neighbor.hasLiveCell != expectedLiveCells[i][j] {
+				t.Fatalf("Tick %v: neighbor %v should be alive: %v.", i+1, j, expectedLiveCells[i][j])
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNeighborhoodNextUnitGeneratorCaseThree(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(6, 6, initialUnitForTest))
+	g.SetTopology(TopologyHexagonal)
+	hexagonalNeighborhood, _ := NewHexagonalNeighborhood(1)
+	// A unit becomes alive when any of its neighbors is alive, same as testHexagonalGameCaseOne.
+	g.SetNeighborhoodNextUnitGenerator(hexagonalNeighborhood, func(coord *Coordinate, unit *unitForTest, neighbors []*unitForTest) *unitForTest {
+		for _, neighbor := range neighbors {
+			if neighbor.hasLiveCell {
+				return &liveUnitForTest
+			}
+		}
+		return unit
+	})
+	g.SetUnit(&Coordinate{X: 0, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+
+	liveCoords := make(map[Coordinate]bool)
+	g.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+		if unit.hasLiveCell {
+			liveCoords[*coord] = true
+		}
+	})
+	expectedLiveCoords := []Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 5, Y: 0}, {X: 0, Y: 1}, {X: 5, Y: 1}, {X: 0, Y: 5}, {X: 5, Y: 5}}
+	if len(liveCoords) != len(expectedLiveCoords) {
+		t.Fatalf("Should have %v live cells, but got %v.", len(expectedLiveCoords), liveCoords)
+	}
+	for _, coord := range expectedLiveCoords {
+		if !liveCoords[coord] {
+			t.Fatalf("Unit at %v should be alive, but got %v.", coord, liveCoords)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNeighborhoodNextUnitGeneratorCaseFour(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(64, 64, initialUnitForTest))
+	mooreNeighborhood, _ := NewMooreNeighborhood(1)
+	g.SetNeighborhoodNextUnitGenerator(mooreNeighborhood, neighborhoodUnitForTestIterator)
+	g.SetUnit(&Coordinate{X: 1, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 1}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 2}, &unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func testNeighborhoodNextUnitGeneratorCaseFive(t *testing.T) {
+	g := NewInfiniteGame(initialUnitForTest)
+	mooreNeighborhood, _ := NewMooreNeighborhood(1)
+	g.SetNeighborhoodNextUnitGenerator(mooreNeighborhood, neighborhoodUnitForTestIterator)
+	g.SetUnit(&Coordinate{X: -1, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 0, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.SetUnit(&Coordinate{X: 1, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+
+	for y := -1; y <= 1; y++ {
+		if unit, _ := g.GetUnit(&Coordinate{X: 0, Y: y}); !unit.hasLiveCell {
+			t.Fatalf("Blinker should be vertical after one generation.")
+		}
+	}
+	if unit, _ := g.GetUnit(&Coordinate{X: 1, Y: 0}); unit.hasLiveCell {
+		t.Fatalf("Blinker should be vertical after one generation.")
+	}
+	t.Log("Passed")
+}
+
+func testNeighborhoodNextUnitGeneratorCaseSix(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(8, 6, initialUnitForTest))
+	g.SetTopology(TopologyTriangular)
+	triangularNeighborhood, _ := NewTriangularNeighborhood(1)
+	// A unit becomes alive when any of its neighbors is alive.
+	g.SetNeighborhoodNextUnitGenerator(triangularNeighborhood, func(coord *Coordinate, unit *unitForTest, neighbors []*unitForTest) *unitForTest {
+		for _, neighbor := range neighbors {
+			if neighbor.hasLiveCell {
+				return &liveUnitForTest
+			}
+		}
+		return unit
+	})
+	// A triangle pointing down on the border, its neighbors wrap around.
+	g.SetUnit(&Coordinate{X: 7, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+
+	// Live units are the same as units within distance 1 on the triangular map.
+	expectedLiveCoords := make(map[Coordinate]bool)
+	g.IterateUnitsWithinDistance(&Coordinate{X: 7, Y: 0}, 1, func(coord *Coordinate, unit *unitForTest) {
+		expectedLiveCoords[*coord] = true
+	})
+	if len(expectedLiveCoords) != 13 {
+		t.Fatalf("Should have 13 units within distance 1, but got %v.", len(expectedLiveCoords))
+	}
+	g.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+		if unit.hasLiveCell != expectedLiveCoords[*coord] {
+			t.Fatalf("Unit at %v should be alive: %v.", coord, expectedLiveCoords[*coord])
+		}
+	})
+	t.Log("Passed")
+}
+
+func testNeighborhoodNextUnitGeneratorCaseSeven(t *testing.T) {
+	game, _ := NewGame(generateInitialUnitMatrixForTest(3, 3, initialUnitForTest))
+	hashLifeGame, _ := NewHashLifeGame(initialUnitForTest, 1)
+	for _, g := range []Game[unitForTest]{game, NewInfiniteGame(initialUnitForTest), hashLifeGame} {
+		g.SetNextUnitGenerator(func(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+			return unit
+		})
+		g.SetUnit(&Coordinate{X: 1, Y: 1}, &liveUnitForTest)
+		err := g.SetNeighborhoodNextUnitGenerator(nil, neighborhoodUnitForTestIterator)
+		if _, ok := err.(*ErrNeighborhoodIsInvalid); !ok {
+			t.Fatalf("Should get ErrNeighborhoodIsInvalid when the neighborhood is nil, but got %v.", err)
+		}
+		// The NextUnitGenerator set before is kept, so the lonely live unit doesn't die.
+		g.GenerateNextUnits()
+		if unit, _ := g.GetUnit(&Coordinate{X: 1, Y: 1}); !unit.hasLiveCell {
+			t.Fatalf("Should keep the NextUnitGenerator when the neighborhood is nil.")
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNeighborhoodNextUnitGeneratorCaseEight(t *testing.T) {
+	mooreNeighborhood, _ := NewMooreNeighborhood(1)
+	hexagonalNeighborhood, _ := NewHexagonalNeighborhood(1)
+	game, _ := NewGame(generateInitialUnitMatrixForTest(4, 4, initialUnitForTest))
+	hashLifeGame, _ := NewHashLifeGame(initialUnitForTest, 1)
+	for _, g := range []Game[unitForTest]{game, NewInfiniteGame(initialUnitForTest), hashLifeGame} {
+		// The neighborhood is set after the topology.
+		err := g.SetNeighborhoodNextUnitGenerator(hexagonalNeighborhood, neighborhoodUnitForTestIterator)
+		if _, ok := err.(*ErrNeighborhoodTopologyIsMismatched); !ok {
+			t.Fatalf("Should get ErrNeighborhoodTopologyIsMismatched when the game is square, but got %v.", err)
+		}
+		if err := g.SetNeighborhoodNextUnitGenerator(mooreNeighborhood, neighborhoodUnitForTestIterator); err != nil {
+			t.Fatalf("Should set the neighborhood of the topology, but got %v.", err)
+		}
+
+		// The topology is set after the neighborhood.
+		if _, ok := g.SetTopology(TopologyHexagonal).(*ErrNeighborhoodTopologyIsMismatched); !ok {
+			t.Fatalf("Should get ErrNeighborhoodTopologyIsMismatched when the neighborhood is square.")
+		}
+		if g.GetTopology() != TopologySquare {
+			t.Fatalf("Topology should not be changed when the neighborhood is mismatched.")
+		}
+
+		// Another generator drops the neighborhood, so the topology can be changed.
+		g.SetNextUnitGenerator(nonAllocatingUnitForTestIterator)
+		if err := g.SetTopology(TopologyHexagonal); err != nil {
+			t.Fatalf("Should set the topology without the neighborhood, but got %v.", err)
+		}
+		if err := g.SetNeighborhoodNextUnitGenerator(hexagonalNeighborhood, neighborhoodUnitForTestIterator); err != nil {
+			t.Fatalf("Should set the neighborhood of the topology, but got %v.", err)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNeighborhoodNextUnitGenerator(t *testing.T) {
+	testNeighborhoodNextUnitGeneratorCaseOne(t)
+	testNeighborhoodNextUnitGeneratorCaseTwo(t)
+	testNeighborhoodNextUnitGeneratorCaseThree(t)
+	testNeighborhoodNextUnitGeneratorCaseFour(t)
+	testNeighborhoodNextUnitGeneratorCaseFive(t)
+	testNeighborhoodNextUnitGeneratorCaseSix(t)
+	testNeighborhoodNextUnitGeneratorCaseSeven(t)
+	testNeighborhoodNextUnitGeneratorCaseEight(t)
+}
+
+func benchmarkNeighborhoodGenerateNextUnits(b *testing.B, width int, height int) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(width, height, initialUnitForTest))
+	mooreNeighborhood, _ := NewMooreNeighborhood(1)
+	g.SetNeighborhoodNextUnitGenerator(mooreNeighborhood, neighborhoodUnitForTestIterator)
+	g.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+		unit.hasLiveCell = (coord.X*7+coord.Y*3)%5 < 2
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.GenerateNextUnits()
+	}
+}
+
+func BenchmarkNeighborhoodGenerateNextUnits1000x1000(b *testing.B) {
+	benchmarkNeighborhoodGenerateNextUnits(b, 1000, 1000)
+}
@@ -0,0 +1,72 @@
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Rulestrings of some well-known elementary cellular automata.
+const (
+	RuleElementary30  string = "W30"
+	RuleElementary90  string = "W90"
+	RuleElementary110 string = "W110"
+	RuleElementary184 string = "W184"
+)
+
+// Units of color 0 and 1, generators of elementary rules return them so they don't allocate.
+var elementaryUnits [2]ColorUnit = [2]ColorUnit{{Color: 0}, {Color: 1}}
+
+// ElementaryRule is a one-dimensional rule with 2 colors, a unit looks at itself and the units on its left and right.
+type ElementaryRule struct {
+	number int
+}
+
+// Parse the Wolfram rule number into an ElementaryRule, e.g. "W30" or "30".
+// Bit i of the number is the next color of a unit whose left, itself and right units form i in binary.
+func NewElementaryRule(rulestring string) (*ElementaryRule, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.ReplaceAll(rulestring, " ", "")), "W"))
+	if err != nil {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+	if number < 0 || number > 255 {
+		return nil, &ErrRuleNumberIsInvalid{Rule: rulestring, Number: number, MaxNumber: 255}
+	}
+	return &ElementaryRule{number: number}, nil
+}
+
+// Get the canonical rulestring of the rule, you can parse it with NewElementaryRule again.
+func (r *ElementaryRule) String() string {
+	return fmt.Sprintf("W%v", r.number)
+}
+
+// Get the Wolfram rule number.
+func (r *ElementaryRule) GetNumber() int {
+	return r.number
+}
+
+// Get the next color of the unit with colors of its left unit, itself and its right unit, any color except 0 counts as 1.
+func (r *ElementaryRule) GetNextColor(leftColor int, color int, rightColor int) int {
+	neighborhoodIndex := 0
+	if leftColor != 0 {
+		neighborhoodIndex |= 0b100
+	}
+	if color != 0 {
+		neighborhoodIndex |= 0b010
+	}
+	if rightColor != 0 {
+		neighborhoodIndex |= 0b001
+	}
+	return (r.number >> neighborhoodIndex) & 1
+}
+
+// Generate a NextUnit1DGenerator of the rule for ColorUnit.
+func NewElementaryNextUnitGenerator(rule *ElementaryRule) ggol.NextUnit1DGenerator[ColorUnit] {
+	return func(x int, unit *ColorUnit, getAdjacentUnit ggol.AdjacentUnit1DGetter[ColorUnit]) *ColorUnit {
+		leftUnit, _ := getAdjacentUnit(x, -1)
+		rightUnit, _ := getAdjacentUnit(x, 1)
+		return &elementaryUnits[rule.GetNextColor(leftUnit.Color, unit.Color, rightUnit.Color)]
+	}
+}
@@ -0,0 +1,166 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateColorUnitsForTest(width int) *[]ColorUnit {
+	units := make([]ColorUnit, width)
+	return &units
+}
+
+func testNewElementaryRuleCaseOne(t *testing.T) {
+	testCases := map[string]string{
+		RuleElementary30:  "W30",
+		RuleElementary110: "W110",
+		"30":              "W30",
+		"w90":             "W90",
+		"W 0":             "W0",
+		"255":             "W255",
+	}
+	for rulestring, expectedRulestring := range testCases {
+		rule, err := NewElementaryRule(rulestring)
+		if err != nil {
+			t.Fatalf("Should parse \"%v\", but got error: %v.", rulestring, err)
+		}
+		if rule.String() != expectedRulestring {
+			t.Fatalf("\"%v\" should be parsed as \"%v\", but got \"%v\".", rulestring, expectedRulestring, rule.String())
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewElementaryRuleCaseTwo(t *testing.T) {
+	for _, rulestring := range []string{"", "W", "Wx", "B3/S23", "W3.5"} {
+		_, err := NewElementaryRule(rulestring)
+		if _, ok := err.(*ErrRuleIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	for _, rulestring := range []string{"W256", "W-1"} {
+		_, err := NewElementaryRule(rulestring)
+		if _, ok := err.(*ErrRuleNumberIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleNumberIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNewElementaryRule(t *testing.T) {
+	testNewElementaryRuleCaseOne(t)
+	testNewElementaryRuleCaseTwo(t)
+}
+
+func testElementaryRuleGetNextColorCaseOne(t *testing.T) {
+	// Rule 30 is "left XOR (itself OR right)".
+	rule, _ := NewElementaryRule(RuleElementary30)
+	for neighborhoodIndex := 0; neighborhoodIndex < 8; neighborhoodIndex++ {
+		left := neighborhoodIndex >> 2 & 1
+		center := neighborhoodIndex >> 1 & 1
+		right := neighborhoodIndex & 1
+		if rule.GetNextColor(left, center, right) != left^(center|right) {
+			t.Fatalf("Next color of %03b should be %v.", neighborhoodIndex, left^(center|right))
+		}
+	}
+	if rule.GetNextColor(0, 0, 3) != 1 {
+		t.Fatalf("Any color except 0 should count as 1.")
+	}
+	t.Log("Passed")
+}
+
+func TestElementaryRuleGetNextColor(t *testing.T) {
+	testElementaryRuleGetNextColorCaseOne(t)
+}
+
+func testNewElementaryNextUnitGeneratorCaseOne(t *testing.T) {
+	rule, _ := NewElementaryRule(RuleElementary30)
+	g := ggol.NewGame1D(generateColorUnitsForTest(15))
+	g.SetNextUnitGenerator(NewElementaryNextUnitGenerator(rule))
+	g.SetHistoryLength(5)
+	g.SetUnit(7, &ColorUnit{Color: 1})
+	for i := 0; i < 4; i++ {
+		g.GenerateNextUnits()
+	}
+
+	expectedRows := []string{
+		".......#.......",
+		"......###......",
+		".....##..#.....",
+		"....##.####....",
+		"...##..#...#...",
+	}
+	g.IterateHistory(func(coord *ggol.Coordinate, unit *ColorUnit) {
+		if (unit.Color == 1) != (expectedRows[coord.Y][coord.X] == '#') {
+			t.Fatalf("Unit at %v of Rule 30 history should be %c.", coord, expectedRows[coord.Y][coord.X])
+		}
+	})
+	t.Log("Passed")
+}
+
+func testNewElementaryNextUnitGeneratorCaseTwo(t *testing.T) {
+	// Rule 90 draws Sierpinski triangle, generation n from a single unit has 2 ^ (count of 1 bits of n) live units.
+	rule, _ := NewElementaryRule(RuleElementary90)
+	g := ggol.NewGame1D(generateColorUnitsForTest(129))
+	g.SetNextUnitGenerator(NewElementaryNextUnitGenerator(rule))
+	g.SetUnit(64, &ColorUnit{Color: 1})
+	for n := 1; n < 64; n++ {
+		g.GenerateNextUnits()
+		liveUnitsCount := 0
+		g.IterateUnits(func(x int, unit *ColorUnit) {
+			liveUnitsCount += unit.Color
+		})
+		expectedLiveUnitsCount := 1
+		for m := n; m > 0; m >>= 1 {
+			if m&1 == 1 {
+				expectedLiveUnitsCount *= 2
+			}
+		}
+		if liveUnitsCount != expectedLiveUnitsCount {
+			t.Fatalf("Generation %v should have %v live units, but got %v.", n, expectedLiveUnitsCount, liveUnitsCount)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewElementaryNextUnitGeneratorCaseThree(t *testing.T) {
+	// Rule 184 moves cars rightward, cars are never created or destroyed on a ring road.
+	rule, _ := NewElementaryRule(RuleElementary184)
+	g := ggol.NewGame1D(generateColorUnitsForTest(40))
+	g.SetNextUnitGenerator(NewElementaryNextUnitGenerator(rule))
+	g.IterateUnits(func(x int, unit *ColorUnit) {
+		if x%3 == 0 || x%5 == 0 {
+			unit.Color = 1
+		}
+	})
+	carsCount := 0
+	g.IterateUnits(func(x int, unit *ColorUnit) {
+		carsCount += unit.Color
+	})
+
+	for i := 0; i < 50; i++ {
+		g.GenerateNextUnits()
+		nextCarsCount := 0
+		g.IterateUnits(func(x int, unit *ColorUnit) {
+			nextCarsCount += unit.Color
+		})
+		if nextCarsCount != carsCount {
+			t.Fatalf("Generation %v should have %v cars, but got %v.", i+1, carsCount, nextCarsCount)
+		}
+	}
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestNewElementaryNextUnitGenerator(t *testing.T) {
+	testNewElementaryNextUnitGeneratorCaseOne(t)
+	testNewElementaryNextUnitGeneratorCaseTwo(t)
+	testNewElementaryNextUnitGeneratorCaseThree(t)
+}
@@ -0,0 +1,177 @@
+package rule
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Rulestrings of some well-known Generations games.
+const (
+	RuleBriansBrain string = "B2/S/C3"
+	RuleStarWars    string = "B2/S345/C4"
+)
+
+// GenerationsUnit is the unit of Generations games, state 0 is dead, state 1 is alive,
+// and states from 2 to "statesCount - 1" are dying, they can't be born or survive, they just get older and finally die.
+type GenerationsUnit struct {
+	State int
+}
+
+// GenerationsRule is a life-like rule where units pass through several dying states before they become dead.
+type GenerationsRule struct {
+	lifeLikeRule *LifeLikeRule
+	statesCount  int
+	// Units of every state, generators return them so they don't allocate.
+	units []GenerationsUnit
+}
+
+// Parse the rulestring into a GenerationsRule, these forms are supported:
+//   - "B2/S/C3", "C" tells the count of states including dead and alive, the order doesn't matter.
+//   - "/2/3", survival counts, birth counts and the count of states.
+//
+// Birth and survival counts can be given in any form that NewLifeLikeRule supports, e.g. "B2/S34H/C5".
+func NewGenerationsRule(rulestring string) (*GenerationsRule, error) {
+	sections := strings.Split(strings.ToUpper(strings.ReplaceAll(rulestring, " ", "")), "/")
+
+	statesSectionIndex := -1
+	for i, section := range sections {
+		if strings.HasPrefix(section, "C") {
+			if statesSectionIndex != -1 {
+				return nil, &ErrRuleIsInvalid{rulestring}
+			}
+			statesSectionIndex = i
+		}
+	}
+	statesSection := ""
+	if statesSectionIndex != -1 {
+		statesSection = sections[statesSectionIndex][1:]
+	} else if len(sections) == 3 && !strings.ContainsAny(sections[0]+sections[1], "BSR") {
+		statesSectionIndex = 2
+		statesSection = sections[2]
+	} else {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+
+	// The neighborhood letter can be appended to the count of states, e.g. "B2/S/C3V".
+	lifeLikeSections := append(append([]string{}, sections[:statesSectionIndex]...), sections[statesSectionIndex+1:]...)
+	if trimmedStatesSection := strings.TrimRight(statesSection, "MVH"); len(trimmedStatesSection) == len(statesSection)-1 {
+		lifeLikeSections = append(lifeLikeSections, statesSection[len(trimmedStatesSection):])
+		statesSection = trimmedStatesSection
+	}
+
+	statesCount, err := strconv.Atoi(statesSection)
+	if err != nil {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+	if statesCount < 2 {
+		return nil, &ErrStatesCountIsInvalid{Rule: rulestring, StatesCount: statesCount}
+	}
+
+	lifeLikeRule, err := NewLifeLikeRule(strings.Join(lifeLikeSections, "/"))
+	if err != nil {
+		if _, ok := err.(*ErrRuleIsInvalid); ok {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		return nil, err
+	}
+
+	units := make([]GenerationsUnit, statesCount)
+	for state := range units {
+		units[state].State = state
+	}
+	return &GenerationsRule{
+		lifeLikeRule: lifeLikeRule,
+		statesCount:  statesCount,
+		units:        units,
+	}, nil
+}
+
+// Get the canonical rulestring of the rule, you can parse it with NewGenerationsRule again.
+func (r *GenerationsRule) String() string {
+	return fmt.Sprintf("%v/C%v%v", r.lifeLikeRule.formatBirthAndSurvival(), r.statesCount, r.lifeLikeRule.formatRadiusAndNeighborhood())
+}
+
+// Get the neighborhood of the rule, you can pass it into SetNeighborhoodNextUnitGenerator.
+func (r *GenerationsRule) GetNeighborhood() *ggol.Neighborhood {
+	return r.lifeLikeRule.GetNeighborhood()
+}
+
+// Get the count of states, including dead and alive.
+func (r *GenerationsRule) GetStatesCount() int {
+	return r.statesCount
+}
+
+// Get the next state of the unit, only neighbors in state 1 are counted as live neighbors.
+func (r *GenerationsRule) GetNextState(state int, liveNeighborsCount int) int {
+	switch {
+	case state == 0:
+		if r.lifeLikeRule.IsNextUnitAlive(false, liveNeighborsCount) {
+			return 1
+		}
+		return 0
+	case state == 1 && r.lifeLikeRule.IsNextUnitAlive(true, liveNeighborsCount):
+		return 1
+	case state+1 >= r.statesCount:
+		return 0
+	default:
+		return state + 1
+	}
+}
+
+// Generate a NextUnitGenerator of the rule for GenerationsUnit.
+func NewGenerationsNextUnitGenerator(rule *GenerationsRule) ggol.NextUnitGenerator[GenerationsUnit] {
+	neighborhood := rule.GetNeighborhood()
+	return func(coord *ggol.Coordinate, unit *GenerationsUnit, getAdjacentUnit ggol.AdjacentUnitGetter[GenerationsUnit]) *GenerationsUnit {
+		relativeCoords := neighborhood.GetRelativeCoordinates(coord)
+		liveNeighborsCount := 0
+		for i := range relativeCoords {
+			adjUnit, _ := getAdjacentUnit(coord, &relativeCoords[i])
+			if adjUnit.State == 1 {
+				liveNeighborsCount += 1
+			}
+		}
+		return &rule.units[rule.GetNextState(unit.State, liveNeighborsCount)]
+	}
+}
+
+// Generate a NeighborhoodNextUnitGenerator of the rule for GenerationsUnit.
+func NewGenerationsNeighborhoodNextUnitGenerator(rule *GenerationsRule) ggol.NeighborhoodNextUnitGenerator[GenerationsUnit] {
+	return func(coord *ggol.Coordinate, unit *GenerationsUnit, neighbors []*GenerationsUnit) *GenerationsUnit {
+		liveNeighborsCount := 0
+		for _, neighbor := range neighbors {
+			if neighbor.State == 1 {
+				liveNeighborsCount += 1
+			}
+		}
+		return &rule.units[rule.GetNextState(unit.State, liveNeighborsCount)]
+	}
+}
+
+// Generate a palette indexed by state, dead units get "deadColor", live units get "liveColor",
+// and dying units fade from "liveColor" to "deadColor". You can draw a unit with palette[unit.State].
+func (r *GenerationsRule) GeneratePalette(liveColor color.Color, deadColor color.Color) []color.Color {
+	palette := make([]color.Color, r.statesCount)
+	palette[0] = deadColor
+	palette[1] = liveColor
+
+	liveR, liveG, liveB, liveA := liveColor.RGBA()
+	deadR, deadG, deadB, deadA := deadColor.RGBA()
+	mix := func(from uint32, to uint32, ratio float64) uint16 {
+		return uint16(float64(from) + (float64(to)-float64(from))*ratio)
+	}
+	for state := 2; state < r.statesCount; state++ {
+		// The last dying state is still a bit different from the dead color.
+		ratio := float64(state-1) / float64(r.statesCount-1)
+		palette[state] = color.RGBA64{
+			R: mix(liveR, deadR, ratio),
+			G: mix(liveG, deadG, ratio),
+			B: mix(liveB, deadB, ratio),
+			A: mix(liveA, deadA, ratio),
+		}
+	}
+	return palette
+}
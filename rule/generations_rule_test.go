@@ -0,0 +1,174 @@
+package rule
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateGenerationsUnitsForTest(width int, height int) *[][]GenerationsUnit {
+	units := make([][]GenerationsUnit, width)
+	for x := 0; x < width; x += 1 {
+		units[x] = make([]GenerationsUnit, height)
+	}
+	return &units
+}
+
+func testNewGenerationsRuleCaseOne(t *testing.T) {
+	testCases := map[string]string{
+		"B2/S/C3":     "B2/S/C3",
+		"b2/s/c3":     "B2/S/C3",
+		"C3/S/B2":     "B2/S/C3",
+		"/2/3":        "B2/S/C3",
+		"345/2/4":     "B2/S345/C4",
+		"B2/S34/C5V":  "B2/S34/C5V",
+		"B2/S34/C5/V": "B2/S34/C5V",
+		"B2/S/C3/R2":  "B2/S/C3/R2",
+		"B3/S23/C2":   "B3/S23/C2",
+	}
+	for rulestring, expectedRulestring := range testCases {
+		rule, err := NewGenerationsRule(rulestring)
+		if err != nil {
+			t.Fatalf("Should parse \"%v\", but got error: %v.", rulestring, err)
+		}
+		if rule.String() != expectedRulestring {
+			t.Fatalf("\"%v\" should be parsed as \"%v\", but got \"%v\".", rulestring, expectedRulestring, rule.String())
+		}
+		if reparsedRule, _ := NewGenerationsRule(rule.String()); reparsedRule.String() != rule.String() {
+			t.Fatalf("\"%v\" should be parsed back to itself.", rule.String())
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewGenerationsRuleCaseTwo(t *testing.T) {
+	for _, rulestring := range []string{"", "B2/S", "B2/S/C", "B2/S/Cx", "B2/S/C3/C4", "1/2/3/4", "B2/S3/4", "B2x/S/C3"} {
+		_, err := NewGenerationsRule(rulestring)
+		if _, ok := err.(*ErrRuleIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	if _, err := NewGenerationsRule("B2/S/C1"); err == nil {
+		t.Fatalf("Should get error when count of states is less than 2.")
+	} else if _, ok := err.(*ErrStatesCountIsInvalid); !ok {
+		t.Fatalf("Should get ErrStatesCountIsInvalid, but got %v.", err)
+	}
+	if _, err := NewGenerationsRule("B9/S/C3"); err == nil {
+		t.Fatalf("Should get error when birth count is more than neighbors.")
+	} else if _, ok := err.(*ErrNeighborsCountIsInvalid); !ok {
+		t.Fatalf("Should get ErrNeighborsCountIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewGenerationsRule(t *testing.T) {
+	testNewGenerationsRuleCaseOne(t)
+	testNewGenerationsRuleCaseTwo(t)
+}
+
+func testGetNextStateCaseOne(t *testing.T) {
+	rule, _ := NewGenerationsRule(RuleStarWars)
+	testCases := []struct {
+		state              int
+		liveNeighborsCount int
+		expectedState      int
+	}{
+		{0, 2, 1}, {0, 3, 0},
+		{1, 3, 1}, {1, 2, 2},
+		{2, 2, 3}, {2, 4, 3},
+		{3, 2, 0}, {3, 0, 0},
+	}
+	for _, testCase := range testCases {
+		if nextState := rule.GetNextState(testCase.state, testCase.liveNeighborsCount); nextState != testCase.expectedState {
+			t.Fatalf("State %v with %v live neighbors should become %v, but got %v.", testCase.state, testCase.liveNeighborsCount, testCase.expectedState, nextState)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetNextState(t *testing.T) {
+	testGetNextStateCaseOne(t)
+}
+
+func testGenerationsNextUnitGeneratorCaseOne(t *testing.T) {
+	rule, _ := NewGenerationsRule(RuleBriansBrain)
+	getterGame, _ := ggol.NewGame(generateGenerationsUnitsForTest(6, 6))
+	getterGame.SetNextUnitGenerator(NewGenerationsNextUnitGenerator(rule))
+	neighborhoodGame, _ := ggol.NewGame(generateGenerationsUnitsForTest(6, 6))
+	neighborhoodGame.SetNeighborhoodNextUnitGenerator(rule.GetNeighborhood(), NewGenerationsNeighborhoodNextUnitGenerator(rule))
+
+	for _, g := range []ggol.Game[GenerationsUnit]{getterGame, neighborhoodGame} {
+		g.SetUnit(&ggol.Coordinate{X: 2, Y: 2}, &GenerationsUnit{State: 1})
+		g.SetUnit(&ggol.Coordinate{X: 3, Y: 2}, &GenerationsUnit{State: 1})
+		g.GenerateNextUnits()
+
+		expectedStates := map[ggol.Coordinate]int{
+			{X: 2, Y: 2}: 2, {X: 3, Y: 2}: 2,
+			{X: 2, Y: 1}: 1, {X: 3, Y: 1}: 1, {X: 2, Y: 3}: 1, {X: 3, Y: 3}: 1,
+		}
+		g.IterateUnits(func(coord *ggol.Coordinate, unit *GenerationsUnit) {
+			if unit.State != expectedStates[*coord] {
+				t.Fatalf("Unit at %v should be in state %v, but got %v.", coord, expectedStates[*coord], unit.State)
+			}
+		})
+
+		g.GenerateNextUnits()
+		for _, coord := range []ggol.Coordinate{{X: 2, Y: 2}, {X: 3, Y: 2}} {
+			if unit, _ := g.GetUnit(&coord); unit.State != 0 {
+				t.Fatalf("Dying unit at %v should be dead, but got state %v.", coord, unit.State)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGenerationsNextUnitGeneratorCaseTwo(t *testing.T) {
+	rule, _ := NewGenerationsRule(RuleBriansBrain)
+	g, _ := ggol.NewGame(generateGenerationsUnitsForTest(32, 32))
+	g.SetNeighborhoodNextUnitGenerator(rule.GetNeighborhood(), NewGenerationsNeighborhoodNextUnitGenerator(rule))
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *GenerationsUnit) {
+		unit.State = (coord.X*5 + coord.Y*3) % 3
+	})
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestGenerationsNextUnitGenerator(t *testing.T) {
+	testGenerationsNextUnitGeneratorCaseOne(t)
+	testGenerationsNextUnitGeneratorCaseTwo(t)
+}
+
+func testGeneratePaletteCaseOne(t *testing.T) {
+	rule, _ := NewGenerationsRule("B2/S/C5")
+	liveColor := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	deadColor := color.RGBA{0x00, 0x00, 0x00, 0xff}
+	palette := rule.GeneratePalette(liveColor, deadColor)
+	if len(palette) != 5 {
+		t.Fatalf("Palette should have a color for every state, but got %v colors.", len(palette))
+	}
+	if palette[0] != deadColor || palette[1] != liveColor {
+		t.Fatalf("Dead and live units should use the given colors.")
+	}
+
+	lastBrightness := uint32(0xffff)
+	for state := 2; state < 5; state++ {
+		brightness, _, _, _ := palette[state].RGBA()
+		if brightness >= lastBrightness || brightness == 0 {
+			t.Fatalf("Dying states should fade from live color to dead color, but got %v.", palette)
+		}
+		lastBrightness = brightness
+	}
+	t.Log("Passed")
+}
+
+func TestGeneratePalette(t *testing.T) {
+	testGeneratePaletteCaseOne(t)
+}
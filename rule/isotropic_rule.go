@@ -0,0 +1,209 @@
+package rule
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Neighbors of isotropic rules go clockwise from the top: N, NE, E, SE, S, SW, W, NW.
+// Neighbor i is bit i of the neighbors mask.
+var isotropicRelativeCoords []ggol.Coordinate = []ggol.Coordinate{
+	{X: 0, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1},
+	{X: 0, Y: 1}, {X: -1, Y: 1}, {X: -1, Y: 0}, {X: -1, Y: -1},
+}
+
+// Letters of every neighbors count in Hensel notation, 5 to 7 use the same letters as 3 to 1.
+var henselLetters [9]string = [9]string{"", "ce", "cekain", "cekainyqjr", "cekainyqjrtwz", "cekainyqjr", "cekain", "ce", ""}
+
+// One neighbors mask of every letter of 1 to 4 neighbors, others are rotations and reflections of it.
+var henselRepresentativeMasks map[string]int = map[string]int{
+	"1c": 0b00000010, "1e": 0b00000001,
+	"2c": 0b00001010, "2e": 0b00000101, "2k": 0b00001001, "2a": 0b00000011, "2i": 0b00010001, "2n": 0b00100010,
+	"3c": 0b00101010, "3e": 0b00010101, "3k": 0b00100101, "3a": 0b00000111, "3i": 0b10000011,
+	"3n": 0b00001011, "3y": 0b00101001, "3q": 0b00100011, "3j": 0b01000011, "3r": 0b00010011,
+	"4c": 0b10101010, "4e": 0b01010101, "4k": 0b01001011, "4a": 0b00001111, "4i": 0b00011011,
+	"4n": 0b10001011, "4y": 0b00101011, "4q": 0b00100111, "4j": 0b01010011, "4r": 0b00010111,
+	"4t": 0b10010011, "4w": 0b01100011, "4z": 0b00110011,
+}
+
+// Get all neighbors masks of the letter, with "n" neighbors, n > 4 is the complement of 8 - n.
+func getHenselMasks(neighborsCount int, letter byte) []int {
+	representativeMask := 0
+	if neighborsCount > 4 {
+		representativeMask = ^henselRepresentativeMasks[fmt.Sprintf("%v%c", 8-neighborsCount, letter)] & 0xff
+	} else {
+		representativeMask = henselRepresentativeMasks[fmt.Sprintf("%v%c", neighborsCount, letter)]
+	}
+
+	masks := make([]int, 0)
+	hasMask := make(map[int]bool)
+	for _, isReflected := range []bool{false, true} {
+		// Rotating 90 degrees moves every neighbor 2 steps clockwise.
+		for rotation := 0; rotation < 8; rotation += 2 {
+			mask := 0
+			for i := 0; i < 8; i++ {
+				if representativeMask&(1<<i) == 0 {
+					continue
+				}
+				j := i
+				if isReflected {
+					j = (8 - i) % 8
+				}
+				mask |= 1 << ((j + rotation) % 8)
+			}
+			if !hasMask[mask] {
+				hasMask[mask] = true
+				masks = append(masks, mask)
+			}
+		}
+	}
+	return masks
+}
+
+// IsotropicRule decides whether a unit is alive by the arrangement of its 8 live neighbors, not just the count.
+type IsotropicRule struct {
+	// Indexed by "isAlive << 8 | neighborsMask".
+	table        [512]bool
+	neighborhood *ggol.Neighborhood
+}
+
+// Parse the rulestring in Hensel notation into an IsotropicRule, e.g. "B2-a/S12" or "B2ae3aijr/S1e2-in".
+// A digit without letters means all arrangements of the count, letters after the digit pick arrangements of it,
+// and letters after "-" remove arrangements from it.
+func NewIsotropicRule(rulestring string) (*IsotropicRule, error) {
+	sections := strings.Split(strings.ToLower(strings.ReplaceAll(rulestring, " ", "")), "/")
+	if len(sections) != 2 {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+	if strings.HasPrefix(sections[0], "s") {
+		sections[0], sections[1] = sections[1], sections[0]
+	}
+	if !strings.HasPrefix(sections[0], "b") || !strings.HasPrefix(sections[1], "s") {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+
+	neighborhood, _ := ggol.NewCustomNeighborhood(isotropicRelativeCoords)
+	rule := IsotropicRule{neighborhood: neighborhood}
+	for i, section := range sections {
+		masks, err := parseHenselSection(rulestring, section[1:])
+		if err != nil {
+			return nil, err
+		}
+		for _, mask := range masks {
+			rule.table[i<<8|mask] = true
+		}
+	}
+	return &rule, nil
+}
+
+// Parse a section like "2ae3-i" into all neighbors masks it has.
+func parseHenselSection(rulestring string, section string) ([]int, error) {
+	masks := make([]int, 0)
+	for i := 0; i < len(section); {
+		if section[i] < '0' || section[i] > '8' {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		neighborsCount := int(section[i] - '0')
+		i++
+
+		isExcluded := false
+		if i < len(section) && section[i] == '-' {
+			isExcluded = true
+			i++
+		}
+		letters := ""
+		for i < len(section) && section[i] >= 'a' && section[i] <= 'z' {
+			if !strings.ContainsRune(henselLetters[neighborsCount], rune(section[i])) {
+				return nil, &ErrRuleIsInvalid{rulestring}
+			}
+			letters += string(section[i])
+			i++
+		}
+		if isExcluded && letters == "" {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+
+		if letters == "" {
+			for mask := 0; mask < 256; mask++ {
+				if bits.OnesCount(uint(mask)) == neighborsCount {
+					masks = append(masks, mask)
+				}
+			}
+			continue
+		}
+		for j := 0; j < len(henselLetters[neighborsCount]); j++ {
+			letter := henselLetters[neighborsCount][j]
+			if strings.IndexByte(letters, letter) >= 0 != isExcluded {
+				masks = append(masks, getHenselMasks(neighborsCount, letter)...)
+			}
+		}
+	}
+	return masks, nil
+}
+
+// Format one side of the table back into Hensel notation, letters or excluded letters, whichever is shorter.
+func formatHenselSection(table []bool) string {
+	var builder strings.Builder
+	for neighborsCount := 0; neighborsCount <= 8; neighborsCount++ {
+		letters := henselLetters[neighborsCount]
+		if letters == "" {
+			if table[(1<<neighborsCount)-1] {
+				builder.WriteString(fmt.Sprint(neighborsCount))
+			}
+			continue
+		}
+
+		includedLetters := ""
+		excludedLetters := ""
+		for i := 0; i < len(letters); i++ {
+			if table[getHenselMasks(neighborsCount, letters[i])[0]] {
+				includedLetters += string(letters[i])
+			} else {
+				excludedLetters += string(letters[i])
+			}
+		}
+		switch {
+		case includedLetters == "":
+		case excludedLetters == "":
+			builder.WriteString(fmt.Sprint(neighborsCount))
+		case len(includedLetters) <= len(excludedLetters):
+			builder.WriteString(fmt.Sprintf("%v%v", neighborsCount, includedLetters))
+		default:
+			builder.WriteString(fmt.Sprintf("%v-%v", neighborsCount, excludedLetters))
+		}
+	}
+	return builder.String()
+}
+
+// Get the canonical rulestring of the rule, you can parse it with NewIsotropicRule again.
+func (r *IsotropicRule) String() string {
+	return fmt.Sprintf("B%v/S%v", formatHenselSection(r.table[:256]), formatHenselSection(r.table[256:]))
+}
+
+// Get the neighborhood of the rule, the 8 neighbors go clockwise from the top: N, NE, E, SE, S, SW, W, NW.
+func (r *IsotropicRule) GetNeighborhood() *ggol.Neighborhood {
+	return r.neighborhood
+}
+
+func (r *IsotropicRule) getNeighborWeight(index int) int {
+	return 1 << index
+}
+
+func (r *IsotropicRule) isNextUnitAliveWithWeight(isAlive bool, weight int) bool {
+	return r.IsNextUnitAlive(isAlive, weight)
+}
+
+// Tell you whether the unit is alive in the next generation,
+// bit i of "neighborsMask" is set when neighbor i of the neighborhood is alive.
+func (r *IsotropicRule) IsNextUnitAlive(isAlive bool, neighborsMask int) bool {
+	if neighborsMask < 0 || neighborsMask > 0xff {
+		return false
+	}
+	if isAlive {
+		return r.table[1<<8|neighborsMask]
+	}
+	return r.table[neighborsMask]
+}
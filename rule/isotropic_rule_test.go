@@ -0,0 +1,171 @@
+package rule
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func testGetHenselMasksCaseOne(t *testing.T) {
+	for neighborsCount := 0; neighborsCount <= 8; neighborsCount++ {
+		letterOfMasks := make(map[int]byte)
+		for i := 0; i < len(henselLetters[neighborsCount]); i++ {
+			letter := henselLetters[neighborsCount][i]
+			for _, mask := range getHenselMasks(neighborsCount, letter) {
+				if bits.OnesCount(uint(mask)) != neighborsCount {
+					t.Fatalf("Mask %08b of %v%c should have %v neighbors.", mask, neighborsCount, letter, neighborsCount)
+				}
+				if otherLetter, ok := letterOfMasks[mask]; ok {
+					t.Fatalf("Mask %08b belongs to both %v%c and %v%c.", mask, neighborsCount, otherLetter, neighborsCount, letter)
+				}
+				letterOfMasks[mask] = letter
+			}
+		}
+
+		expectedMasksCount := 0
+		for mask := 0; mask < 256; mask++ {
+			if bits.OnesCount(uint(mask)) == neighborsCount {
+				expectedMasksCount += 1
+			}
+		}
+		if henselLetters[neighborsCount] != "" && len(letterOfMasks) != expectedMasksCount {
+			t.Fatalf("Letters of %v neighbors should cover %v masks, but got %v.", neighborsCount, expectedMasksCount, len(letterOfMasks))
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGetHenselMasksCaseTwo(t *testing.T) {
+	// N and S are opposite edges, W and E too.
+	expectedMasks := map[int]bool{0b00010001: true, 0b01000100: true}
+	masks := getHenselMasks(2, 'i')
+	if len(masks) != len(expectedMasks) {
+		t.Fatalf("2i should have %v masks, but got %v.", len(expectedMasks), masks)
+	}
+	for _, mask := range masks {
+		if !expectedMasks[mask] {
+			t.Fatalf("Mask %08b should not be 2i.", mask)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetHenselMasks(t *testing.T) {
+	testGetHenselMasksCaseOne(t)
+	testGetHenselMasksCaseTwo(t)
+}
+
+func testNewIsotropicRuleCaseOne(t *testing.T) {
+	testCases := map[string]string{
+		"B3/S23":            "B3/S23",
+		"b3/s23":            "B3/S23",
+		"S23/B3":            "B3/S23",
+		"B2-a/S12":          "B2-a/S12",
+		"B2cekin/S12":       "B2-a/S12",
+		"B2ae3aijr/S1e2-in": "B2ea3aijr/S1e2-in",
+		"B3/S2-i34q":        "B3/S2-i34q",
+		"B3/S238":           "B3/S238",
+		"B0/S012345678":     "B0/S012345678",
+	}
+	for rulestring, expectedRulestring := range testCases {
+		rule, err := NewIsotropicRule(rulestring)
+		if err != nil {
+			t.Fatalf("Should parse \"%v\", but got error: %v.", rulestring, err)
+		}
+		if rule.String() != expectedRulestring {
+			t.Fatalf("\"%v\" should be parsed as \"%v\", but got \"%v\".", rulestring, expectedRulestring, rule.String())
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewIsotropicRuleCaseTwo(t *testing.T) {
+	for _, rulestring := range []string{"", "B3", "B3/S23/R2", "B2x/S", "B0c/S", "B9/S", "B2-/S", "B3/B23", "B1z/S"} {
+		_, err := NewIsotropicRule(rulestring)
+		if _, ok := err.(*ErrRuleIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNewIsotropicRule(t *testing.T) {
+	testNewIsotropicRuleCaseOne(t)
+	testNewIsotropicRuleCaseTwo(t)
+}
+
+func testIsotropicRuleIsNextUnitAliveCaseOne(t *testing.T) {
+	// Totalistic rules in Hensel notation should work like life-like rules.
+	isotropicRule, _ := NewIsotropicRule(RuleHighLife)
+	lifeLikeRule, _ := NewLifeLikeRule(RuleHighLife)
+	for _, isAlive := range []bool{false, true} {
+		for mask := 0; mask < 256; mask++ {
+			if isotropicRule.IsNextUnitAlive(isAlive, mask) != lifeLikeRule.IsNextUnitAlive(isAlive, bits.OnesCount(uint(mask))) {
+				t.Fatalf("Unit (alive: %v) with neighbors %08b is not generated like the life-like rule.", isAlive, mask)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testIsotropicRuleIsNextUnitAliveCaseTwo(t *testing.T) {
+	rule, _ := NewIsotropicRule("B2-a/S12")
+	// N and NE are adjacent.
+	if rule.IsNextUnitAlive(false, 0b00000011) {
+		t.Fatalf("Unit with 2 adjacent neighbors should not be born in B2-a.")
+	}
+	// N and S are opposite.
+	if !rule.IsNextUnitAlive(false, 0b00010001) {
+		t.Fatalf("Unit with 2 opposite neighbors should be born in B2-a.")
+	}
+	if !rule.IsNextUnitAlive(true, 0b00000011) || rule.IsNextUnitAlive(true, 0b00000111) {
+		t.Fatalf("Unit should survive with 1 or 2 neighbors only.")
+	}
+	t.Log("Passed")
+}
+
+func TestIsotropicRuleIsNextUnitAlive(t *testing.T) {
+	testIsotropicRuleIsNextUnitAliveCaseOne(t)
+	testIsotropicRuleIsNextUnitAliveCaseTwo(t)
+}
+
+func testIsotropicRuleNextUnitGeneratorCaseOne(t *testing.T) {
+	rule, _ := NewIsotropicRule("B2-a/S12")
+	getterGame, _ := ggol.NewGame(generateLifeUnitsForTest(16, 16))
+	getterGame.SetNextUnitGenerator(NewLifeUnitNextUnitGenerator(rule))
+	neighborhoodGame, _ := ggol.NewGame(generateLifeUnitsForTest(16, 16))
+	neighborhoodGame.SetNeighborhoodNextUnitGenerator(rule.GetNeighborhood(), NewLifeUnitNeighborhoodNextUnitGenerator(rule))
+
+	for _, g := range []ggol.Game[LifeUnit]{getterGame, neighborhoodGame} {
+		g.IterateUnits(func(coord *ggol.Coordinate, unit *LifeUnit) {
+			unit.Alive = (coord.X*5+coord.Y*3)%7 < 2
+		})
+	}
+	for i := 0; i < 8; i++ {
+		getterUnits := *getterGame.GenerateNextUnits()
+		neighborhoodUnits := *neighborhoodGame.GenerateNextUnits()
+		for x := range getterUnits {
+			for y := range getterUnits[x] {
+				if getterUnits[x][y] != neighborhoodUnits[x][y] {
+					t.Fatalf("Generation %v: units at (%v, %v) are different.", i+1, x, y)
+				}
+			}
+		}
+	}
+
+	// A unit with opposite live neighbors is born.
+	g, _ := ggol.NewGame(generateLifeUnitsForTest(5, 5))
+	g.SetNextUnitGenerator(NewLifeUnitNextUnitGenerator(rule))
+	g.SetUnit(&ggol.Coordinate{X: 2, Y: 1}, &LifeUnit{Alive: true})
+	g.SetUnit(&ggol.Coordinate{X: 2, Y: 3}, &LifeUnit{Alive: true})
+	g.GenerateNextUnits()
+	if unit, _ := g.GetUnit(&ggol.Coordinate{X: 2, Y: 2}); !unit.Alive {
+		t.Fatalf("Unit between 2 opposite live units should be born.")
+	}
+	t.Log("Passed")
+}
+
+func TestIsotropicRuleNextUnitGenerator(t *testing.T) {
+	testIsotropicRuleNextUnitGeneratorCaseOne(t)
+}
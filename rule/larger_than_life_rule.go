@@ -0,0 +1,320 @@
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Rulestrings of some well-known Larger than Life games.
+const (
+	RuleBosco    string = "R5,C0,M1,S34..58,B34..45,NM"
+	RuleMajority string = "R4,C0,M1,S41..81,B41..81,NM"
+	RuleGnarl    string = "R1,C0,M1,S1..1,B1..1,NN"
+)
+
+// LargerThanLifeRule is a Generations-like rule with a large neighborhood, units count live neighbors in a
+// Moore or von Neumann neighborhood of the radius, and birth and survival are decided by ranges of the count.
+type LargerThanLifeRule struct {
+	radius           int
+	statesCount      int
+	isCenterIncluded bool
+	survivalRange    [2]int
+	birthRange       [2]int
+	neighborhoodType neighborhoodType
+	neighborhood     *ggol.Neighborhood
+	// Units of every state, generators return them so they don't allocate.
+	units []GenerationsUnit
+}
+
+// Parse the rulestring in "R5,C0,M1,S34..58,B34..45,NM" notation into a LargerThanLifeRule.
+//   - "R" is the radius of the neighborhood.
+//   - "C" is the count of states like Generations rules, 0 and 2 mean units are either dead or alive. Default is 0.
+//   - "M" is 1 when the unit itself is counted as its neighbor, or 0 when it's not. Default is 0.
+//   - "S" and "B" are the ranges of live neighbors count to survive and to be born, "S34" means "S34..34".
+//   - "N" is the neighborhood, "NM" for Moore and "NN" for von Neumann. Default is "NM".
+func NewLargerThanLifeRule(rulestring string) (*LargerThanLifeRule, error) {
+	rule := LargerThanLifeRule{statesCount: 2, neighborhoodType: neighborhoodTypeMoore}
+	parsedKeys := make(map[byte]bool)
+	var survivalSection, birthSection string
+	for _, token := range strings.Split(strings.ToUpper(strings.ReplaceAll(rulestring, " ", "")), ",") {
+		if token == "" || parsedKeys[token[0]] {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		key := token[0]
+		value := token[1:]
+		parsedKeys[key] = true
+
+		var err error
+		switch key {
+		case 'R':
+			if rule.radius, err = strconv.Atoi(value); err != nil {
+				return nil, &ErrRuleIsInvalid{rulestring}
+			}
+		case 'C':
+			if rule.statesCount, err = strconv.Atoi(value); err != nil {
+				return nil, &ErrRuleIsInvalid{rulestring}
+			}
+			if rule.statesCount == 0 {
+				rule.statesCount = 2
+			} else if rule.statesCount < 2 {
+				return nil, &ErrStatesCountIsInvalid{Rule: rulestring, StatesCount: rule.statesCount}
+			}
+		case 'M':
+			if value != "0" && value != "1" {
+				return nil, &ErrRuleIsInvalid{rulestring}
+			}
+			rule.isCenterIncluded = value == "1"
+		case 'S':
+			survivalSection = value
+		case 'B':
+			birthSection = value
+		case 'N':
+			switch value {
+			case "M":
+				rule.neighborhoodType = neighborhoodTypeMoore
+			case "N":
+				rule.neighborhoodType = neighborhoodTypeVonNeumann
+			default:
+				return nil, &ErrRuleIsInvalid{rulestring}
+			}
+		default:
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+	}
+	if !parsedKeys['R'] || !parsedKeys['S'] || !parsedKeys['B'] {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+
+	var err error
+	if rule.neighborhoodType == neighborhoodTypeVonNeumann {
+		rule.neighborhood, err = ggol.NewVonNeumannNeighborhood(rule.radius)
+	} else {
+		rule.neighborhood, err = ggol.NewMooreNeighborhood(rule.radius)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	maxCount := len(rule.neighborhood.GetRelativeCoordinates(&ggol.Coordinate{X: 0, Y: 0}))
+	if rule.isCenterIncluded {
+		maxCount += 1
+	}
+	if rule.survivalRange, err = parseRange(rulestring, survivalSection, maxCount); err != nil {
+		return nil, err
+	}
+	if rule.birthRange, err = parseRange(rulestring, birthSection, maxCount); err != nil {
+		return nil, err
+	}
+
+	rule.units = make([]GenerationsUnit, rule.statesCount)
+	for state := range rule.units {
+		rule.units[state].State = state
+	}
+	return &rule, nil
+}
+
+// Parse a range like "34..58" or "34".
+func parseRange(rulestring string, section string, maxCount int) ([2]int, error) {
+	bounds := strings.SplitN(section, "..", 2)
+	from, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return [2]int{}, &ErrRuleIsInvalid{rulestring}
+	}
+	to := from
+	if len(bounds) == 2 {
+		if to, err = strconv.Atoi(bounds[1]); err != nil || to < from {
+			return [2]int{}, &ErrRuleIsInvalid{rulestring}
+		}
+	}
+	if from < 0 || to > maxCount {
+		return [2]int{}, &ErrNeighborsCountIsInvalid{Rule: rulestring, Count: to, MaxCount: maxCount}
+	}
+	return [2]int{from, to}, nil
+}
+
+// Get the canonical rulestring of the rule, you can parse it with NewLargerThanLifeRule again.
+func (r *LargerThanLifeRule) String() string {
+	statesCount := r.statesCount
+	if statesCount == 2 {
+		statesCount = 0
+	}
+	centerFlag := 0
+	if r.isCenterIncluded {
+		centerFlag = 1
+	}
+	neighborhoodLetter := "M"
+	if r.neighborhoodType == neighborhoodTypeVonNeumann {
+		neighborhoodLetter = "N"
+	}
+	return fmt.Sprintf(
+		"R%v,C%v,M%v,S%v..%v,B%v..%v,N%v",
+		r.radius, statesCount, centerFlag, r.survivalRange[0], r.survivalRange[1], r.birthRange[0], r.birthRange[1], neighborhoodLetter,
+	)
+}
+
+// Get the neighborhood of the rule, it never includes the unit itself.
+func (r *LargerThanLifeRule) GetNeighborhood() *ggol.Neighborhood {
+	return r.neighborhood
+}
+
+// Get the count of states, including dead and alive.
+func (r *LargerThanLifeRule) GetStatesCount() int {
+	return r.statesCount
+}
+
+// Get the next state of the unit, only neighbors in state 1 are counted as live neighbors,
+// and the unit itself is counted when "M" is 1.
+func (r *LargerThanLifeRule) GetNextState(state int, liveNeighborsCount int) int {
+	switch {
+	case state == 0:
+		if liveNeighborsCount >= r.birthRange[0] && liveNeighborsCount <= r.birthRange[1] {
+			return 1
+		}
+		return 0
+	case state == 1 && liveNeighborsCount >= r.survivalRange[0] && liveNeighborsCount <= r.survivalRange[1]:
+		return 1
+	case state+1 >= r.statesCount:
+		return 0
+	default:
+		return state + 1
+	}
+}
+
+// Summed-area table of live units, it's built once per generation so counting neighbors doesn't grow with radius squared.
+type largerThanLifeTable struct {
+	// The coordinate of the first unit in the table, the table covers the area and units within the radius around it.
+	from ggol.Coordinate
+	to   ggol.Coordinate
+	// sums[(x + 1) * stride + (y + 1)] is the count of live units from "from" to (from.X + x, from.Y + y).
+	stride     int
+	sums       []int
+	isPrepared bool
+	// Coordinates passed to AdjacentUnitGetter, they are kept here so preparing doesn't allocate.
+	origin ggol.Coordinate
+	coord  ggol.Coordinate
+}
+
+func (t *largerThanLifeTable) prepare(area *ggol.Area, getAdjacentUnit ggol.AdjacentUnitGetter[GenerationsUnit], radius int) {
+	t.from = ggol.Coordinate{X: area.From.X - radius, Y: area.From.Y - radius}
+	t.to = ggol.Coordinate{X: area.To.X + radius, Y: area.To.Y + radius}
+	width := t.to.X - t.from.X + 1
+	height := t.to.Y - t.from.Y + 1
+	t.stride = height + 1
+	if cap(t.sums) < (width+1)*t.stride {
+		t.sums = make([]int, (width+1)*t.stride)
+	}
+	t.sums = t.sums[:(width+1)*t.stride]
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			t.coord.X = t.from.X + x
+			t.coord.Y = t.from.Y + y
+			liveUnitsCount := 0
+			if unit, _ := getAdjacentUnit(&t.origin, &t.coord); unit.State == 1 {
+				liveUnitsCount = 1
+			}
+			t.sums[(x+1)*t.stride+y+1] = liveUnitsCount + t.sums[x*t.stride+y+1] + t.sums[(x+1)*t.stride+y] - t.sums[x*t.stride+y]
+		}
+	}
+	t.isPrepared = true
+}
+
+// Tell whether all units within the radius around the coordinate are in the table.
+func (t *largerThanLifeTable) isCovering(coord *ggol.Coordinate, radius int) bool {
+	return t.isPrepared && coord.X-radius >= t.from.X && coord.X+radius <= t.to.X && coord.Y-radius >= t.from.Y && coord.Y+radius <= t.to.Y
+}
+
+// Count live units from (fromX, fromY) to (toX, toY).
+func (t *largerThanLifeTable) countLiveUnits(fromX int, fromY int, toX int, toY int) int {
+	fromX -= t.from.X
+	fromY -= t.from.Y
+	toX -= t.from.X - 1
+	toY -= t.from.Y - 1
+	return t.sums[toX*t.stride+toY] - t.sums[fromX*t.stride+toY] - t.sums[toX*t.stride+fromY] + t.sums[fromX*t.stride+fromY]
+}
+
+// Count live neighbors of the unit, the unit itself is not included.
+func (r *LargerThanLifeRule) countLiveNeighbors(
+	table *largerThanLifeTable,
+	coord *ggol.Coordinate,
+	unit *GenerationsUnit,
+	getAdjacentUnit ggol.AdjacentUnitGetter[GenerationsUnit],
+) int {
+	if !table.isCovering(coord, r.radius) {
+		relativeCoords := r.neighborhood.GetRelativeCoordinates(coord)
+		liveNeighborsCount := 0
+		for i := range relativeCoords {
+			if adjUnit, _ := getAdjacentUnit(coord, &relativeCoords[i]); adjUnit.State == 1 {
+				liveNeighborsCount += 1
+			}
+		}
+		return liveNeighborsCount
+	}
+
+	liveUnitsCount := 0
+	if r.neighborhoodType == neighborhoodTypeVonNeumann {
+		// Every row of the diamond is a rectangle of height 1.
+		for dy := -r.radius; dy <= r.radius; dy++ {
+			halfWidth := r.radius - abs(dy)
+			liveUnitsCount += table.countLiveUnits(coord.X-halfWidth, coord.Y+dy, coord.X+halfWidth, coord.Y+dy)
+		}
+	} else {
+		liveUnitsCount = table.countLiveUnits(coord.X-r.radius, coord.Y-r.radius, coord.X+r.radius, coord.Y+r.radius)
+	}
+	if unit.State == 1 {
+		liveUnitsCount -= 1
+	}
+	return liveUnitsCount
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Get the next unit, neighbors are counted with the table when it covers them, otherwise one by one.
+func (r *LargerThanLifeRule) generateNextUnit(
+	table *largerThanLifeTable,
+	coord *ggol.Coordinate,
+	unit *GenerationsUnit,
+	getAdjacentUnit ggol.AdjacentUnitGetter[GenerationsUnit],
+) *GenerationsUnit {
+	liveNeighborsCount := r.countLiveNeighbors(table, coord, unit, getAdjacentUnit)
+	if r.isCenterIncluded && unit.State == 1 {
+		liveNeighborsCount += 1
+	}
+	return &r.units[r.GetNextState(unit.State, liveNeighborsCount)]
+}
+
+// Generate a NextUnitGenerator of the rule for GenerationsUnit, it counts neighbors one by one without a summed-area table,
+// so you can set it to any number of games.
+func NewLargerThanLifeNextUnitGenerator(rule *LargerThanLifeRule) ggol.NextUnitGenerator[GenerationsUnit] {
+	// The table is never prepared, so it covers nothing.
+	table := largerThanLifeTable{}
+	return func(coord *ggol.Coordinate, unit *GenerationsUnit, getAdjacentUnit ggol.AdjacentUnitGetter[GenerationsUnit]) *GenerationsUnit {
+		return rule.generateNextUnit(&table, coord, unit, getAdjacentUnit)
+	}
+}
+
+// Set a GenerationPreparer and a NextUnitGenerator of the rule to the game. The preparer builds a summed-area table
+// of live units before every generation, so the generator counts neighbors in constant time for Moore neighborhood
+// and in linear time of the radius for von Neumann neighborhood. Every game gets its own table.
+// The game only takes the preparer with UpdateOrderSynchronous, otherwise you get the error and nothing is set.
+func SetLargerThanLifeGenerators(game ggol.Game[GenerationsUnit], rule *LargerThanLifeRule) error {
+	table := largerThanLifeTable{}
+	err := game.SetGenerationPreparer(func(area *ggol.Area, getAdjacentUnit ggol.AdjacentUnitGetter[GenerationsUnit]) {
+		table.prepare(area, getAdjacentUnit, rule.radius)
+	})
+	if err != nil {
+		return err
+	}
+	game.SetNextUnitGenerator(func(coord *ggol.Coordinate, unit *GenerationsUnit, getAdjacentUnit ggol.AdjacentUnitGetter[GenerationsUnit]) *GenerationsUnit {
+		return rule.generateNextUnit(&table, coord, unit, getAdjacentUnit)
+	})
+	return nil
+}
@@ -0,0 +1,300 @@
+package rule
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateRandomGenerationsUnitsForTest(width int, height int, statesCount int, seed int64) *[][]GenerationsUnit {
+	random := rand.New(rand.NewSource(seed))
+	units := generateGenerationsUnitsForTest(width, height)
+	for x := 0; x < width; x += 1 {
+		for y := 0; y < height; y += 1 {
+			(*units)[x][y].State = random.Intn(statesCount)
+		}
+	}
+	return units
+}
+
+func testNewLargerThanLifeRuleCaseOne(t *testing.T) {
+	testCases := map[string]string{
+		RuleBosco:                       RuleBosco,
+		RuleMajority:                    RuleMajority,
+		RuleGnarl:                       RuleGnarl,
+		"r5, c0, m1, s34..58, b34..45":  RuleBosco,
+		"B34..45,S34..58,M1,R5":         RuleBosco,
+		"R2,S3..4,B3":                   "R2,C0,M0,S3..4,B3..3,NM",
+		"R3,C2,M0,S0..10,B5..6,NN":      "R3,C0,M0,S0..10,B5..6,NN",
+		"R10,C5,M1,S100..200,B150..160": "R10,C5,M1,S100..200,B150..160,NM",
+	}
+	for rulestring, expectedRulestring := range testCases {
+		rule, err := NewLargerThanLifeRule(rulestring)
+		if err != nil {
+			t.Fatalf("Should parse \"%v\", but got error: %v.", rulestring, err)
+		}
+		if rule.String() != expectedRulestring {
+			t.Fatalf("\"%v\" should be parsed as \"%v\", but got \"%v\".", rulestring, expectedRulestring, rule.String())
+		}
+		if reparsedRule, _ := NewLargerThanLifeRule(rule.String()); reparsedRule.String() != rule.String() {
+			t.Fatalf("\"%v\" should be parsed back to itself.", rule.String())
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewLargerThanLifeRuleCaseTwo(t *testing.T) {
+	invalidRulestrings := []string{
+		"", "R5", "S3..4,B3", "R2,S3..4", "R2,B3", "R2,S3..4,B3,R3", "R2,S3..4,B3,M2",
+		"R2,S3..4,B3,NX", "R2,S4..3,B3", "R2,S3..,B3", "R2,Sx,B3", "Rx,S3,B3", "R2,S3,B3,X1", "R2,,S3,B3",
+	}
+	for _, rulestring := range invalidRulestrings {
+		_, err := NewLargerThanLifeRule(rulestring)
+		if _, ok := err.(*ErrRuleIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	if _, err := NewLargerThanLifeRule("R0,S1,B1"); err == nil {
+		t.Fatalf("Should get error when radius is less than 1.")
+	} else if _, ok := err.(*ggol.ErrRadiusIsInvalid); !ok {
+		t.Fatalf("Should get ErrRadiusIsInvalid, but got %v.", err)
+	}
+	if _, err := NewLargerThanLifeRule("R1,C1,S1,B1"); err == nil {
+		t.Fatalf("Should get error when count of states is 1.")
+	} else if _, ok := err.(*ErrStatesCountIsInvalid); !ok {
+		t.Fatalf("Should get ErrStatesCountIsInvalid, but got %v.", err)
+	}
+	// Radius 1 has 8 neighbors, 9 with the unit itself, von Neumann radius 2 has 12 neighbors.
+	for _, rulestring := range []string{"R1,M0,S9,B3", "R1,M1,S2,B10", "R2,S13,B3,NN"} {
+		if _, err := NewLargerThanLifeRule(rulestring); err == nil {
+			t.Fatalf("Should get error when range of \"%v\" is more than neighbors.", rulestring)
+		} else if _, ok := err.(*ErrNeighborsCountIsInvalid); !ok {
+			t.Fatalf("Should get ErrNeighborsCountIsInvalid, but got %v.", err)
+		}
+	}
+	if _, err := NewLargerThanLifeRule("R1,M1,S9,B9"); err != nil {
+		t.Fatalf("Unit itself should be counted when \"M\" is 1, but got error: %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewLargerThanLifeRule(t *testing.T) {
+	testNewLargerThanLifeRuleCaseOne(t)
+	testNewLargerThanLifeRuleCaseTwo(t)
+}
+
+func testLargerThanLifeRuleGetNextStateCaseOne(t *testing.T) {
+	rule, _ := NewLargerThanLifeRule("R2,C4,M1,S5..8,B3..4")
+	testCases := []struct {
+		state              int
+		liveNeighborsCount int
+		expectedState      int
+	}{
+		{0, 2, 0}, {0, 3, 1}, {0, 4, 1}, {0, 5, 0},
+		{1, 5, 1}, {1, 8, 1}, {1, 4, 2}, {1, 9, 2},
+		{2, 5, 3}, {3, 5, 0},
+	}
+	for _, testCase := range testCases {
+		if nextState := rule.GetNextState(testCase.state, testCase.liveNeighborsCount); nextState != testCase.expectedState {
+			t.Fatalf("State %v with %v live neighbors should become %v, but got %v.", testCase.state, testCase.liveNeighborsCount, testCase.expectedState, nextState)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestLargerThanLifeRuleGetNextState(t *testing.T) {
+	testLargerThanLifeRuleGetNextStateCaseOne(t)
+}
+
+func testSetLargerThanLifeGeneratorsCaseOne(t *testing.T) {
+	// With radius 1 and without the unit itself, it's Conway's Game of Life.
+	largerThanLifeRule, _ := NewLargerThanLifeRule("R1,C0,M0,S2..3,B3,NM")
+	lifeLikeRule, _ := NewLifeLikeRule(RuleConwaysGameOfLife)
+
+	largerThanLifeGame, _ := ggol.NewGame(generateGenerationsUnitsForTest(20, 20))
+	SetLargerThanLifeGenerators(largerThanLifeGame, largerThanLifeRule)
+	lifeLikeGame, _ := ggol.NewGame(generateLifeUnitsForTest(20, 20))
+	lifeLikeGame.SetNextUnitGenerator(NewLifeUnitNextUnitGenerator(lifeLikeRule))
+
+	for _, boundaryPolicy := range []ggol.BoundaryPolicy{ggol.BoundaryPolicyWrap, ggol.BoundaryPolicyConstant} {
+		largerThanLifeGame.SetBoundaryPolicy(boundaryPolicy)
+		lifeLikeGame.SetBoundaryPolicy(boundaryPolicy)
+		largerThanLifeGame.IterateUnits(func(coord *ggol.Coordinate, unit *GenerationsUnit) {
+			unit.State = 0
+			if (coord.X*7+coord.Y*3)%5 < 2 {
+				unit.State = 1
+			}
+		})
+		lifeLikeGame.IterateUnits(func(coord *ggol.Coordinate, unit *LifeUnit) {
+			unit.Alive = (coord.X*7+coord.Y*3)%5 < 2
+		})
+		for i := 0; i < 10; i++ {
+			largerThanLifeUnits := *largerThanLifeGame.GenerateNextUnits()
+			lifeLikeUnits := *lifeLikeGame.GenerateNextUnits()
+			for x := range lifeLikeUnits {
+				for y := range lifeLikeUnits[x] {
+					if (largerThanLifeUnits[x][y].State == 1) != lifeLikeUnits[x][y].Alive {
+						t.Fatalf("Generation %v: unit at (%v, %v) is not generated like Conway's Game of Life.", i+1, x, y)
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLargerThanLifeGeneratorsCaseTwo(t *testing.T) {
+	// Counting with summed-area tables should be the same as counting neighbors one by one.
+	for _, rulestring := range []string{RuleBosco, RuleMajority, "R3,C4,M0,S4..12,B5..8,NN", "R7,C3,M1,S60..120,B70..100,NM"} {
+		rule, _ := NewLargerThanLifeRule(rulestring)
+		for _, boundaryPolicy := range []ggol.BoundaryPolicy{ggol.BoundaryPolicyWrap, ggol.BoundaryPolicyConstant, ggol.BoundaryPolicyMirror, ggol.BoundaryPolicyProjectivePlane} {
+			preparedGame, _ := ggol.NewGame(generateRandomGenerationsUnitsForTest(24, 30, 2, 1))
+			SetLargerThanLifeGenerators(preparedGame, rule)
+			preparedGame.SetBoundaryPolicy(boundaryPolicy)
+
+			unpreparedGame, _ := ggol.NewGame(generateRandomGenerationsUnitsForTest(24, 30, 2, 1))
+			unpreparedGame.SetNextUnitGenerator(NewLargerThanLifeNextUnitGenerator(rule))
+			unpreparedGame.SetBoundaryPolicy(boundaryPolicy)
+
+			for i := 0; i < 6; i++ {
+				preparedUnits := *preparedGame.GenerateNextUnits()
+				unpreparedUnits := *unpreparedGame.GenerateNextUnits()
+				for x := range preparedUnits {
+					for y := range preparedUnits[x] {
+						if preparedUnits[x][y] != unpreparedUnits[x][y] {
+							t.Fatalf("\"%v\" generation %v: units at (%v, %v) are different with summed-area tables.", rulestring, i+1, x, y)
+						}
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLargerThanLifeGeneratorsCaseThree(t *testing.T) {
+	rule, _ := NewLargerThanLifeRule(RuleBosco)
+	preparedGame := ggol.NewInfiniteGame(GenerationsUnit{})
+	SetLargerThanLifeGenerators(preparedGame, rule)
+	unpreparedGame := ggol.NewInfiniteGame(GenerationsUnit{})
+	unpreparedGame.SetNextUnitGenerator(NewLargerThanLifeNextUnitGenerator(rule))
+
+	units := *generateRandomGenerationsUnitsForTest(20, 20, 2, 2)
+	for x := range units {
+		for y := range units[x] {
+			preparedGame.SetUnit(&ggol.Coordinate{X: x - 10, Y: y - 10}, &units[x][y])
+			unpreparedGame.SetUnit(&ggol.Coordinate{X: x - 10, Y: y - 10}, &units[x][y])
+		}
+	}
+	area := ggol.Area{From: ggol.Coordinate{X: -30, Y: -30}, To: ggol.Coordinate{X: 30, Y: 30}}
+	for i := 0; i < 4; i++ {
+		preparedGame.GenerateNextUnits()
+		unpreparedGame.GenerateNextUnits()
+		preparedUnits, _ := preparedGame.GetUnitsInArea(&area)
+		unpreparedUnits, _ := unpreparedGame.GetUnitsInArea(&area)
+		for x := range *preparedUnits {
+			for y := range (*preparedUnits)[x] {
+				if (*preparedUnits)[x][y] != (*unpreparedUnits)[x][y] {
+					t.Fatalf("Generation %v: units at (%v, %v) are different with summed-area tables.", i+1, x+area.From.X, y+area.From.Y)
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLargerThanLifeGeneratorsCaseFour(t *testing.T) {
+	rule, _ := NewLargerThanLifeRule(RuleBosco)
+	g, _ := ggol.NewGame(generateRandomGenerationsUnitsForTest(32, 32, 2, 3))
+	SetLargerThanLifeGenerators(g, rule)
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func testSetLargerThanLifeGeneratorsCaseFive(t *testing.T) {
+	// Games of different sizes with the same rule have their own tables, even when they're generated concurrently.
+	rule, _ := NewLargerThanLifeRule(RuleBosco)
+	sizes := []ggol.Size{{Width: 24, Height: 30}, {Width: 40, Height: 16}}
+	preparedGames := make([]ggol.Game[GenerationsUnit], len(sizes))
+	unpreparedGames := make([]ggol.Game[GenerationsUnit], len(sizes))
+	for i, size := range sizes {
+		preparedGames[i], _ = ggol.NewGame(generateRandomGenerationsUnitsForTest(size.Width, size.Height, 2, int64(i)))
+		SetLargerThanLifeGenerators(preparedGames[i], rule)
+		unpreparedGames[i], _ = ggol.NewGame(generateRandomGenerationsUnitsForTest(size.Width, size.Height, 2, int64(i)))
+		unpreparedGames[i].SetNextUnitGenerator(NewLargerThanLifeNextUnitGenerator(rule))
+	}
+
+	for generation := 0; generation < 6; generation++ {
+		wg := sync.WaitGroup{}
+		for i := range preparedGames {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				preparedGames[i].GenerateNextUnits()
+			}(i)
+		}
+		wg.Wait()
+		for i := range preparedGames {
+			preparedUnits := *preparedGames[i].GetUnits()
+			unpreparedUnits := *unpreparedGames[i].GenerateNextUnits()
+			for x := range preparedUnits {
+				for y := range preparedUnits[x] {
+					if preparedUnits[x][y] != unpreparedUnits[x][y] {
+						t.Fatalf("Game %v generation %v: units at (%v, %v) are different with summed-area tables.", i, generation+1, x, y)
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testSetLargerThanLifeGeneratorsCaseSix(t *testing.T) {
+	rule, _ := NewLargerThanLifeRule(RuleBosco)
+	g, _ := ggol.NewGame(generateRandomGenerationsUnitsForTest(8, 8, 2, 5))
+	g.SetUpdateOrder(ggol.UpdateOrderRandom, 0)
+	if _, ok := SetLargerThanLifeGenerators(g, rule).(*ggol.ErrUpdateOrderIsNotSupported); !ok {
+		t.Fatalf("Should get ErrUpdateOrderIsNotSupported when units are not updated synchronously.")
+	}
+	t.Log("Passed")
+}
+
+func TestSetLargerThanLifeGenerators(t *testing.T) {
+	testSetLargerThanLifeGeneratorsCaseOne(t)
+	testSetLargerThanLifeGeneratorsCaseTwo(t)
+	testSetLargerThanLifeGeneratorsCaseThree(t)
+	testSetLargerThanLifeGeneratorsCaseFour(t)
+	testSetLargerThanLifeGeneratorsCaseFive(t)
+	testSetLargerThanLifeGeneratorsCaseSix(t)
+}
+
+func benchmarkLargerThanLife(b *testing.B, rulestring string, isPrepared bool) {
+	rule, _ := NewLargerThanLifeRule(rulestring)
+	g, _ := ggol.NewGame(generateRandomGenerationsUnitsForTest(128, 128, 2, 4))
+	if isPrepared {
+		SetLargerThanLifeGenerators(g, rule)
+	} else {
+		g.SetNextUnitGenerator(NewLargerThanLifeNextUnitGenerator(rule))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.GenerateNextUnits()
+	}
+}
+
+func BenchmarkLargerThanLifeWithSummedAreaTable(b *testing.B) {
+	benchmarkLargerThanLife(b, "R10,C0,M1,S122..211,B123..170,NM", true)
+}
+
+func BenchmarkLargerThanLifeWithoutSummedAreaTable(b *testing.B) {
+	benchmarkLargerThanLife(b, "R10,C0,M1,S122..211,B123..170,NM", false)
+}
@@ -0,0 +1,251 @@
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Rulestrings of some well-known life-like games.
+const (
+	RuleConwaysGameOfLife string = "B3/S23"
+	RuleHighLife          string = "B36/S23"
+	RuleSeeds             string = "B2/S"
+	RuleDayAndNight       string = "B3678/S34678"
+	RuleLifeWithoutDeath  string = "B3/S012345678"
+)
+
+type neighborhoodType byte
+
+const (
+	neighborhoodTypeMoore      neighborhoodType = 'M'
+	neighborhoodTypeVonNeumann neighborhoodType = 'V'
+	neighborhoodTypeHexagonal  neighborhoodType = 'H'
+)
+
+// LifeLikeRule decides whether a unit is alive in the next generation by counting its live neighbors.
+type LifeLikeRule struct {
+	// Indexed by live neighbors count.
+	birthCounts      []bool
+	survivalCounts   []bool
+	neighborhoodType neighborhoodType
+	radius           int
+	neighborhood     *ggol.Neighborhood
+}
+
+// Parse the rulestring into a LifeLikeRule, these forms are supported:
+//   - "B3/S23", birth counts after "B" and survival counts after "S", the order doesn't matter.
+//   - "23/3", survival counts and birth counts.
+//   - "B5-7/S4,6-9/R2", counts are separated by commas and ranges can be given with "-" or "..", "R" tells the radius.
+//
+// The neighborhood is Moore by default, append "V" for von Neumann or "H" for hexagonal, e.g. "B2/S34H".
+// Remember to set TopologyHexagonal to your game before setting the hexagonal neighborhood.
+func NewLifeLikeRule(rulestring string) (*LifeLikeRule, error) {
+	normalizedRulestring := strings.ToUpper(strings.ReplaceAll(rulestring, " ", ""))
+	if normalizedRulestring == "" {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+
+	var birthSection, survivalSection *string
+	var radiusSection *string
+	plainSections := make([]string, 0)
+	parsedNeighborhoodType := neighborhoodTypeMoore
+	sections := strings.Split(normalizedRulestring, "/")
+	for i, section := range sections {
+		if i == len(sections)-1 && section != "" {
+			switch lastLetter := neighborhoodType(section[len(section)-1]); lastLetter {
+			case neighborhoodTypeMoore, neighborhoodTypeVonNeumann, neighborhoodTypeHexagonal:
+				parsedNeighborhoodType = lastLetter
+				section = section[:len(section)-1]
+				if section == "" {
+					continue
+				}
+			}
+		}
+
+		content := section
+		var target **string
+		switch {
+		case strings.HasPrefix(section, "B"):
+			target = &birthSection
+			content = section[1:]
+		case strings.HasPrefix(section, "S"):
+			target = &survivalSection
+			content = section[1:]
+		case strings.HasPrefix(section, "R"):
+			target = &radiusSection
+			content = section[1:]
+		default:
+			plainSections = append(plainSections, section)
+			continue
+		}
+		if *target != nil {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		*target = &content
+	}
+
+	if birthSection == nil && survivalSection == nil && len(plainSections) == 2 {
+		survivalSection = &plainSections[0]
+		birthSection = &plainSections[1]
+	} else if birthSection == nil || survivalSection == nil || len(plainSections) > 0 {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+
+	radius := 1
+	if radiusSection != nil {
+		var err error
+		if radius, err = strconv.Atoi(*radiusSection); err != nil {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+	}
+
+	var neighborhood *ggol.Neighborhood
+	var err error
+	switch parsedNeighborhoodType {
+	case neighborhoodTypeVonNeumann:
+		neighborhood, err = ggol.NewVonNeumannNeighborhood(radius)
+	case neighborhoodTypeHexagonal:
+		neighborhood, err = ggol.NewHexagonalNeighborhood(radius)
+	default:
+		neighborhood, err = ggol.NewMooreNeighborhood(radius)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	maxCount := len(neighborhood.GetRelativeCoordinates(&ggol.Coordinate{X: 0, Y: 0}))
+	birthCounts, err := parseCounts(rulestring, *birthSection, maxCount)
+	if err != nil {
+		return nil, err
+	}
+	survivalCounts, err := parseCounts(rulestring, *survivalSection, maxCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LifeLikeRule{
+		birthCounts:      birthCounts,
+		survivalCounts:   survivalCounts,
+		neighborhoodType: parsedNeighborhoodType,
+		radius:           radius,
+		neighborhood:     neighborhood,
+	}, nil
+}
+
+// Parse counts like "23" or "2,5-7" into a slice indexed by count.
+func parseCounts(rulestring string, section string, maxCount int) ([]bool, error) {
+	counts := make([]bool, maxCount+1)
+	if section == "" {
+		return counts, nil
+	}
+
+	tokens := make([]string, 0)
+	if strings.ContainsAny(section, ",-.") {
+		tokens = strings.Split(section, ",")
+	} else {
+		for _, digit := range section {
+			tokens = append(tokens, string(digit))
+		}
+	}
+
+	for _, token := range tokens {
+		bounds := strings.SplitN(strings.Replace(token, "..", "-", 1), "-", 2)
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = strconv.Atoi(bounds[1]); err != nil || to < from {
+				return nil, &ErrRuleIsInvalid{rulestring}
+			}
+		}
+		if from < 0 || to > maxCount {
+			return nil, &ErrNeighborsCountIsInvalid{Rule: rulestring, Count: to, MaxCount: maxCount}
+		}
+		for count := from; count <= to; count++ {
+			counts[count] = true
+		}
+	}
+	return counts, nil
+}
+
+// Format counts back into "23", or "2,5-7" when some count has more than one digit.
+func formatCounts(counts []bool) string {
+	if len(counts) <= 10 {
+		var builder strings.Builder
+		for count, isIncluded := range counts {
+			if isIncluded {
+				builder.WriteString(strconv.Itoa(count))
+			}
+		}
+		return builder.String()
+	}
+
+	tokens := make([]string, 0)
+	for from := 0; from < len(counts); from++ {
+		if !counts[from] {
+			continue
+		}
+		to := from
+		for to+1 < len(counts) && counts[to+1] {
+			to++
+		}
+		if from == to {
+			tokens = append(tokens, strconv.Itoa(from))
+		} else {
+			tokens = append(tokens, fmt.Sprintf("%v-%v", from, to))
+		}
+		from = to
+	}
+	return strings.Join(tokens, ",")
+}
+
+// Get the canonical rulestring of the rule, you can parse it with NewLifeLikeRule again.
+func (r *LifeLikeRule) String() string {
+	return r.formatBirthAndSurvival() + r.formatRadiusAndNeighborhood()
+}
+
+func (r *LifeLikeRule) formatBirthAndSurvival() string {
+	return fmt.Sprintf("B%v/S%v", formatCounts(r.birthCounts), formatCounts(r.survivalCounts))
+}
+
+// Format the radius and the neighborhood like "/R2V", it's empty for Moore neighborhood with radius 1.
+func (r *LifeLikeRule) formatRadiusAndNeighborhood() string {
+	rulestring := ""
+	if r.radius > 1 {
+		rulestring += fmt.Sprintf("/R%v", r.radius)
+	}
+	if r.neighborhoodType != neighborhoodTypeMoore {
+		rulestring += string(r.neighborhoodType)
+	}
+	return rulestring
+}
+
+// Get the neighborhood of the rule, you can pass it into SetNeighborhoodNextUnitGenerator.
+func (r *LifeLikeRule) GetNeighborhood() *ggol.Neighborhood {
+	return r.neighborhood
+}
+
+// Every live neighbor adds 1, so the weight is the live neighbors count.
+func (r *LifeLikeRule) getNeighborWeight(index int) int {
+	return 1
+}
+
+func (r *LifeLikeRule) isNextUnitAliveWithWeight(isAlive bool, weight int) bool {
+	return r.IsNextUnitAlive(isAlive, weight)
+}
+
+// Tell you whether the unit is alive in the next generation.
+func (r *LifeLikeRule) IsNextUnitAlive(isAlive bool, liveNeighborsCount int) bool {
+	if liveNeighborsCount < 0 || liveNeighborsCount >= len(r.birthCounts) {
+		return false
+	}
+	if isAlive {
+		return r.survivalCounts[liveNeighborsCount]
+	}
+	return r.birthCounts[liveNeighborsCount]
+}
@@ -0,0 +1,84 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func testNewLifeLikeRuleCaseOne(t *testing.T) {
+	testCases := map[string]string{
+		"B3/S23":           "B3/S23",
+		"b3/s23":           "B3/S23",
+		"S23/B3":           "B3/S23",
+		"23/3":             "B3/S23",
+		"B36/S23":          "B36/S23",
+		"B2/S":             "B2/S",
+		"/2":               "B2/S",
+		"B2/S34H":          "B2/S34H",
+		"B2/S34/H":         "B2/S34H",
+		"B13/S0V":          "B13/S0V",
+		"B3/S23/R1":        "B3/S23",
+		"B3..4/S2-3":       "B34/S23",
+		"B5-7/S4,6-9/R2":   "B5-7/S4,6-9/R2",
+		"B10,12/S8..9/R2V": "B10,12/S8-9/R2V",
+	}
+	for rulestring, expectedRulestring := range testCases {
+		rule, err := NewLifeLikeRule(rulestring)
+		if err != nil {
+			t.Fatalf("Should parse \"%v\", but got error: %v.", rulestring, err)
+		}
+		if rule.String() != expectedRulestring {
+			t.Fatalf("\"%v\" should be parsed as \"%v\", but got \"%v\".", rulestring, expectedRulestring, rule.String())
+		}
+		if reparsedRule, _ := NewLifeLikeRule(rule.String()); reparsedRule.String() != rule.String() {
+			t.Fatalf("\"%v\" should be parsed back to itself.", rule.String())
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewLifeLikeRuleCaseTwo(t *testing.T) {
+	for _, rulestring := range []string{"", "B3", "S23", "B3/S23/B3", "B3/S2a", "B3/S23/X", "3", "1/2/3", "B3/S23/RX", "B4-3/S23"} {
+		_, err := NewLifeLikeRule(rulestring)
+		if _, ok := err.(*ErrRuleIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	for _, rulestring := range []string{"B9/S23", "B3/S5V", "B7/S2H", "B3/S2,13/R2V"} {
+		_, err := NewLifeLikeRule(rulestring)
+		if _, ok := err.(*ErrNeighborsCountIsInvalid); !ok {
+			t.Fatalf("Should get ErrNeighborsCountIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	_, err := NewLifeLikeRule("B3/S23/R0")
+	if _, ok := err.(*ggol.ErrRadiusIsInvalid); !ok {
+		t.Fatalf("Should get ErrRadiusIsInvalid when radius is 0, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewLifeLikeRule(t *testing.T) {
+	testNewLifeLikeRuleCaseOne(t)
+	testNewLifeLikeRuleCaseTwo(t)
+}
+
+func testIsNextUnitAliveCaseOne(t *testing.T) {
+	rule, _ := NewLifeLikeRule(RuleHighLife)
+	for count := 0; count <= 8; count++ {
+		if rule.IsNextUnitAlive(false, count) != (count == 3 || count == 6) {
+			t.Fatalf("Dead unit with %v live neighbors is not generated correctly in HighLife.", count)
+		}
+		if rule.IsNextUnitAlive(true, count) != (count == 2 || count == 3) {
+			t.Fatalf("Live unit with %v live neighbors is not generated correctly in HighLife.", count)
+		}
+	}
+	if rule.IsNextUnitAlive(false, 9) {
+		t.Fatalf("Should never be alive with more neighbors than the neighborhood has.")
+	}
+	t.Log("Passed")
+}
+
+func TestIsNextUnitAlive(t *testing.T) {
+	testIsNextUnitAliveCaseOne(t)
+}
@@ -0,0 +1,129 @@
+package rule
+
+import (
+	"math/bits"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// LatticeGasUnit is the unit of lattice gases, it tells whether a particle is here.
+type LatticeGasUnit struct {
+	HasParticle bool
+}
+
+// Masks of blocks, bit 0, 1, 2 and 3 tell whether units at (0, 0), (1, 0), (0, 1) and (1, 1) are set.
+const (
+	blockMaskAll          = 0b1111
+	blockMaskMainDiagonal = 0b1001
+	blockMaskAntiDiagonal = 0b0110
+)
+
+// Rotating a block by 180 degrees swaps (0, 0) with (1, 1), and (1, 0) with (0, 1).
+func rotateBlockMaskBy180(mask int) int {
+	return (mask&0b0001)<<3 | (mask&0b1000)>>3 | (mask&0b0010)<<1 | (mask&0b0100)>>1
+}
+
+func complementBlockMask(mask int) int {
+	return ^mask & blockMaskAll
+}
+
+// Build the table of blocks of every mask, generators return them so they don't allocate.
+func newBlocksOfMasks[T any](newUnit func(isSet bool) T) *[16]ggol.Block[T] {
+	blocks := [16]ggol.Block[T]{}
+	for mask := range blocks {
+		blocks[mask][0][0] = newUnit(mask&0b0001 != 0)
+		blocks[mask][1][0] = newUnit(mask&0b0010 != 0)
+		blocks[mask][0][1] = newUnit(mask&0b0100 != 0)
+		blocks[mask][1][1] = newUnit(mask&0b1000 != 0)
+	}
+	return &blocks
+}
+
+func getBlockMask[T any](block *ggol.Block[T], isSet func(unit *T) bool) int {
+	mask := 0
+	if isSet(&block[0][0]) {
+		mask |= 0b0001
+	}
+	if isSet(&block[1][0]) {
+		mask |= 0b0010
+	}
+	if isSet(&block[0][1]) {
+		mask |= 0b0100
+	}
+	if isSet(&block[1][1]) {
+		mask |= 0b1000
+	}
+	return mask
+}
+
+var lifeBlocks = newBlocksOfMasks(func(isSet bool) LifeUnit { return LifeUnit{Alive: isSet} })
+
+var latticeGasBlocks = newBlocksOfMasks(func(isSet bool) LatticeGasUnit { return LatticeGasUnit{HasParticle: isSet} })
+
+func hasLatticeGasUnitParticle(unit *LatticeGasUnit) bool {
+	return unit.HasParticle
+}
+
+// Get the next block of Critters, the block stays the same when it has exactly 2 live units,
+// otherwise it's complemented, and it's also rotated by 180 degrees when it had 3 live units.
+func GetCrittersNextBlock(block *ggol.Block[LifeUnit]) *ggol.Block[LifeUnit] {
+	mask := getBlockMask(block, isLifeUnitAlive)
+	switch bits.OnesCount(uint(mask)) {
+	case 2:
+		return &lifeBlocks[mask]
+	case 3:
+		return &lifeBlocks[rotateBlockMaskBy180(complementBlockMask(mask))]
+	default:
+		return &lifeBlocks[complementBlockMask(mask)]
+	}
+}
+
+// Get the previous block of Critters, it's the inverse of GetCrittersNextBlock, a block with 1 live unit
+// came from a block with 3 live units, so it's complemented and rotated back.
+func GetCrittersPreviousBlock(block *ggol.Block[LifeUnit]) *ggol.Block[LifeUnit] {
+	mask := getBlockMask(block, isLifeUnitAlive)
+	switch bits.OnesCount(uint(mask)) {
+	case 2:
+		return &lifeBlocks[mask]
+	case 1:
+		return &lifeBlocks[rotateBlockMaskBy180(complementBlockMask(mask))]
+	default:
+		return &lifeBlocks[complementBlockMask(mask)]
+	}
+}
+
+// Generate BlockGenerators of Critters, set them to your BlockGame with SetNextBlockGenerator and SetPreviousBlockGenerator.
+// Critters is a reversible rule of Margolus, gliders fly in it and bounce off each other.
+func NewCrittersBlockGenerators() (nextBlockGenerator ggol.BlockGenerator[LifeUnit], previousBlockGenerator ggol.BlockGenerator[LifeUnit]) {
+	nextBlockGenerator = func(coord *ggol.Coordinate, block *ggol.Block[LifeUnit]) *ggol.Block[LifeUnit] {
+		return GetCrittersNextBlock(block)
+	}
+	previousBlockGenerator = func(coord *ggol.Coordinate, block *ggol.Block[LifeUnit]) *ggol.Block[LifeUnit] {
+		return GetCrittersPreviousBlock(block)
+	}
+	return nextBlockGenerator, previousBlockGenerator
+}
+
+// Get the next block of the HPP lattice gas, every particle moves to the opposite corner of the block,
+// except that 2 particles on a diagonal collide head-on and leave along the other diagonal.
+// It's its own inverse, so it's also the previous block.
+func GetHPPGasNextBlock(block *ggol.Block[LatticeGasUnit]) *ggol.Block[LatticeGasUnit] {
+	mask := getBlockMask(block, hasLatticeGasUnitParticle)
+	switch mask {
+	case blockMaskMainDiagonal:
+		return &latticeGasBlocks[blockMaskAntiDiagonal]
+	case blockMaskAntiDiagonal:
+		return &latticeGasBlocks[blockMaskMainDiagonal]
+	default:
+		return &latticeGasBlocks[rotateBlockMaskBy180(mask)]
+	}
+}
+
+// Generate BlockGenerators of the HPP lattice gas, set them to your BlockGame with SetNextBlockGenerator and SetPreviousBlockGenerator.
+// Particles move diagonally and conserve their count and momentum, so the gas diffuses like a real gas.
+func NewHPPGasBlockGenerators() (nextBlockGenerator ggol.BlockGenerator[LatticeGasUnit], previousBlockGenerator ggol.BlockGenerator[LatticeGasUnit]) {
+	nextBlockGenerator = func(coord *ggol.Coordinate, block *ggol.Block[LatticeGasUnit]) *ggol.Block[LatticeGasUnit] {
+		return GetHPPGasNextBlock(block)
+	}
+	return nextBlockGenerator, nextBlockGenerator
+}
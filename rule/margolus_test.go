@@ -0,0 +1,219 @@
+package rule
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateLifeBlockForTest(mask int) *ggol.Block[LifeUnit] {
+	return &ggol.Block[LifeUnit]{
+		{{Alive: mask&0b0001 != 0}, {Alive: mask&0b0100 != 0}},
+		{{Alive: mask&0b0010 != 0}, {Alive: mask&0b1000 != 0}},
+	}
+}
+
+func generateLatticeGasBlockForTest(mask int) *ggol.Block[LatticeGasUnit] {
+	return &ggol.Block[LatticeGasUnit]{
+		{{HasParticle: mask&0b0001 != 0}, {HasParticle: mask&0b0100 != 0}},
+		{{HasParticle: mask&0b0010 != 0}, {HasParticle: mask&0b1000 != 0}},
+	}
+}
+
+func generateLatticeGasUnitsForTest(width int, height int) *[][]LatticeGasUnit {
+	units := make([][]LatticeGasUnit, width)
+	for x := 0; x < width; x += 1 {
+		units[x] = make([]LatticeGasUnit, height)
+	}
+	return &units
+}
+
+func generateSoupForTest[T any](width int, height int, seed int64, newUnit func(isSet bool) T) *[][]T {
+	random := rand.New(rand.NewSource(seed))
+	units := make([][]T, width)
+	for x := 0; x < width; x += 1 {
+		units[x] = make([]T, height)
+		for y := 0; y < height; y += 1 {
+			units[x][y] = newUnit(random.Intn(3) == 0)
+		}
+	}
+	return &units
+}
+
+func areUnitsEqualForTest[T comparable](units *[][]T, anotherUnits *[][]T) bool {
+	for x := range *units {
+		for y := range (*units)[x] {
+			if (*units)[x][y] != (*anotherUnits)[x][y] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func testGetCrittersNextBlockCaseOne(t *testing.T) {
+	testCases := []struct {
+		mask         int
+		expectedMask int
+	}{
+		// Blocks with 2 live units stay the same.
+		{0b0011, 0b0011}, {0b1001, 0b1001},
+		// Blocks with 0, 1 or 4 live units are complemented.
+		{0b0000, 0b1111}, {0b0001, 0b1110}, {0b1111, 0b0000},
+		// Blocks with 3 live units are complemented and rotated by 180 degrees.
+		{0b0111, 0b0001}, {0b1110, 0b1000},
+	}
+	for _, testCase := range testCases {
+		nextBlock := GetCrittersNextBlock(generateLifeBlockForTest(testCase.mask))
+		if *nextBlock != *generateLifeBlockForTest(testCase.expectedMask) {
+			t.Fatalf("Block %04b should become %04b, but got %v.", testCase.mask, testCase.expectedMask, *nextBlock)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGetCrittersNextBlockCaseTwo(t *testing.T) {
+	for mask := 0; mask < 16; mask += 1 {
+		block := generateLifeBlockForTest(mask)
+		if previousBlock := GetCrittersPreviousBlock(GetCrittersNextBlock(block)); *previousBlock != *block {
+			t.Fatalf("Previous block of the next block of %04b should be itself, but got %v.", mask, *previousBlock)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetCrittersNextBlock(t *testing.T) {
+	testGetCrittersNextBlockCaseOne(t)
+	testGetCrittersNextBlockCaseTwo(t)
+}
+
+func testNewCrittersBlockGeneratorsCaseOne(t *testing.T) {
+	newLifeUnit := func(isSet bool) LifeUnit { return LifeUnit{Alive: isSet} }
+	g, _ := ggol.NewBlockGame(generateSoupForTest(32, 24, 1, newLifeUnit))
+	nextBlockGenerator, previousBlockGenerator := NewCrittersBlockGenerators()
+	g.SetNextBlockGenerator(nextBlockGenerator)
+	g.SetPreviousBlockGenerator(previousBlockGenerator)
+	g.SetConcurrency(4)
+
+	// Run forward then backward, the soup should come back exactly.
+	for i := 0; i < 100; i += 1 {
+		g.GenerateNextUnits()
+	}
+	if areUnitsEqualForTest(g.GetUnits(), generateSoupForTest(32, 24, 1, newLifeUnit)) {
+		t.Fatalf("Soup should be changed after 100 generations.")
+	}
+	for i := 0; i < 100; i += 1 {
+		g.GeneratePreviousUnits()
+	}
+	if !areUnitsEqualForTest(g.GetUnits(), generateSoupForTest(32, 24, 1, newLifeUnit)) {
+		t.Fatalf("Soup should come back after running 100 generations forward and backward.")
+	}
+	t.Log("Passed")
+}
+
+func TestNewCrittersBlockGenerators(t *testing.T) {
+	testNewCrittersBlockGeneratorsCaseOne(t)
+}
+
+func testGetHPPGasNextBlockCaseOne(t *testing.T) {
+	testCases := []struct {
+		mask         int
+		expectedMask int
+	}{
+		// Particles move to opposite corners.
+		{0b0000, 0b0000}, {0b0001, 0b1000}, {0b0010, 0b0100}, {0b0011, 0b1100}, {0b0111, 0b1110}, {0b1111, 0b1111},
+		// Particles on a diagonal collide and leave along the other diagonal.
+		{0b1001, 0b0110}, {0b0110, 0b1001},
+	}
+	for _, testCase := range testCases {
+		nextBlock := GetHPPGasNextBlock(generateLatticeGasBlockForTest(testCase.mask))
+		if *nextBlock != *generateLatticeGasBlockForTest(testCase.expectedMask) {
+			t.Fatalf("Block %04b should become %04b, but got %v.", testCase.mask, testCase.expectedMask, *nextBlock)
+		}
+	}
+	for mask := 0; mask < 16; mask += 1 {
+		block := generateLatticeGasBlockForTest(mask)
+		if nextBlock := GetHPPGasNextBlock(GetHPPGasNextBlock(block)); *nextBlock != *block {
+			t.Fatalf("Block %04b should be its own inverse, but got %v.", mask, *nextBlock)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetHPPGasNextBlock(t *testing.T) {
+	testGetHPPGasNextBlockCaseOne(t)
+}
+
+func countParticlesForTest(units *[][]LatticeGasUnit) int {
+	count := 0
+	for x := range *units {
+		for y := range (*units)[x] {
+			if (*units)[x][y].HasParticle {
+				count += 1
+			}
+		}
+	}
+	return count
+}
+
+func testNewHPPGasBlockGeneratorsCaseOne(t *testing.T) {
+	g, _ := ggol.NewBlockGame(generateLatticeGasUnitsForTest(8, 8))
+	nextBlockGenerator, _ := NewHPPGasBlockGenerators()
+	g.SetNextBlockGenerator(nextBlockGenerator)
+	g.SetUnit(&ggol.Coordinate{X: 0, Y: 0}, &LatticeGasUnit{HasParticle: true})
+
+	// A single particle flies diagonally and wraps around the map.
+	for i := 1; i <= 10; i += 1 {
+		g.GenerateNextUnits()
+		coord := ggol.Coordinate{X: i % 8, Y: i % 8}
+		if unit, _ := g.GetUnit(&coord); !unit.HasParticle || countParticlesForTest(g.GetUnits()) != 1 {
+			t.Fatalf("Particle should be at %v in generation %v.", coord, i)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewHPPGasBlockGeneratorsCaseTwo(t *testing.T) {
+	newLatticeGasUnit := func(isSet bool) LatticeGasUnit { return LatticeGasUnit{HasParticle: isSet} }
+	g, _ := ggol.NewBlockGame(generateSoupForTest(40, 30, 2, newLatticeGasUnit))
+	nextBlockGenerator, previousBlockGenerator := NewHPPGasBlockGenerators()
+	g.SetNextBlockGenerator(nextBlockGenerator)
+	g.SetPreviousBlockGenerator(previousBlockGenerator)
+	particlesCount := countParticlesForTest(g.GetUnits())
+
+	for i := 0; i < 100; i += 1 {
+		g.GenerateNextUnits()
+		if countParticlesForTest(g.GetUnits()) != particlesCount {
+			t.Fatalf("Particles count should be %v in generation %v, but got %v.", particlesCount, i+1, countParticlesForTest(g.GetUnits()))
+		}
+	}
+	for i := 0; i < 100; i += 1 {
+		g.GeneratePreviousUnits()
+	}
+	if !areUnitsEqualForTest(g.GetUnits(), generateSoupForTest(40, 30, 2, newLatticeGasUnit)) {
+		t.Fatalf("Gas should come back after running 100 generations forward and backward.")
+	}
+	t.Log("Passed")
+}
+
+func testNewHPPGasBlockGeneratorsWithoutAllocation(t *testing.T) {
+	newLatticeGasUnit := func(isSet bool) LatticeGasUnit { return LatticeGasUnit{HasParticle: isSet} }
+	g, _ := ggol.NewBlockGame(generateSoupForTest(64, 64, 3, newLatticeGasUnit))
+	nextBlockGenerator, _ := NewHPPGasBlockGenerators()
+	g.SetNextBlockGenerator(nextBlockGenerator)
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestNewHPPGasBlockGenerators(t *testing.T) {
+	testNewHPPGasBlockGeneratorsCaseOne(t)
+	testNewHPPGasBlockGeneratorsCaseTwo(t)
+	testNewHPPGasBlockGeneratorsWithoutAllocation(t)
+}
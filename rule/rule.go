@@ -0,0 +1,80 @@
+package rule
+
+import (
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Rule decides whether a unit is alive in the next generation by its live neighbors,
+// LifeLikeRule and IsotropicRule are rules.
+type Rule interface {
+	// Get the canonical rulestring of the rule.
+	String() string
+	// Get the neighborhood of the rule, you can pass it into SetNeighborhoodNextUnitGenerator.
+	GetNeighborhood() *ggol.Neighborhood
+	// Every live neighbor adds its weight, the index is in the order of relative coordinates of the neighborhood.
+	getNeighborWeight(index int) int
+	// Tell whether the unit is alive in the next generation with the sum of weights of live neighbors.
+	isNextUnitAliveWithWeight(isAlive bool, weight int) bool
+}
+
+// Generate a NextUnitGenerator of the rule for any unit type,
+// "isAlive" tells whether a unit is alive and "generateNextUnit" returns the next unit with the given state.
+func NewNextUnitGenerator[T any](
+	rule Rule,
+	isAlive func(unit *T) bool,
+	generateNextUnit func(unit *T, isAlive bool) *T,
+) ggol.NextUnitGenerator[T] {
+	neighborhood := rule.GetNeighborhood()
+	return func(coord *ggol.Coordinate, unit *T, getAdjacentUnit ggol.AdjacentUnitGetter[T]) *T {
+		relativeCoords := neighborhood.GetRelativeCoordinates(coord)
+		weight := 0
+		for i := range relativeCoords {
+			adjUnit, _ := getAdjacentUnit(coord, &relativeCoords[i])
+			if isAlive(adjUnit) {
+				weight += rule.getNeighborWeight(i)
+			}
+		}
+		return generateNextUnit(unit, rule.isNextUnitAliveWithWeight(isAlive(unit), weight))
+	}
+}
+
+// Same as NewNextUnitGenerator, but for SetNeighborhoodNextUnitGenerator with the neighborhood of the rule.
+func NewNeighborhoodNextUnitGenerator[T any](
+	rule Rule,
+	isAlive func(unit *T) bool,
+	generateNextUnit func(unit *T, isAlive bool) *T,
+) ggol.NeighborhoodNextUnitGenerator[T] {
+	return func(coord *ggol.Coordinate, unit *T, neighbors []*T) *T {
+		weight := 0
+		for i, neighbor := range neighbors {
+			if isAlive(neighbor) {
+				weight += rule.getNeighborWeight(i)
+			}
+		}
+		return generateNextUnit(unit, rule.isNextUnitAliveWithWeight(isAlive(unit), weight))
+	}
+}
+
+var liveLifeUnit LifeUnit = LifeUnit{Alive: true}
+var deadLifeUnit LifeUnit = LifeUnit{Alive: false}
+
+func isLifeUnitAlive(unit *LifeUnit) bool {
+	return unit.Alive
+}
+
+func generateNextLifeUnit(unit *LifeUnit, isAlive bool) *LifeUnit {
+	if isAlive {
+		return &liveLifeUnit
+	}
+	return &deadLifeUnit
+}
+
+// Generate a NextUnitGenerator of the rule for LifeUnit.
+func NewLifeUnitNextUnitGenerator(rule Rule) ggol.NextUnitGenerator[LifeUnit] {
+	return NewNextUnitGenerator(rule, isLifeUnitAlive, generateNextLifeUnit)
+}
+
+// Generate a NeighborhoodNextUnitGenerator of the rule for LifeUnit.
+func NewLifeUnitNeighborhoodNextUnitGenerator(rule Rule) ggol.NeighborhoodNextUnitGenerator[LifeUnit] {
+	return NewNeighborhoodNextUnitGenerator(rule, isLifeUnitAlive, generateNextLifeUnit)
+}
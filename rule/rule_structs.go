@@ -0,0 +1,58 @@
+package rule
+
+import "fmt"
+
+// LifeUnit is the simplest unit of life-like games, it's either alive or dead.
+type LifeUnit struct {
+	Alive bool
+}
+
+// This error will be thrown when the rulestring can't be parsed.
+type ErrRuleIsInvalid struct {
+	Rule string
+}
+
+// Tell you that the rulestring is invalid.
+func (e *ErrRuleIsInvalid) Error() string {
+	return fmt.Sprintf("Rule \"%v\" is not valid.", e.Rule)
+}
+
+// This error will be thrown when a birth or survival count in the rulestring is more than the neighbors a unit has.
+type ErrNeighborsCountIsInvalid struct {
+	Rule     string
+	Count    int
+	MaxCount int
+}
+
+// Tell you that the neighbors count is invalid.
+func (e *ErrNeighborsCountIsInvalid) Error() string {
+	return fmt.Sprintf("Neighbors count %v in rule \"%v\" is not valid, it should be between 0 and %v.", e.Count, e.Rule, e.MaxCount)
+}
+
+// This error will be thrown when the count of states in the rulestring is less than 2.
+type ErrStatesCountIsInvalid struct {
+	Rule        string
+	StatesCount int
+}
+
+// Tell you that the count of states is invalid.
+func (e *ErrStatesCountIsInvalid) Error() string {
+	return fmt.Sprintf("States count %v in rule \"%v\" is not valid, it should be at least 2.", e.StatesCount, e.Rule)
+}
+
+// ColorUnit is the unit of one-dimensional games like elementary and totalistic rules, color 0 is the background.
+type ColorUnit struct {
+	Color int
+}
+
+// This error will be thrown when the rule number in the rulestring is out of range.
+type ErrRuleNumberIsInvalid struct {
+	Rule      string
+	Number    int
+	MaxNumber int
+}
+
+// Tell you that the rule number is invalid.
+func (e *ErrRuleNumberIsInvalid) Error() string {
+	return fmt.Sprintf("Rule number %v in rule \"%v\" is not valid, it should be between 0 and %v.", e.Number, e.Rule, e.MaxNumber)
+}
@@ -0,0 +1,122 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateLifeUnitsForTest(width int, height int) *[][]LifeUnit {
+	units := make([][]LifeUnit, width)
+	for x := 0; x < width; x += 1 {
+		units[x] = make([]LifeUnit, height)
+	}
+	return &units
+}
+
+func getLiveCoordsForTest(g ggol.Game[LifeUnit]) map[ggol.Coordinate]bool {
+	liveCoords := make(map[ggol.Coordinate]bool)
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *LifeUnit) {
+		if unit.Alive {
+			liveCoords[*coord] = true
+		}
+	})
+	return liveCoords
+}
+
+func testLifeUnitNextUnitGeneratorCaseOne(t *testing.T) {
+	rule, _ := NewLifeLikeRule(RuleConwaysGameOfLife)
+	getterGame, _ := ggol.NewGame(generateLifeUnitsForTest(8, 8))
+	getterGame.SetNextUnitGenerator(NewLifeUnitNextUnitGenerator(rule))
+	neighborhoodGame, _ := ggol.NewGame(generateLifeUnitsForTest(8, 8))
+	neighborhoodGame.SetNeighborhoodNextUnitGenerator(rule.GetNeighborhood(), NewLifeUnitNeighborhoodNextUnitGenerator(rule))
+
+	// Glider.
+	for _, g := range []ggol.Game[LifeUnit]{getterGame, neighborhoodGame} {
+		for _, coord := range []ggol.Coordinate{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}} {
+			g.SetUnit(&coord, &LifeUnit{Alive: true})
+		}
+		for i := 0; i < 4; i++ {
+			g.GenerateNextUnits()
+		}
+		liveCoords := getLiveCoordsForTest(g)
+		expectedLiveCoords := []ggol.Coordinate{{X: 2, Y: 1}, {X: 3, Y: 2}, {X: 1, Y: 3}, {X: 2, Y: 3}, {X: 3, Y: 3}}
+		if len(liveCoords) != len(expectedLiveCoords) {
+			t.Fatalf("Glider should have %v live cells, but got %v.", len(expectedLiveCoords), liveCoords)
+		}
+		for _, coord := range expectedLiveCoords {
+			if !liveCoords[coord] {
+				t.Fatalf("Glider should move by (1, 1) after 4 generations, but got %v.", liveCoords)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testLifeUnitNextUnitGeneratorCaseTwo(t *testing.T) {
+	// HighLife replicator grows, while it dies out in Conway's Game of Life.
+	replicatorCoords := []ggol.Coordinate{
+		{X: 11, Y: 10}, {X: 12, Y: 10}, {X: 13, Y: 10},
+		{X: 10, Y: 11}, {X: 13, Y: 11},
+		{X: 9, Y: 12}, {X: 13, Y: 12},
+		{X: 9, Y: 13}, {X: 12, Y: 13},
+		{X: 9, Y: 14}, {X: 10, Y: 14}, {X: 11, Y: 14},
+	}
+	liveCellsCounts := make(map[string]int)
+	for _, rulestring := range []string{RuleConwaysGameOfLife, RuleHighLife} {
+		rule, _ := NewLifeLikeRule(rulestring)
+		g, _ := ggol.NewGame(generateLifeUnitsForTest(32, 32))
+		g.SetNextUnitGenerator(NewLifeUnitNextUnitGenerator(rule))
+		for _, coord := range replicatorCoords {
+			g.SetUnit(&coord, &LifeUnit{Alive: true})
+		}
+		for i := 0; i < 12; i++ {
+			g.GenerateNextUnits()
+		}
+		liveCellsCounts[rulestring] = len(getLiveCoordsForTest(g))
+	}
+	if liveCellsCounts[RuleConwaysGameOfLife] == liveCellsCounts[RuleHighLife] {
+		t.Fatalf("Replicator should behave differently in HighLife, but both have %v live cells.", liveCellsCounts[RuleHighLife])
+	}
+	t.Log("Passed")
+}
+
+type customUnitForTest struct {
+	generation int
+	isAlive    bool
+}
+
+func testNewNextUnitGeneratorCaseOne(t *testing.T) {
+	rule, _ := NewLifeLikeRule("B1/S/V")
+	units := make([][]customUnitForTest, 5)
+	for x := range units {
+		units[x] = make([]customUnitForTest, 5)
+	}
+	g, _ := ggol.NewGame(&units)
+	g.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	g.SetNextUnitGenerator(NewNextUnitGenerator(
+		rule,
+		func(unit *customUnitForTest) bool { return unit.isAlive },
+		func(unit *customUnitForTest, isAlive bool) *customUnitForTest {
+			return &customUnitForTest{generation: unit.generation + 1, isAlive: isAlive}
+		},
+	))
+	g.SetUnit(&ggol.Coordinate{X: 2, Y: 2}, &customUnitForTest{isAlive: true})
+	g.GenerateNextUnits()
+
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *customUnitForTest) {
+		dx := coord.X - 2
+		dy := coord.Y - 2
+		expectedIsAlive := dx*dx+dy*dy == 1
+		if unit.isAlive != expectedIsAlive || unit.generation != 1 {
+			t.Fatalf("Unit at %v should be alive: %v in generation 1, but got %v.", coord, expectedIsAlive, unit)
+		}
+	})
+	t.Log("Passed")
+}
+
+func TestNextUnitGenerator(t *testing.T) {
+	testLifeUnitNextUnitGeneratorCaseOne(t)
+	testLifeUnitNextUnitGeneratorCaseTwo(t)
+	testNewNextUnitGeneratorCaseOne(t)
+}
@@ -0,0 +1,108 @@
+package rule
+
+import (
+	"math"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// SandpileUnit is the unit of the Abelian sandpile model, it topples when it has as many grains as its neighbors,
+// and gives one grain to every neighbor.
+type SandpileUnit struct {
+	Grains int
+}
+
+// Units with few grains, generators return them so they don't allocate in most cases.
+var sandpileUnits [16]SandpileUnit = [16]SandpileUnit{
+	{0}, {1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}, {10}, {11}, {12}, {13}, {14}, {15},
+}
+
+func getSandpileUnit(grains int) *SandpileUnit {
+	if grains >= 0 && grains < len(sandpileUnits) {
+		return &sandpileUnits[grains]
+	}
+	return &SandpileUnit{Grains: grains}
+}
+
+// Get the neighborhood of the Abelian sandpile model, it's the von Neumann neighborhood of radius 1.
+func GetSandpileNeighborhood() *ggol.Neighborhood {
+	neighborhood, _ := ggol.NewVonNeumannNeighborhood(1)
+	return neighborhood
+}
+
+// Get the next grains of the unit, it topples when "grains" reaches "threshold", and gets one grain from every toppled neighbor.
+func GetSandpileNextGrains(grains int, threshold int, toppledNeighborsCount int) int {
+	if grains >= threshold {
+		grains -= threshold
+	}
+	return grains + toppledNeighborsCount
+}
+
+// Generate a NextUnitGenerator of the Abelian sandpile model, every unit topples once a generation at most,
+// so keep generating with GenerateNextUnitsUntilStable until no unit topples.
+// Set BoundaryPolicyConstant to your game, so grains falling off the border are gone.
+func NewSandpileNextUnitGenerator() ggol.NextUnitGenerator[SandpileUnit] {
+	relativeCoords := GetSandpileNeighborhood().GetRelativeCoordinates(&ggol.Coordinate{X: 0, Y: 0})
+	threshold := len(relativeCoords)
+	return func(coord *ggol.Coordinate, unit *SandpileUnit, getAdjacentUnit ggol.AdjacentUnitGetter[SandpileUnit]) *SandpileUnit {
+		toppledNeighborsCount := 0
+		for i := range relativeCoords {
+			adjUnit, _ := getAdjacentUnit(coord, &relativeCoords[i])
+			if adjUnit.Grains >= threshold {
+				toppledNeighborsCount += 1
+			}
+		}
+		return getSandpileUnit(GetSandpileNextGrains(unit.Grains, threshold, toppledNeighborsCount))
+	}
+}
+
+// Generate a NeighborhoodNextUnitGenerator of the Abelian sandpile model, units topple when they have as many grains
+// as their neighbors, so it works with any neighborhood, e.g. units topple with 8 grains in Moore neighborhood.
+func NewSandpileNeighborhoodNextUnitGenerator() ggol.NeighborhoodNextUnitGenerator[SandpileUnit] {
+	return func(coord *ggol.Coordinate, unit *SandpileUnit, neighbors []*SandpileUnit) *SandpileUnit {
+		threshold := len(neighbors)
+		toppledNeighborsCount := 0
+		for _, neighbor := range neighbors {
+			if neighbor.Grains >= threshold {
+				toppledNeighborsCount += 1
+			}
+		}
+		return getSandpileUnit(GetSandpileNextGrains(unit.Grains, threshold, toppledNeighborsCount))
+	}
+}
+
+// Topple units until they are stable, grains falling off the border are gone, so units always become stable.
+func stabilizeSandpile(units *[][]SandpileUnit) (*[][]SandpileUnit, error) {
+	game, err := ggol.NewGame(units)
+	if err != nil {
+		return nil, err
+	}
+	game.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	game.SetNextUnitGenerator(NewSandpileNextUnitGenerator())
+	if _, err := game.GenerateNextUnitsUntilStable(math.MaxInt, ggol.AreUnitsEqual[SandpileUnit]); err != nil {
+		return nil, err
+	}
+	return game.GetUnits(), nil
+}
+
+// Get the identity of the sandpile group of the size, adding it to any recurrent configuration and toppling changes nothing.
+// It's "stabilize(6 - stabilize(6))", where "6" means every unit has 6 grains.
+func GetSandpileIdentity(size *ggol.Size) (*[][]SandpileUnit, error) {
+	units := make([][]SandpileUnit, size.Width)
+	for x := range units {
+		units[x] = make([]SandpileUnit, size.Height)
+		for y := range units[x] {
+			units[x][y].Grains = 6
+		}
+	}
+	stableUnits, err := stabilizeSandpile(&units)
+	if err != nil {
+		return nil, err
+	}
+	for x := range units {
+		for y := range units[x] {
+			units[x][y].Grains = 6 - (*stableUnits)[x][y].Grains
+		}
+	}
+	return stabilizeSandpile(&units)
+}
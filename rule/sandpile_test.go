@@ -0,0 +1,220 @@
+package rule
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateSandpileUnitsForTest(width int, height int, grains int) *[][]SandpileUnit {
+	units := make([][]SandpileUnit, width)
+	for x := range units {
+		units[x] = make([]SandpileUnit, height)
+		for y := range units[x] {
+			units[x][y].Grains = grains
+		}
+	}
+	return &units
+}
+
+// Add grains of both units and topple them until they are stable.
+func addSandpilesForTest(units *[][]SandpileUnit, anotherUnits *[][]SandpileUnit) *[][]SandpileUnit {
+	sumUnits := generateSandpileUnitsForTest(len(*units), len((*units)[0]), 0)
+	for x := range *sumUnits {
+		for y := range (*sumUnits)[x] {
+			(*sumUnits)[x][y].Grains = (*units)[x][y].Grains + (*anotherUnits)[x][y].Grains
+		}
+	}
+	stableUnits, _ := stabilizeSandpile(sumUnits)
+	return stableUnits
+}
+
+func areSandpilesEqualForTest(units *[][]SandpileUnit, anotherUnits *[][]SandpileUnit) bool {
+	for x := range *units {
+		for y := range (*units)[x] {
+			if (*units)[x][y] != (*anotherUnits)[x][y] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func testGetSandpileNextGrainsCaseOne(t *testing.T) {
+	testCases := []struct {
+		grains                int
+		threshold             int
+		toppledNeighborsCount int
+		expectedGrains        int
+	}{
+		{3, 4, 0, 3}, {4, 4, 0, 0}, {5, 4, 2, 3}, {3, 4, 4, 7}, {9, 8, 1, 2},
+	}
+	for _, testCase := range testCases {
+		if grains := GetSandpileNextGrains(testCase.grains, testCase.threshold, testCase.toppledNeighborsCount); grains != testCase.expectedGrains {
+			t.Fatalf("%v grains with threshold %v and %v toppled neighbors should become %v, but got %v.", testCase.grains, testCase.threshold, testCase.toppledNeighborsCount, testCase.expectedGrains, grains)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetSandpileNextGrains(t *testing.T) {
+	testGetSandpileNextGrainsCaseOne(t)
+}
+
+func testGetSandpileIdentityCaseOne(t *testing.T) {
+	testCases := []struct {
+		size             ggol.Size
+		expectedIdentity [][]int
+	}{
+		{ggol.Size{Width: 1, Height: 1}, [][]int{{0}}},
+		{ggol.Size{Width: 2, Height: 2}, [][]int{{2, 2}, {2, 2}}},
+		{ggol.Size{Width: 3, Height: 3}, [][]int{{2, 1, 2}, {1, 0, 1}, {2, 1, 2}}},
+	}
+	for _, testCase := range testCases {
+		identity, err := GetSandpileIdentity(&testCase.size)
+		if err != nil {
+			t.Fatalf("Should get identity of %v, but got error: %v.", testCase.size, err)
+		}
+		for x := range testCase.expectedIdentity {
+			for y := range testCase.expectedIdentity[x] {
+				if (*identity)[x][y].Grains != testCase.expectedIdentity[x][y] {
+					t.Fatalf("Identity of %v should be %v, but got %v.", testCase.size, testCase.expectedIdentity, *identity)
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testGetSandpileIdentityCaseTwo(t *testing.T) {
+	// Adding the identity to itself or to a recurrent configuration like all 3 grains changes nothing.
+	for _, size := range []ggol.Size{{Width: 16, Height: 16}, {Width: 9, Height: 5}} {
+		identity, _ := GetSandpileIdentity(&size)
+		if !areSandpilesEqualForTest(addSandpilesForTest(identity, identity), identity) {
+			t.Fatalf("Identity of %v plus itself should be itself.", size)
+		}
+		maxStableUnits := generateSandpileUnitsForTest(size.Width, size.Height, 3)
+		if !areSandpilesEqualForTest(addSandpilesForTest(maxStableUnits, identity), maxStableUnits) {
+			t.Fatalf("All 3 grains plus the identity of %v should be all 3 grains.", size)
+		}
+		// The identity is symmetric.
+		for x := range *identity {
+			for y := range (*identity)[x] {
+				if (*identity)[x][y] != (*identity)[size.Width-1-x][size.Height-1-y] {
+					t.Fatalf("Identity of %v should be symmetric, but got %v.", size, *identity)
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetSandpileIdentity(t *testing.T) {
+	testGetSandpileIdentityCaseOne(t)
+	testGetSandpileIdentityCaseTwo(t)
+}
+
+func testNewSandpileNextUnitGeneratorCaseOne(t *testing.T) {
+	// 4 grains topple once into a plus sign.
+	g := ggol.NewInfiniteGame(SandpileUnit{})
+	g.SetNextUnitGenerator(NewSandpileNextUnitGenerator())
+	g.SetUnit(&ggol.Coordinate{X: 0, Y: 0}, &SandpileUnit{Grains: 4})
+	report, err := g.GenerateNextUnitsUntilStable(10, ggol.AreUnitsEqual[SandpileUnit])
+	expectedArea := ggol.Area{From: ggol.Coordinate{X: -1, Y: -1}, To: ggol.Coordinate{X: 1, Y: 1}}
+	if err != nil || report.GenerationsCount != 1 || report.ChangedUnitsCount != 5 || *report.ChangedArea != expectedArea {
+		t.Fatalf("Avalanche should change 5 units in 1 generation within %v, but got %v and error: %v.", expectedArea, report, err)
+	}
+	t.Log("Passed")
+}
+
+func testNewSandpileNextUnitGeneratorCaseTwo(t *testing.T) {
+	// Grains are kept without border, and the pile is symmetric.
+	g := ggol.NewInfiniteGame(SandpileUnit{})
+	g.SetNextUnitGenerator(NewSandpileNextUnitGenerator())
+	g.SetUnit(&ggol.Coordinate{X: 0, Y: 0}, &SandpileUnit{Grains: 1000})
+	report, err := g.GenerateNextUnitsUntilStable(10000, ggol.AreUnitsEqual[SandpileUnit])
+	if err != nil {
+		t.Fatalf("Pile should be stable, but got error: %v.", err)
+	}
+	if report.ChangedArea.From.X != -report.ChangedArea.To.X || report.ChangedArea.From.Y != report.ChangedArea.From.X {
+		t.Fatalf("Avalanche should be symmetric, but got %v.", report.ChangedArea)
+	}
+	grainsCount := 0
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *SandpileUnit) {
+		if unit.Grains >= 4 {
+			t.Fatalf("Unit at %v should be stable, but got %v grains.", coord, unit.Grains)
+		}
+		mirroredUnit, _ := g.GetUnit(&ggol.Coordinate{X: coord.Y, Y: -coord.X})
+		if *mirroredUnit != *unit {
+			t.Fatalf("Units should be symmetric, but %v and its rotation are different.", coord)
+		}
+		grainsCount += unit.Grains
+	})
+	if grainsCount != 1000 {
+		t.Fatalf("Should keep 1000 grains, but got %v.", grainsCount)
+	}
+	t.Log("Passed")
+}
+
+func testNewSandpileNextUnitGeneratorCaseThree(t *testing.T) {
+	// The neighborhood generator topples units like the other one.
+	units := generateSandpileUnitsForTest(7, 7, 0)
+	(*units)[3][3].Grains = 40
+	expectedUnits, _ := stabilizeSandpile(units)
+
+	g, _ := ggol.NewGame(generateSandpileUnitsForTest(7, 7, 0))
+	g.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	g.SetNeighborhoodNextUnitGenerator(GetSandpileNeighborhood(), NewSandpileNeighborhoodNextUnitGenerator())
+	g.SetUnit(&ggol.Coordinate{X: 3, Y: 3}, &SandpileUnit{Grains: 40})
+	if _, err := g.GenerateNextUnitsUntilStable(math.MaxInt, ggol.AreUnitsEqual[SandpileUnit]); err != nil {
+		t.Fatalf("Pile should be stable, but got error: %v.", err)
+	}
+	if !areSandpilesEqualForTest(g.GetUnits(), expectedUnits) {
+		t.Fatalf("Units should be %v, but got %v.", *expectedUnits, *g.GetUnits())
+	}
+	t.Log("Passed")
+}
+
+func testNewSandpileNextUnitGeneratorCaseFour(t *testing.T) {
+	// 8 grains topple into all 8 neighbors in Moore neighborhood.
+	mooreNeighborhood, _ := ggol.NewMooreNeighborhood(1)
+	g, _ := ggol.NewGame(generateSandpileUnitsForTest(3, 3, 0))
+	g.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	g.SetNeighborhoodNextUnitGenerator(mooreNeighborhood, NewSandpileNeighborhoodNextUnitGenerator())
+	g.SetUnit(&ggol.Coordinate{X: 1, Y: 1}, &SandpileUnit{Grains: 8})
+	g.GenerateNextUnitsUntilStable(10, ggol.AreUnitsEqual[SandpileUnit])
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *SandpileUnit) {
+		expectedGrains := 1
+		if coord.X == 1 && coord.Y == 1 {
+			expectedGrains = 0
+		}
+		if unit.Grains != expectedGrains {
+			t.Fatalf("Unit at %v should have %v grains, but got %v.", coord, expectedGrains, unit.Grains)
+		}
+	})
+	t.Log("Passed")
+}
+
+func testNewSandpileNextUnitGeneratorCaseFive(t *testing.T) {
+	g, _ := ggol.NewGame(generateSandpileUnitsForTest(32, 32, 3))
+	g.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	g.SetNextUnitGenerator(NewSandpileNextUnitGenerator())
+	g.SetUnit(&ggol.Coordinate{X: 16, Y: 16}, &SandpileUnit{Grains: 4})
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestNewSandpileNextUnitGenerator(t *testing.T) {
+	testNewSandpileNextUnitGeneratorCaseOne(t)
+	testNewSandpileNextUnitGeneratorCaseTwo(t)
+	testNewSandpileNextUnitGeneratorCaseThree(t)
+	testNewSandpileNextUnitGeneratorCaseFour(t)
+	testNewSandpileNextUnitGeneratorCaseFive(t)
+}
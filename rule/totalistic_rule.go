@@ -0,0 +1,125 @@
+package rule
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// TotalisticRule is a one-dimensional rule with k colors, the next color of a unit only depends on
+// the sum of colors of itself and the units within the radius on its left and right.
+type TotalisticRule struct {
+	code        int
+	colorsCount int
+	radius      int
+	// Indexed by the sum of colors.
+	nextColors []int
+	// Units of every color, generators return them so they don't allocate.
+	units []ColorUnit
+}
+
+// Parse the totalistic code into a TotalisticRule, the rulestring looks like "T777,K3,R1".
+//   - "T" is the Wolfram code, digit i of it in base k is the next color when the sum of colors is i.
+//   - "K" is the count of colors. Default is 2.
+//   - "R" is the radius, a unit looks at "2 * R + 1" units including itself. Default is 1.
+func NewTotalisticRule(rulestring string) (*TotalisticRule, error) {
+	rule := TotalisticRule{colorsCount: 2, radius: 1}
+	parsedKeys := make(map[byte]bool)
+	for _, token := range strings.Split(strings.ToUpper(strings.ReplaceAll(rulestring, " ", "")), ",") {
+		if token == "" || parsedKeys[token[0]] {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		parsedKeys[token[0]] = true
+
+		value, err := strconv.Atoi(token[1:])
+		if err != nil {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		switch token[0] {
+		case 'T':
+			rule.code = value
+		case 'K':
+			rule.colorsCount = value
+		case 'R':
+			rule.radius = value
+		default:
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+	}
+	if !parsedKeys['T'] {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+	if rule.colorsCount < 2 {
+		return nil, &ErrStatesCountIsInvalid{Rule: rulestring, StatesCount: rule.colorsCount}
+	}
+	if rule.radius < 1 {
+		return nil, &ggol.ErrRadiusIsInvalid{Radius: rule.radius}
+	}
+
+	sumsCount := (rule.colorsCount-1)*(2*rule.radius+1) + 1
+	maxCode := 1
+	for i := 0; i < sumsCount; i++ {
+		if maxCode > math.MaxInt/rule.colorsCount {
+			maxCode = math.MaxInt
+			break
+		}
+		maxCode *= rule.colorsCount
+	}
+	if maxCode != math.MaxInt {
+		maxCode -= 1
+	}
+	if rule.code < 0 || rule.code > maxCode {
+		return nil, &ErrRuleNumberIsInvalid{Rule: rulestring, Number: rule.code, MaxNumber: maxCode}
+	}
+
+	rule.nextColors = make([]int, sumsCount)
+	code := rule.code
+	for sum := range rule.nextColors {
+		rule.nextColors[sum] = code % rule.colorsCount
+		code /= rule.colorsCount
+	}
+	rule.units = make([]ColorUnit, rule.colorsCount)
+	for color := range rule.units {
+		rule.units[color].Color = color
+	}
+	return &rule, nil
+}
+
+// Get the canonical rulestring of the rule, you can parse it with NewTotalisticRule again.
+func (r *TotalisticRule) String() string {
+	return fmt.Sprintf("T%v,K%v,R%v", r.code, r.colorsCount, r.radius)
+}
+
+// Get the count of colors.
+func (r *TotalisticRule) GetColorsCount() int {
+	return r.colorsCount
+}
+
+// Get the radius, a unit looks at "2 * radius + 1" units including itself.
+func (r *TotalisticRule) GetRadius() int {
+	return r.radius
+}
+
+// Get the next color of the unit with the sum of colors of itself and the units within the radius.
+func (r *TotalisticRule) GetNextColor(colorsSum int) int {
+	if colorsSum < 0 || colorsSum >= len(r.nextColors) {
+		return 0
+	}
+	return r.nextColors[colorsSum]
+}
+
+// Generate a NextUnit1DGenerator of the rule for ColorUnit.
+func NewTotalisticNextUnitGenerator(rule *TotalisticRule) ggol.NextUnit1DGenerator[ColorUnit] {
+	return func(x int, unit *ColorUnit, getAdjacentUnit ggol.AdjacentUnit1DGetter[ColorUnit]) *ColorUnit {
+		colorsSum := unit.Color
+		for relativeX := 1; relativeX <= rule.radius; relativeX++ {
+			leftUnit, _ := getAdjacentUnit(x, -relativeX)
+			rightUnit, _ := getAdjacentUnit(x, relativeX)
+			colorsSum += leftUnit.Color + rightUnit.Color
+		}
+		return &rule.units[rule.GetNextColor(colorsSum)]
+	}
+}
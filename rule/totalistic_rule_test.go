@@ -0,0 +1,136 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func testNewTotalisticRuleCaseOne(t *testing.T) {
+	testCases := map[string]string{
+		"T777,K3,R1":  "T777,K3,R1",
+		"t777, k3":    "T777,K3,R1",
+		"K3,T777":     "T777,K3,R1",
+		"T10":         "T10,K2,R1",
+		"T1599,K3,R1": "T1599,K3,R1",
+		"T0,K4,R2":    "T0,K4,R2",
+	}
+	for rulestring, expectedRulestring := range testCases {
+		rule, err := NewTotalisticRule(rulestring)
+		if err != nil {
+			t.Fatalf("Should parse \"%v\", but got error: %v.", rulestring, err)
+		}
+		if rule.String() != expectedRulestring {
+			t.Fatalf("\"%v\" should be parsed as \"%v\", but got \"%v\".", rulestring, expectedRulestring, rule.String())
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewTotalisticRuleCaseTwo(t *testing.T) {
+	for _, rulestring := range []string{"", "K3", "T1,T2", "Tx", "T1,X2", "T1,,K2"} {
+		_, err := NewTotalisticRule(rulestring)
+		if _, ok := err.(*ErrRuleIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	if _, err := NewTotalisticRule("T1,K1"); err == nil {
+		t.Fatalf("Should get error when count of colors is less than 2.")
+	} else if _, ok := err.(*ErrStatesCountIsInvalid); !ok {
+		t.Fatalf("Should get ErrStatesCountIsInvalid, but got %v.", err)
+	}
+	if _, err := NewTotalisticRule("T1,R0"); err == nil {
+		t.Fatalf("Should get error when radius is less than 1.")
+	} else if _, ok := err.(*ggol.ErrRadiusIsInvalid); !ok {
+		t.Fatalf("Should get ErrRadiusIsInvalid, but got %v.", err)
+	}
+	// 3 colors with radius 1 have 7 sums, so the code is less than 3 ^ 7.
+	for _, rulestring := range []string{"T2187,K3", "T-1,K3", "T16"} {
+		if _, err := NewTotalisticRule(rulestring); err == nil {
+			t.Fatalf("Should get error when the code of \"%v\" is out of range.", rulestring)
+		} else if _, ok := err.(*ErrRuleNumberIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleNumberIsInvalid, but got %v.", err)
+		}
+	}
+	if _, err := NewTotalisticRule("T2186,K3"); err != nil {
+		t.Fatalf("Should parse the largest code, but got error: %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestNewTotalisticRule(t *testing.T) {
+	testNewTotalisticRuleCaseOne(t)
+	testNewTotalisticRuleCaseTwo(t)
+}
+
+func testTotalisticRuleGetNextColorCaseOne(t *testing.T) {
+	// 777 is 1001210 in base 3, the last digit is for sum 0.
+	rule, _ := NewTotalisticRule("T777,K3,R1")
+	expectedNextColors := []int{0, 1, 2, 1, 0, 0, 1}
+	for colorsSum, expectedNextColor := range expectedNextColors {
+		if nextColor := rule.GetNextColor(colorsSum); nextColor != expectedNextColor {
+			t.Fatalf("Next color of sum %v should be %v, but got %v.", colorsSum, expectedNextColor, nextColor)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestTotalisticRuleGetNextColor(t *testing.T) {
+	testTotalisticRuleGetNextColorCaseOne(t)
+}
+
+func testNewTotalisticNextUnitGeneratorCaseOne(t *testing.T) {
+	// Code 10 is 1010 in binary, a unit is alive with 1 or 3 live units, which is Rule 150.
+	totalisticRule, _ := NewTotalisticRule("T10,K2,R1")
+	elementaryRule, _ := NewElementaryRule("W150")
+	totalisticGame := ggol.NewGame1D(generateColorUnitsForTest(31))
+	totalisticGame.SetNextUnitGenerator(NewTotalisticNextUnitGenerator(totalisticRule))
+	elementaryGame := ggol.NewGame1D(generateColorUnitsForTest(31))
+	elementaryGame.SetNextUnitGenerator(NewElementaryNextUnitGenerator(elementaryRule))
+
+	for _, g := range []ggol.Game1D[ColorUnit]{totalisticGame, elementaryGame} {
+		g.SetUnit(15, &ColorUnit{Color: 1})
+		g.SetUnit(4, &ColorUnit{Color: 1})
+	}
+	for i := 0; i < 30; i++ {
+		totalisticUnits := *totalisticGame.GenerateNextUnits()
+		elementaryUnits := *elementaryGame.GenerateNextUnits()
+		for x := range totalisticUnits {
+			if totalisticUnits[x] != elementaryUnits[x] {
+				t.Fatalf("Generation %v: unit at %v is not generated like Rule 150.", i+1, x)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewTotalisticNextUnitGeneratorCaseTwo(t *testing.T) {
+	rule, _ := NewTotalisticRule("T777,K3,R1")
+	g := ggol.NewGame1D(generateColorUnitsForTest(9))
+	g.SetNextUnitGenerator(NewTotalisticNextUnitGenerator(rule))
+	g.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	g.SetUnit(4, &ColorUnit{Color: 2})
+	g.GenerateNextUnits()
+
+	// Units around the center have sum 2, others have sum 0.
+	expectedColors := []int{0, 0, 0, 2, 2, 2, 0, 0, 0}
+	g.IterateUnits(func(x int, unit *ColorUnit) {
+		if unit.Color != expectedColors[x] {
+			t.Fatalf("Unit at %v should be color %v, but got %v.", x, expectedColors[x], unit.Color)
+		}
+	})
+
+	g.SetHistoryLength(8)
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestNewTotalisticNextUnitGenerator(t *testing.T) {
+	testNewTotalisticNextUnitGeneratorCaseOne(t)
+	testNewTotalisticNextUnitGeneratorCaseTwo(t)
+}
@@ -0,0 +1,178 @@
+package rule
+
+import (
+	"strings"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+// Rulestrings of some well-known turmites, every letter tells how an ant turns on a color.
+const (
+	RuleLangtonsAnt      string = "RL"
+	RuleSymmetricTurmite string = "LLRR"
+	RuleChaoticTurmite   string = "RLR"
+	RuleSquareTurmite    string = "LRRRRRLLR"
+)
+
+// AntHeading is the direction an ant is facing, Y grows downward so north is (0, -1).
+type AntHeading int
+
+const (
+	AntHeadingNorth AntHeading = iota
+	AntHeadingEast
+	AntHeadingSouth
+	AntHeadingWest
+)
+
+// Relative coordinates of the unit in front of an ant, indexed by AntHeading.
+var antHeadingRelativeCoords []ggol.Coordinate = []ggol.Coordinate{
+	{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0},
+}
+
+// AntUnit is the unit of turmites like Langton's Ant, it has a color and the ants standing on it.
+//
+// Ants never collide, several ants can stand on the same unit. In every generation, all ants on a unit turn
+// by the color of the unit, the color changes to the next one only once no matter how many ants are there,
+// then every ant moves forward by one unit. Ants with the same heading on the same unit move together from then on.
+type AntUnit struct {
+	// The color of the unit, from 0 to "colorsCount - 1" of the rule.
+	Color int
+	// Count of ants standing on the unit, indexed by their AntHeading.
+	Ants [4]int
+}
+
+// Tell you whether any ant is standing on the unit.
+func (u *AntUnit) HasAnts() bool {
+	return u.Ants[AntHeadingNorth] > 0 || u.Ants[AntHeadingEast] > 0 || u.Ants[AntHeadingSouth] > 0 || u.Ants[AntHeadingWest] > 0
+}
+
+// TurmiteRule tells how ants turn on every color, Langton's Ant is the turmite "RL".
+type TurmiteRule struct {
+	// Quarter turns clockwise on every color.
+	turns        []int
+	neighborhood *ggol.Neighborhood
+	// Units with at most one ant in every heading, units[color * 16 + mask] has an ant in heading h when bit h of mask is 1,
+	// so generators hand them back without allocating.
+	units []AntUnit
+}
+
+// Parse the rulestring into a TurmiteRule, letter i tells how an ant turns on color i:
+// "R" turns right, "L" turns left, "N" doesn't turn and "U" turns around, e.g. "RL" or "LLRR".
+func NewTurmiteRule(rulestring string) (*TurmiteRule, error) {
+	letters := strings.ToUpper(strings.ReplaceAll(rulestring, " ", ""))
+	if letters == "" {
+		return nil, &ErrRuleIsInvalid{rulestring}
+	}
+	turns := make([]int, len(letters))
+	for i, letter := range letters {
+		turn := strings.IndexRune("NRUL", letter)
+		if turn == -1 {
+			return nil, &ErrRuleIsInvalid{rulestring}
+		}
+		turns[i] = turn
+	}
+
+	// Neighbors are in the order of AntHeading, so neighbor i is the unit in direction i.
+	neighborhood, _ := ggol.NewCustomNeighborhood(antHeadingRelativeCoords)
+	units := make([]AntUnit, len(turns)*16)
+	for i := range units {
+		units[i].Color = i / 16
+		for heading := range units[i].Ants {
+			units[i].Ants[heading] = (i >> heading) & 1
+		}
+	}
+	return &TurmiteRule{turns: turns, neighborhood: neighborhood, units: units}, nil
+}
+
+// Get the canonical rulestring of the rule, you can parse it with NewTurmiteRule again.
+func (r *TurmiteRule) String() string {
+	var builder strings.Builder
+	for _, turn := range r.turns {
+		builder.WriteByte("NRUL"[turn])
+	}
+	return builder.String()
+}
+
+// Get the neighborhood of the rule, the 4 neighbors are in the order of AntHeading: north, east, south, west.
+func (r *TurmiteRule) GetNeighborhood() *ggol.Neighborhood {
+	return r.neighborhood
+}
+
+// Get the count of colors, it's the length of the rulestring.
+func (r *TurmiteRule) GetColorsCount() int {
+	return len(r.turns)
+}
+
+// Get the heading of an ant after it turns on the color.
+func (r *TurmiteRule) GetNextHeading(heading AntHeading, color int) AntHeading {
+	return AntHeading((int(heading) + r.turns[color]) % 4)
+}
+
+// Get the next unit with its 4 neighbors in the order of AntHeading. Ants are pulled from neighbors,
+// an ant on the neighbor in direction d comes here when it heads to the opposite direction after turning.
+func (r *TurmiteRule) generateNextUnit(unit *AntUnit, neighbors *[4]*AntUnit) *AntUnit {
+	var nextAnts [4]int
+	hasIncomingAnts := false
+	for direction, neighbor := range neighbors {
+		// The unit is its own neighbor on the edge with BoundaryPolicyClamp and BoundaryPolicyMirror, ants heading there
+		// hit the border and stay, and ants heading away are pulled by the unit they walk into, not by this unit.
+		if neighbor == unit {
+			for heading, antsCount := range unit.Ants {
+				if antsCount > 0 && r.GetNextHeading(AntHeading(heading), unit.Color) == AntHeading(direction) {
+					nextAnts[direction] += antsCount
+					hasIncomingAnts = true
+				}
+			}
+			continue
+		}
+		for heading, antsCount := range neighbor.Ants {
+			if antsCount > 0 && r.GetNextHeading(AntHeading(heading), neighbor.Color) == AntHeading((direction+2)%4) {
+				nextAnts[(direction+2)%4] += antsCount
+				hasIncomingAnts = true
+			}
+		}
+	}
+	if !hasIncomingAnts && !unit.HasAnts() {
+		return unit
+	}
+
+	nextColor := unit.Color
+	if unit.HasAnts() {
+		nextColor = (unit.Color + 1) % len(r.turns)
+	}
+	mask := 0
+	for heading, antsCount := range nextAnts {
+		if antsCount > 1 {
+			// Ants moving together are rare, so only their units are allocated.
+			return &AntUnit{Color: nextColor, Ants: nextAnts}
+		}
+		mask |= antsCount << heading
+	}
+	return &r.units[nextColor*16+mask]
+}
+
+// Generate a NextUnitGenerator of the rule for AntUnit.
+//
+// Ants walking across the border follow the BoundaryPolicy of the game, they come back from the other side
+// with BoundaryPolicyWrap, BoundaryPolicyKleinBottle and BoundaryPolicyProjectivePlane, they stay on the edge with
+// BoundaryPolicyClamp and BoundaryPolicyMirror, and they are gone with BoundaryPolicyConstant if the boundary unit has no ants.
+// Ants crossing the twisted edge of BoundaryPolicyKleinBottle or BoundaryPolicyProjectivePlane keep their heading and
+// their chirality, they aren't mirrored, so they still turn right when the rule says "R".
+//
+// Units with at most one ant in every heading are shared by the rule, so don't change units the generator gives you.
+func NewTurmiteNextUnitGenerator(rule *TurmiteRule) ggol.NextUnitGenerator[AntUnit] {
+	return func(coord *ggol.Coordinate, unit *AntUnit, getAdjacentUnit ggol.AdjacentUnitGetter[AntUnit]) *AntUnit {
+		var neighbors [4]*AntUnit
+		for i := range antHeadingRelativeCoords {
+			neighbors[i], _ = getAdjacentUnit(coord, &antHeadingRelativeCoords[i])
+		}
+		return rule.generateNextUnit(unit, &neighbors)
+	}
+}
+
+// Generate a NeighborhoodNextUnitGenerator of the rule for AntUnit, set it with the neighborhood of the rule.
+func NewTurmiteNeighborhoodNextUnitGenerator(rule *TurmiteRule) ggol.NeighborhoodNextUnitGenerator[AntUnit] {
+	return func(coord *ggol.Coordinate, unit *AntUnit, neighbors []*AntUnit) *AntUnit {
+		return rule.generateNextUnit(unit, (*[4]*AntUnit)(neighbors))
+	}
+}
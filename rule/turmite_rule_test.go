@@ -0,0 +1,354 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateAntUnitsForTest(width int, height int) *[][]AntUnit {
+	units := make([][]AntUnit, width)
+	for x := 0; x < width; x += 1 {
+		units[x] = make([]AntUnit, height)
+	}
+	return &units
+}
+
+type antForTest struct {
+	coord   ggol.Coordinate
+	heading AntHeading
+}
+
+// Move ants one by one, so we can check the game with it.
+func moveAntsForTest(rule *TurmiteRule, colors [][]int, ants []antForTest) {
+	width := len(colors)
+	height := len(colors[0])
+	hasAnts := make(map[ggol.Coordinate]bool)
+	for i, ant := range ants {
+		hasAnts[ant.coord] = true
+		ants[i].heading = rule.GetNextHeading(ant.heading, colors[ant.coord.X][ant.coord.Y])
+		relativeCoord := antHeadingRelativeCoords[ants[i].heading]
+		ants[i].coord.X = (ant.coord.X + relativeCoord.X + width) % width
+		ants[i].coord.Y = (ant.coord.Y + relativeCoord.Y + height) % height
+	}
+	for coord := range hasAnts {
+		colors[coord.X][coord.Y] = (colors[coord.X][coord.Y] + 1) % rule.GetColorsCount()
+	}
+}
+
+func testNewTurmiteRuleCaseOne(t *testing.T) {
+	testCases := map[string]string{
+		RuleLangtonsAnt:    "RL",
+		"llrr":             "LLRR",
+		"R L N U":          "RLNU",
+		RuleSquareTurmite:  "LRRRRRLLR",
+		RuleChaoticTurmite: "RLR",
+		"N":                "N",
+	}
+	for rulestring, expectedRulestring := range testCases {
+		rule, err := NewTurmiteRule(rulestring)
+		if err != nil {
+			t.Fatalf("Should parse \"%v\", but got error: %v.", rulestring, err)
+		}
+		if rule.String() != expectedRulestring {
+			t.Fatalf("\"%v\" should be parsed as \"%v\", but got \"%v\".", rulestring, expectedRulestring, rule.String())
+		}
+		if rule.GetColorsCount() != len(expectedRulestring) {
+			t.Fatalf("\"%v\" should have %v colors, but got %v.", rulestring, len(expectedRulestring), rule.GetColorsCount())
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewTurmiteRuleCaseTwo(t *testing.T) {
+	for _, rulestring := range []string{"", " ", "RLX", "B3/S23", "R1"} {
+		_, err := NewTurmiteRule(rulestring)
+		if _, ok := err.(*ErrRuleIsInvalid); !ok {
+			t.Fatalf("Should get ErrRuleIsInvalid when parsing \"%v\", but got %v.", rulestring, err)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestNewTurmiteRule(t *testing.T) {
+	testNewTurmiteRuleCaseOne(t)
+	testNewTurmiteRuleCaseTwo(t)
+}
+
+func testGetNextHeadingCaseOne(t *testing.T) {
+	rule, _ := NewTurmiteRule("RLNU")
+	testCases := []struct {
+		heading         AntHeading
+		color           int
+		expectedHeading AntHeading
+	}{
+		{AntHeadingNorth, 0, AntHeadingEast}, {AntHeadingWest, 0, AntHeadingNorth},
+		{AntHeadingNorth, 1, AntHeadingWest}, {AntHeadingEast, 1, AntHeadingNorth},
+		{AntHeadingSouth, 2, AntHeadingSouth},
+		{AntHeadingNorth, 3, AntHeadingSouth}, {AntHeadingWest, 3, AntHeadingEast},
+	}
+	for _, testCase := range testCases {
+		if nextHeading := rule.GetNextHeading(testCase.heading, testCase.color); nextHeading != testCase.expectedHeading {
+			t.Fatalf("Heading %v on color %v should become %v, but got %v.", testCase.heading, testCase.color, testCase.expectedHeading, nextHeading)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetNextHeading(t *testing.T) {
+	testGetNextHeadingCaseOne(t)
+}
+
+func testNewTurmiteNextUnitGeneratorCaseOne(t *testing.T) {
+	// Langton's Ant turns right on color 0, and turns left on color 1.
+	rule, _ := NewTurmiteRule(RuleLangtonsAnt)
+	g, _ := ggol.NewGame(generateAntUnitsForTest(10, 10))
+	g.SetNextUnitGenerator(NewTurmiteNextUnitGenerator(rule))
+	g.SetUnit(&ggol.Coordinate{X: 5, Y: 5}, &AntUnit{Ants: [4]int{AntHeadingNorth: 1}})
+
+	expectedAnts := []antForTest{
+		{ggol.Coordinate{X: 6, Y: 5}, AntHeadingEast},
+		{ggol.Coordinate{X: 6, Y: 6}, AntHeadingSouth},
+		{ggol.Coordinate{X: 5, Y: 6}, AntHeadingWest},
+		{ggol.Coordinate{X: 5, Y: 5}, AntHeadingNorth},
+		{ggol.Coordinate{X: 4, Y: 5}, AntHeadingWest},
+	}
+	for i, expectedAnt := range expectedAnts {
+		g.GenerateNextUnits()
+		g.IterateUnits(func(coord *ggol.Coordinate, unit *AntUnit) {
+			expectedAntsCount := 0
+			if *coord == expectedAnt.coord {
+				expectedAntsCount = 1
+			}
+			if unit.Ants[expectedAnt.heading] != expectedAntsCount {
+				t.Fatalf("Step %v: ant should be at %v heading %v, but unit at %v has ants %v.", i+1, expectedAnt.coord, expectedAnt.heading, coord, unit.Ants)
+			}
+		})
+	}
+	if unit, _ := g.GetUnit(&ggol.Coordinate{X: 5, Y: 5}); unit.Color != 0 {
+		t.Fatalf("The first unit should be flipped twice back to color 0, but got %v.", unit.Color)
+	}
+	if unit, _ := g.GetUnit(&ggol.Coordinate{X: 6, Y: 6}); unit.Color != 1 {
+		t.Fatalf("Unit at (6, 6) should be flipped to color 1, but got %v.", unit.Color)
+	}
+	t.Log("Passed")
+}
+
+func testNewTurmiteNextUnitGeneratorCaseTwo(t *testing.T) {
+	// Multiple ants, two of them start on the same unit with the same heading, and others cross their paths.
+	for _, rulestring := range []string{RuleLangtonsAnt, RuleSymmetricTurmite, RuleChaoticTurmite} {
+		rule, _ := NewTurmiteRule(rulestring)
+		getterGame, _ := ggol.NewGame(generateAntUnitsForTest(16, 12))
+		getterGame.SetNextUnitGenerator(NewTurmiteNextUnitGenerator(rule))
+		neighborhoodGame, _ := ggol.NewGame(generateAntUnitsForTest(16, 12))
+		neighborhoodGame.SetNeighborhoodNextUnitGenerator(rule.GetNeighborhood(), NewTurmiteNeighborhoodNextUnitGenerator(rule))
+
+		ants := []antForTest{
+			{ggol.Coordinate{X: 3, Y: 3}, AntHeadingNorth},
+			{ggol.Coordinate{X: 3, Y: 3}, AntHeadingNorth},
+			{ggol.Coordinate{X: 3, Y: 3}, AntHeadingSouth},
+			{ggol.Coordinate{X: 8, Y: 6}, AntHeadingEast},
+			{ggol.Coordinate{X: 12, Y: 2}, AntHeadingWest},
+		}
+		colors := make([][]int, 16)
+		for x := range colors {
+			colors[x] = make([]int, 12)
+		}
+		for _, g := range []ggol.Game[AntUnit]{getterGame, neighborhoodGame} {
+			for _, ant := range ants {
+				unit, _ := g.GetUnit(&ant.coord)
+				unit.Ants[ant.heading] += 1
+				g.SetUnit(&ant.coord, unit)
+			}
+		}
+
+		for i := 0; i < 300; i++ {
+			moveAntsForTest(rule, colors, ants)
+			expectedUnits := *generateAntUnitsForTest(16, 12)
+			for x := range colors {
+				for y := range colors[x] {
+					expectedUnits[x][y].Color = colors[x][y]
+				}
+			}
+			for _, ant := range ants {
+				expectedUnits[ant.coord.X][ant.coord.Y].Ants[ant.heading] += 1
+			}
+
+			for _, g := range []ggol.Game[AntUnit]{getterGame, neighborhoodGame} {
+				units := *g.GenerateNextUnits()
+				for x := range units {
+					for y := range units[x] {
+						if units[x][y] != expectedUnits[x][y] {
+							t.Fatalf("\"%v\" step %v: unit at (%v, %v) should be %v, but got %v.", rulestring, i+1, x, y, expectedUnits[x][y], units[x][y])
+						}
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewTurmiteNextUnitGeneratorCaseThree(t *testing.T) {
+	// Ants are gone when they walk across the border with BoundaryPolicyConstant.
+	rule, _ := NewTurmiteRule("N")
+	g, _ := ggol.NewGame(generateAntUnitsForTest(4, 4))
+	g.SetNextUnitGenerator(NewTurmiteNextUnitGenerator(rule))
+	g.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+	g.SetUnit(&ggol.Coordinate{X: 1, Y: 2}, &AntUnit{Ants: [4]int{AntHeadingWest: 1}})
+
+	g.GenerateNextUnits()
+	if unit, _ := g.GetUnit(&ggol.Coordinate{X: 0, Y: 2}); unit.Ants[AntHeadingWest] != 1 {
+		t.Fatalf("Ant should move to (0, 2).")
+	}
+	g.GenerateNextUnits()
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *AntUnit) {
+		if unit.HasAnts() {
+			t.Fatalf("Ant should be gone, but unit at %v has ants %v.", coord, unit.Ants)
+		}
+	})
+	t.Log("Passed")
+}
+
+func countAntsForTest(g ggol.Game[AntUnit]) int {
+	antsCount := 0
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *AntUnit) {
+		for _, count := range unit.Ants {
+			antsCount += count
+		}
+	})
+	return antsCount
+}
+
+func testNewTurmiteNextUnitGeneratorCaseFour(t *testing.T) {
+	// Ants are never lost or duplicated on the border with policies other than BoundaryPolicyConstant.
+	policies := []ggol.BoundaryPolicy{
+		ggol.BoundaryPolicyWrap, ggol.BoundaryPolicyClamp, ggol.BoundaryPolicyMirror,
+		ggol.BoundaryPolicyKleinBottle, ggol.BoundaryPolicyProjectivePlane,
+	}
+	for _, policy := range policies {
+		for _, rulestring := range []string{RuleLangtonsAnt, RuleChaoticTurmite, "N", "U"} {
+			rule, _ := NewTurmiteRule(rulestring)
+			getterGame, _ := ggol.NewGame(generateAntUnitsForTest(5, 4))
+			getterGame.SetNextUnitGenerator(NewTurmiteNextUnitGenerator(rule))
+			neighborhoodGame, _ := ggol.NewGame(generateAntUnitsForTest(5, 4))
+			neighborhoodGame.SetNeighborhoodNextUnitGenerator(rule.GetNeighborhood(), NewTurmiteNeighborhoodNextUnitGenerator(rule))
+
+			for _, g := range []ggol.Game[AntUnit]{getterGame, neighborhoodGame} {
+				g.SetBoundaryPolicy(policy)
+				g.SetUnit(&ggol.Coordinate{X: 0, Y: 0}, &AntUnit{Ants: [4]int{AntHeadingNorth: 2, AntHeadingWest: 1}})
+				g.SetUnit(&ggol.Coordinate{X: 4, Y: 1}, &AntUnit{Ants: [4]int{AntHeadingEast: 1}})
+				g.SetUnit(&ggol.Coordinate{X: 2, Y: 3}, &AntUnit{Ants: [4]int{AntHeadingSouth: 1, AntHeadingNorth: 1}})
+				for i := 0; i < 200; i++ {
+					g.GenerateNextUnits()
+					if antsCount := countAntsForTest(g); antsCount != 6 {
+						t.Fatalf("\"%v\" with boundary policy %v step %v: should have 6 ants, but got %v.", rulestring, policy, i+1, antsCount)
+					}
+				}
+			}
+			getterUnits, neighborhoodUnits := *getterGame.GetUnits(), *neighborhoodGame.GetUnits()
+			for x := range getterUnits {
+				for y := range getterUnits[x] {
+					if getterUnits[x][y] != neighborhoodUnits[x][y] {
+						t.Fatalf("\"%v\" with boundary policy %v: both generators should give the same unit at (%v, %v).", rulestring, policy, x, y)
+					}
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewTurmiteNextUnitGeneratorCaseFive(t *testing.T) {
+	// Ants hit the border and stay with BoundaryPolicyClamp and BoundaryPolicyMirror.
+	rule, _ := NewTurmiteRule("N")
+	for _, policy := range []ggol.BoundaryPolicy{ggol.BoundaryPolicyClamp, ggol.BoundaryPolicyMirror} {
+		g, _ := ggol.NewGame(generateAntUnitsForTest(4, 4))
+		g.SetNextUnitGenerator(NewTurmiteNextUnitGenerator(rule))
+		g.SetBoundaryPolicy(policy)
+		g.SetUnit(&ggol.Coordinate{X: 1, Y: 2}, &AntUnit{Ants: [4]int{AntHeadingWest: 1}})
+
+		for i := 0; i < 3; i++ {
+			g.GenerateNextUnits()
+		}
+		if unit, _ := g.GetUnit(&ggol.Coordinate{X: 0, Y: 2}); unit.Ants[AntHeadingWest] != 1 {
+			t.Fatalf("Ant should stay at (0, 2) with boundary policy %v, but got %v.", policy, unit.Ants)
+		}
+		if unit, _ := g.GetUnit(&ggol.Coordinate{X: 1, Y: 2}); unit.HasAnts() {
+			t.Fatalf("Ant should not come back to (1, 2) with boundary policy %v.", policy)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewTurmiteNextUnitGeneratorCaseSix(t *testing.T) {
+	// Ants crossing the twisted edge come back mirrored on the map, but keep their heading.
+	rule, _ := NewTurmiteRule("N")
+	crossings := []struct {
+		policy  ggol.BoundaryPolicy
+		from    ggol.Coordinate
+		to      ggol.Coordinate
+		heading AntHeading
+	}{
+		{ggol.BoundaryPolicyKleinBottle, ggol.Coordinate{X: 1, Y: 0}, ggol.Coordinate{X: 3, Y: 3}, AntHeadingNorth},
+		{ggol.BoundaryPolicyProjectivePlane, ggol.Coordinate{X: 0, Y: 1}, ggol.Coordinate{X: 4, Y: 2}, AntHeadingWest},
+	}
+	for _, crossing := range crossings {
+		g, _ := ggol.NewGame(generateAntUnitsForTest(5, 4))
+		g.SetNextUnitGenerator(NewTurmiteNextUnitGenerator(rule))
+		g.SetBoundaryPolicy(crossing.policy)
+		var ants [4]int
+		ants[crossing.heading] = 1
+		g.SetUnit(&crossing.from, &AntUnit{Ants: ants})
+
+		g.GenerateNextUnits()
+		if unit, _ := g.GetUnit(&crossing.to); unit.Ants != ants {
+			t.Fatalf("Ant should cross the twisted edge of boundary policy %v to %v with heading %v, but got %v.", crossing.policy, crossing.to, crossing.heading, unit.Ants)
+		}
+		if antsCount := countAntsForTest(g); antsCount != 1 {
+			t.Fatalf("Should have 1 ant after crossing the twisted edge of boundary policy %v, but got %v.", crossing.policy, antsCount)
+		}
+	}
+
+	// Chirality is kept, an ant turning right on the other side still turns clockwise on the map.
+	rule, _ = NewTurmiteRule("RR")
+	g, _ := ggol.NewGame(generateAntUnitsForTest(5, 4))
+	g.SetNextUnitGenerator(NewTurmiteNextUnitGenerator(rule))
+	g.SetBoundaryPolicy(ggol.BoundaryPolicyKleinBottle)
+	// It turns right to the north on (1, 0), crosses the edge to (3, 3) and turns right to the east.
+	g.SetUnit(&ggol.Coordinate{X: 1, Y: 0}, &AntUnit{Ants: [4]int{AntHeadingWest: 1}})
+	g.GenerateNextUnits()
+	g.GenerateNextUnits()
+	if unit, _ := g.GetUnit(&ggol.Coordinate{X: 4, Y: 3}); unit.Ants[AntHeadingEast] != 1 {
+		t.Fatalf("Ant should turn right to the east after crossing the twisted edge, but got %v.", unit.Ants)
+	}
+	t.Log("Passed")
+}
+
+func testNewTurmiteNextUnitGeneratorCaseSeven(t *testing.T) {
+	// Units with at most one ant in every heading are prebuilt, so generating doesn't allocate.
+	rule, _ := NewTurmiteRule(RuleChaoticTurmite)
+	g, _ := ggol.NewGame(generateAntUnitsForTest(32, 32))
+	g.SetNeighborhoodNextUnitGenerator(rule.GetNeighborhood(), NewTurmiteNeighborhoodNextUnitGenerator(rule))
+	g.SetUnit(&ggol.Coordinate{X: 8, Y: 8}, &AntUnit{Ants: [4]int{AntHeadingNorth: 1}})
+	g.SetUnit(&ggol.Coordinate{X: 20, Y: 12}, &AntUnit{Ants: [4]int{AntHeadingEast: 1}})
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestNewTurmiteNextUnitGenerator(t *testing.T) {
+	testNewTurmiteNextUnitGeneratorCaseOne(t)
+	testNewTurmiteNextUnitGeneratorCaseTwo(t)
+	testNewTurmiteNextUnitGeneratorCaseThree(t)
+	testNewTurmiteNextUnitGeneratorCaseFour(t)
+	testNewTurmiteNextUnitGeneratorCaseFive(t)
+	testNewTurmiteNextUnitGeneratorCaseSix(t)
+	testNewTurmiteNextUnitGeneratorCaseSeven(t)
+}
@@ -0,0 +1,83 @@
+package rule
+
+import (
+	"github.com/dum-dum-genius/ggol"
+)
+
+// WireworldState is the state of a Wireworld unit.
+type WireworldState int
+
+const (
+	// Nothing is here, it stays empty forever.
+	WireworldStateEmpty WireworldState = iota
+	// The head of an electron, it becomes a tail.
+	WireworldStateElectronHead
+	// The tail of an electron, it becomes a conductor.
+	WireworldStateElectronTail
+	// The wire, it becomes a head when 1 or 2 of its 8 neighbors are heads.
+	WireworldStateConductor
+)
+
+// WireworldUnit is the unit of Wireworld, electrons flow along conductors so you can build circuits with it.
+type WireworldUnit struct {
+	State WireworldState
+}
+
+// Units of every state, generators return them so they don't allocate.
+var wireworldUnits [4]WireworldUnit = [4]WireworldUnit{
+	{WireworldStateEmpty}, {WireworldStateElectronHead}, {WireworldStateElectronTail}, {WireworldStateConductor},
+}
+
+// Get the neighborhood of Wireworld, it's the Moore neighborhood of radius 1.
+func GetWireworldNeighborhood() *ggol.Neighborhood {
+	neighborhood, _ := ggol.NewMooreNeighborhood(1)
+	return neighborhood
+}
+
+// Get the next state of the unit with the count of electron heads around it.
+func GetWireworldNextState(state WireworldState, electronHeadsCount int) WireworldState {
+	switch state {
+	case WireworldStateElectronHead:
+		return WireworldStateElectronTail
+	case WireworldStateElectronTail:
+		return WireworldStateConductor
+	case WireworldStateConductor:
+		if electronHeadsCount == 1 || electronHeadsCount == 2 {
+			return WireworldStateElectronHead
+		}
+		return WireworldStateConductor
+	default:
+		return WireworldStateEmpty
+	}
+}
+
+// Generate a NextUnitGenerator of Wireworld.
+func NewWireworldNextUnitGenerator() ggol.NextUnitGenerator[WireworldUnit] {
+	relativeCoords := GetWireworldNeighborhood().GetRelativeCoordinates(&ggol.Coordinate{X: 0, Y: 0})
+	return func(coord *ggol.Coordinate, unit *WireworldUnit, getAdjacentUnit ggol.AdjacentUnitGetter[WireworldUnit]) *WireworldUnit {
+		if unit.State != WireworldStateConductor {
+			return &wireworldUnits[GetWireworldNextState(unit.State, 0)]
+		}
+		electronHeadsCount := 0
+		for i := range relativeCoords {
+			adjUnit, _ := getAdjacentUnit(coord, &relativeCoords[i])
+			if adjUnit.State == WireworldStateElectronHead {
+				electronHeadsCount += 1
+			}
+		}
+		return &wireworldUnits[GetWireworldNextState(unit.State, electronHeadsCount)]
+	}
+}
+
+// Generate a NeighborhoodNextUnitGenerator of Wireworld, set it with the neighborhood from GetWireworldNeighborhood.
+func NewWireworldNeighborhoodNextUnitGenerator() ggol.NeighborhoodNextUnitGenerator[WireworldUnit] {
+	return func(coord *ggol.Coordinate, unit *WireworldUnit, neighbors []*WireworldUnit) *WireworldUnit {
+		electronHeadsCount := 0
+		for _, neighbor := range neighbors {
+			if neighbor.State == WireworldStateElectronHead {
+				electronHeadsCount += 1
+			}
+		}
+		return &wireworldUnits[GetWireworldNextState(unit.State, electronHeadsCount)]
+	}
+}
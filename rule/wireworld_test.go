@@ -0,0 +1,148 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/dum-dum-genius/ggol"
+)
+
+func generateWireworldUnitsForTest(width int, height int) *[][]WireworldUnit {
+	units := make([][]WireworldUnit, width)
+	for x := 0; x < width; x += 1 {
+		units[x] = make([]WireworldUnit, height)
+	}
+	return &units
+}
+
+func testGetWireworldNextStateCaseOne(t *testing.T) {
+	testCases := []struct {
+		state              WireworldState
+		electronHeadsCount int
+		expectedState      WireworldState
+	}{
+		{WireworldStateEmpty, 0, WireworldStateEmpty}, {WireworldStateEmpty, 2, WireworldStateEmpty},
+		{WireworldStateElectronHead, 1, WireworldStateElectronTail},
+		{WireworldStateElectronTail, 1, WireworldStateConductor},
+		{WireworldStateConductor, 0, WireworldStateConductor}, {WireworldStateConductor, 1, WireworldStateElectronHead},
+		{WireworldStateConductor, 2, WireworldStateElectronHead}, {WireworldStateConductor, 3, WireworldStateConductor},
+	}
+	for _, testCase := range testCases {
+		if nextState := GetWireworldNextState(testCase.state, testCase.electronHeadsCount); nextState != testCase.expectedState {
+			t.Fatalf("State %v with %v electron heads should become %v, but got %v.", testCase.state, testCase.electronHeadsCount, testCase.expectedState, nextState)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestGetWireworldNextState(t *testing.T) {
+	testGetWireworldNextStateCaseOne(t)
+}
+
+func testNewWireworldNextUnitGeneratorCaseOne(t *testing.T) {
+	// An electron flows rightward along a wire that wraps around the map.
+	getterGame, _ := ggol.NewGame(generateWireworldUnitsForTest(8, 3))
+	getterGame.SetNextUnitGenerator(NewWireworldNextUnitGenerator())
+	neighborhoodGame, _ := ggol.NewGame(generateWireworldUnitsForTest(8, 3))
+	neighborhoodGame.SetNeighborhoodNextUnitGenerator(GetWireworldNeighborhood(), NewWireworldNeighborhoodNextUnitGenerator())
+
+	for _, g := range []ggol.Game[WireworldUnit]{getterGame, neighborhoodGame} {
+		for x := 0; x < 8; x++ {
+			g.SetUnit(&ggol.Coordinate{X: x, Y: 1}, &WireworldUnit{State: WireworldStateConductor})
+		}
+		g.SetUnit(&ggol.Coordinate{X: 1, Y: 1}, &WireworldUnit{State: WireworldStateElectronTail})
+		g.SetUnit(&ggol.Coordinate{X: 2, Y: 1}, &WireworldUnit{State: WireworldStateElectronHead})
+
+		for i := 1; i <= 8; i++ {
+			g.GenerateNextUnits()
+			headCoord := ggol.Coordinate{X: (2 + i) % 8, Y: 1}
+			tailCoord := ggol.Coordinate{X: (1 + i) % 8, Y: 1}
+			g.IterateUnits(func(coord *ggol.Coordinate, unit *WireworldUnit) {
+				expectedState := WireworldStateEmpty
+				switch {
+				case *coord == headCoord:
+					expectedState = WireworldStateElectronHead
+				case *coord == tailCoord:
+					expectedState = WireworldStateElectronTail
+				case coord.Y == 1:
+					expectedState = WireworldStateConductor
+				}
+				if unit.State != expectedState {
+					t.Fatalf("Generation %v: unit at %v should be in state %v, but got %v.", i, coord, expectedState, unit.State)
+				}
+			})
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewWireworldNextUnitGeneratorCaseTwo(t *testing.T) {
+	// A diode in the middle of a wire, electrons pass from left to right, but not from right to left.
+	diode := []string{
+		".##.",
+		"##.#",
+		".##.",
+	}
+	for _, isLeftward := range []bool{false, true} {
+		g, _ := ggol.NewGame(generateWireworldUnitsForTest(12, 3))
+		g.SetNextUnitGenerator(NewWireworldNextUnitGenerator())
+		g.SetBoundaryPolicy(ggol.BoundaryPolicyConstant)
+		for x := 0; x < 12; x++ {
+			g.SetUnit(&ggol.Coordinate{X: x, Y: 1}, &WireworldUnit{State: WireworldStateConductor})
+		}
+		for y, row := range diode {
+			for x, letter := range row {
+				state := WireworldStateEmpty
+				if letter == '#' {
+					state = WireworldStateConductor
+				}
+				g.SetUnit(&ggol.Coordinate{X: x + 4, Y: y}, &WireworldUnit{State: state})
+			}
+		}
+		if isLeftward {
+			g.SetUnit(&ggol.Coordinate{X: 11, Y: 1}, &WireworldUnit{State: WireworldStateElectronTail})
+			g.SetUnit(&ggol.Coordinate{X: 10, Y: 1}, &WireworldUnit{State: WireworldStateElectronHead})
+		} else {
+			g.SetUnit(&ggol.Coordinate{X: 0, Y: 1}, &WireworldUnit{State: WireworldStateElectronTail})
+			g.SetUnit(&ggol.Coordinate{X: 1, Y: 1}, &WireworldUnit{State: WireworldStateElectronHead})
+		}
+
+		hasPassed := false
+		for i := 0; i < 12; i++ {
+			g.GenerateNextUnits()
+			endCoord := ggol.Coordinate{X: 0, Y: 1}
+			if !isLeftward {
+				endCoord.X = 11
+			}
+			if unit, _ := g.GetUnit(&endCoord); unit.State == WireworldStateElectronHead {
+				hasPassed = true
+			}
+		}
+		if hasPassed == isLeftward {
+			t.Fatalf("Electron flowing leftward: %v, it should pass the diode: %v.", isLeftward, !isLeftward)
+		}
+	}
+	t.Log("Passed")
+}
+
+func testNewWireworldNextUnitGeneratorCaseThree(t *testing.T) {
+	g, _ := ggol.NewGame(generateWireworldUnitsForTest(32, 32))
+	g.SetNeighborhoodNextUnitGenerator(GetWireworldNeighborhood(), NewWireworldNeighborhoodNextUnitGenerator())
+	g.IterateUnits(func(coord *ggol.Coordinate, unit *WireworldUnit) {
+		unit.State = WireworldState((coord.X*5 + coord.Y*3) % 4)
+	})
+	g.GenerateNextUnits()
+
+	allocsPerTick := testing.AllocsPerRun(10, func() {
+		g.GenerateNextUnits()
+	})
+	if allocsPerTick != 0 {
+		t.Fatalf("Should not allocate when generating next units, but got %v allocations per tick.", allocsPerTick)
+	}
+	t.Log("Passed")
+}
+
+func TestNewWireworldNextUnitGenerator(t *testing.T) {
+	testNewWireworldNextUnitGeneratorCaseOne(t)
+	testNewWireworldNextUnitGeneratorCaseTwo(t)
+	testNewWireworldNextUnitGeneratorCaseThree(t)
+}
@@ -0,0 +1,27 @@
+package ggol
+
+// A UnitsEqualityChecker for comparable units, e.g. pass "AreUnitsEqual[CgolCell]" to GenerateNextUnitsUntilStable.
+func AreUnitsEqual[T comparable](unit *T, anotherUnit *T) bool {
+	return *unit == *anotherUnit
+}
+
+// Count the changed unit at (x, y) and extend the changed area to cover it.
+func (r *StabilizationReport) addChangedUnit(x int, y int) {
+	r.ChangedUnitsCount += 1
+	if r.ChangedArea == nil {
+		r.ChangedArea = &Area{From: Coordinate{X: x, Y: y}, To: Coordinate{X: x, Y: y}}
+		return
+	}
+	if x < r.ChangedArea.From.X {
+		r.ChangedArea.From.X = x
+	}
+	if y < r.ChangedArea.From.Y {
+		r.ChangedArea.From.Y = y
+	}
+	if x > r.ChangedArea.To.X {
+		r.ChangedArea.To.X = x
+	}
+	if y > r.ChangedArea.To.Y {
+		r.ChangedArea.To.Y = y
+	}
+}
@@ -0,0 +1,35 @@
+package ggol
+
+import (
+	"testing"
+)
+
+func testAreUnitsEqualCaseOne(t *testing.T) {
+	if !AreUnitsEqual(&unitForTest{hasLiveCell: true}, &unitForTest{hasLiveCell: true}) {
+		t.Fatalf("Units with the same fields should be equal.")
+	}
+	if AreUnitsEqual(&unitForTest{hasLiveCell: true}, &unitForTest{hasLiveCell: false}) {
+		t.Fatalf("Units with different fields should not be equal.")
+	}
+	t.Log("Passed")
+}
+
+func TestAreUnitsEqual(t *testing.T) {
+	testAreUnitsEqualCaseOne(t)
+}
+
+func testStabilizationReportAddChangedUnitCaseOne(t *testing.T) {
+	report := StabilizationReport{}
+	for _, coord := range []Coordinate{{X: 3, Y: -2}, {X: -1, Y: 5}, {X: 0, Y: 0}, {X: 3, Y: -2}} {
+		report.addChangedUnit(coord.X, coord.Y)
+	}
+	expectedArea := Area{From: Coordinate{X: -1, Y: -2}, To: Coordinate{X: 3, Y: 5}}
+	if report.ChangedUnitsCount != 4 || *report.ChangedArea != expectedArea {
+		t.Fatalf("Should count 4 changes within %v, but got %v and %v.", expectedArea, report.ChangedUnitsCount, report.ChangedArea)
+	}
+	t.Log("Passed")
+}
+
+func TestStabilizationReportAddChangedUnit(t *testing.T) {
+	testStabilizationReportAddChangedUnitCaseOne(t)
+}
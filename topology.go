@@ -0,0 +1,144 @@
+package ggol
+
+// Topology tells you how units are arranged in the map, it decides which units are adjacent to each other.
+// Games use it in IterateUnitsWithinDistance, and only take neighborhoods of the same topology.
+type Topology int
+
+const (
+	// Units are squares, every unit has 8 adjacent units that share an edge or a corner with it, this is the default topology.
+	TopologySquare Topology = iota
+	// Units are hexagons in "odd-r" offset coordinates, X is the column and Y is the row, odd rows are shifted right by half a unit.
+	// Every unit has 6 adjacent units. To wrap around the border correctly, the height of the map has to be even.
+	TopologyHexagonal
+	// Units are triangles, unit at (x, y) points up when x + y is even and points down when it's odd.
+	// Every unit has 12 adjacent units that share an edge or a corner with it, the first 3 of them share an edge.
+	// To wrap around the border correctly, both width and height of the map have to be even.
+	TopologyTriangular
+)
+
+var squareAdjacentRelativeCoordinates = []Coordinate{
+	{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 1, Y: -1}, {X: -1, Y: 0},
+	{X: 1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1},
+}
+
+var hexagonalAdjacentRelativeCoordinatesOfEvenRow = []Coordinate{
+	{X: 1, Y: 0}, {X: 0, Y: -1}, {X: -1, Y: -1}, {X: -1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: 1},
+}
+
+var hexagonalAdjacentRelativeCoordinatesOfOddRow = []Coordinate{
+	{X: 1, Y: 0}, {X: 1, Y: -1}, {X: 0, Y: -1}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1},
+}
+
+var triangularAdjacentRelativeCoordinatesOfUpTriangle = []Coordinate{
+	// Units sharing an edge.
+	{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1},
+	// Units sharing a corner.
+	{X: -2, Y: 0}, {X: 2, Y: 0},
+	{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 1, Y: -1},
+	{X: -2, Y: 1}, {X: -1, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1},
+}
+
+var triangularAdjacentRelativeCoordinatesOfDownTriangle = []Coordinate{
+	// Units sharing an edge.
+	{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1},
+	// Units sharing a corner.
+	{X: -2, Y: 0}, {X: 2, Y: 0},
+	{X: -1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1},
+	{X: -2, Y: -1}, {X: -1, Y: -1}, {X: 1, Y: -1}, {X: 2, Y: -1},
+}
+
+func (t Topology) isValid() bool {
+	return t >= TopologySquare && t <= TopologyTriangular
+}
+
+// Tell whether the map of the size wraps around correctly with the topology under the boundary policy.
+func (t Topology) isSizeSupported(size *Size, policy BoundaryPolicy) bool {
+	if policy != BoundaryPolicyWrap && policy != BoundaryPolicyKleinBottle && policy != BoundaryPolicyProjectivePlane {
+		return true
+	}
+	switch t {
+	case TopologyHexagonal:
+		return size.Height%2 == 0
+	case TopologyTriangular:
+		return size.Width%2 == 0 && size.Height%2 == 0
+	default:
+		return true
+	}
+}
+
+// Units in the same class have the same relative coordinates of adjacent units, there are at most 2 classes.
+func (t Topology) getClass(coord *Coordinate) int {
+	switch t {
+	case TopologyHexagonal:
+		return floorModulo(coord.Y, 2)
+	case TopologyTriangular:
+		return floorModulo(coord.X+coord.Y, 2)
+	default:
+		return 0
+	}
+}
+
+// Get relative coordinates of all adjacent units of the unit at the given coordinate,
+// you can pass them into AdjacentUnitGetter directly. Don't modify the returned slice, it's shared.
+func (t Topology) GetAdjacentRelativeCoordinates(coord *Coordinate) []Coordinate {
+	switch t {
+	case TopologyHexagonal:
+		if floorModulo(coord.Y, 2) == 0 {
+			return hexagonalAdjacentRelativeCoordinatesOfEvenRow
+		}
+		return hexagonalAdjacentRelativeCoordinatesOfOddRow
+	case TopologyTriangular:
+		if floorModulo(coord.X+coord.Y, 2) == 0 {
+			return triangularAdjacentRelativeCoordinatesOfUpTriangle
+		}
+		return triangularAdjacentRelativeCoordinatesOfDownTriangle
+	default:
+		return squareAdjacentRelativeCoordinates
+	}
+}
+
+// Convert a hexagonal coordinate in "odd-r" offset coordinates into axial coordinates, X is "q" and Y is "r".
+func ConvertOffsetToAxialCoordinate(coord *Coordinate) *Coordinate {
+	return &Coordinate{X: coord.X - floorDivide(coord.Y-floorModulo(coord.Y, 2), 2), Y: coord.Y}
+}
+
+// Convert a hexagonal coordinate in axial coordinates into "odd-r" offset coordinates.
+func ConvertAxialToOffsetCoordinate(coord *Coordinate) *Coordinate {
+	return &Coordinate{X: coord.X + floorDivide(coord.Y-floorModulo(coord.Y, 2), 2), Y: coord.Y}
+}
+
+// Find all coordinates within the distance from the center by walking through adjacent units of the topology.
+// "resolve" converts a coordinate into the coordinate where the unit is stored, it returns false when there is no unit there.
+func iterateCoordinatesWithinDistance(
+	topology Topology,
+	center *Coordinate,
+	distance int,
+	resolve func(coord *Coordinate) (resolvedCoord Coordinate, isResolved bool),
+	callback func(coord *Coordinate),
+) {
+	visitedCoords := map[Coordinate]bool{*center: true}
+	visitedResolvedCoords := make(map[Coordinate]bool)
+	currentCoords := []Coordinate{*center}
+
+	for step := 0; step <= distance && len(currentCoords) > 0; step++ {
+		nextCoords := make([]Coordinate, 0)
+		for i := range currentCoords {
+			coord := currentCoords[i]
+			if resolvedCoord, isResolved := resolve(&coord); isResolved && !visitedResolvedCoords[resolvedCoord] {
+				visitedResolvedCoords[resolvedCoord] = true
+				callback(&resolvedCoord)
+			}
+			if step == distance {
+				continue
+			}
+			for _, relativeCoord := range topology.GetAdjacentRelativeCoordinates(&coord) {
+				adjCoord := Coordinate{X: coord.X + relativeCoord.X, Y: coord.Y + relativeCoord.Y}
+				if !visitedCoords[adjCoord] {
+					visitedCoords[adjCoord] = true
+					nextCoords = append(nextCoords, adjCoord)
+				}
+			}
+		}
+		currentCoords = nextCoords
+	}
+}
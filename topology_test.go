@@ -0,0 +1,202 @@
+package ggol
+
+import (
+	"testing"
+)
+
+func testAdjacentRelativeCoordinatesAreSymmetric(t *testing.T, topology Topology, expectedCount int) {
+	for x := -3; x < 3; x++ {
+		for y := -3; y < 3; y++ {
+			coord := Coordinate{X: x, Y: y}
+			relativeCoords := topology.GetAdjacentRelativeCoordinates(&coord)
+			if len(relativeCoords) != expectedCount {
+				t.Fatalf("Topology %v should have %v adjacent units, but got %v.", topology, expectedCount, len(relativeCoords))
+			}
+			for _, relativeCoord := range relativeCoords {
+				adjCoord := Coordinate{X: x + relativeCoord.X, Y: y + relativeCoord.Y}
+				isSymmetric := false
+				for _, adjRelativeCoord := range topology.GetAdjacentRelativeCoordinates(&adjCoord) {
+					if adjCoord.X+adjRelativeCoord.X == x && adjCoord.Y+adjRelativeCoord.Y == y {
+						isSymmetric = true
+					}
+				}
+				if !isSymmetric {
+					t.Fatalf("Topology %v: %v is adjacent to %v, but not the other way around.", topology, adjCoord, coord)
+				}
+			}
+		}
+	}
+}
+
+func TestGetAdjacentRelativeCoordinates(t *testing.T) {
+	testAdjacentRelativeCoordinatesAreSymmetric(t, TopologySquare, 8)
+	testAdjacentRelativeCoordinatesAreSymmetric(t, TopologyHexagonal, 6)
+	testAdjacentRelativeCoordinatesAreSymmetric(t, TopologyTriangular, 12)
+	t.Log("Passed")
+}
+
+func testConvertAxialCoordinateCaseOne(t *testing.T) {
+	axialDirections := map[Coordinate]bool{
+		{X: 1, Y: 0}: true, {X: 1, Y: -1}: true, {X: 0, Y: -1}: true,
+		{X: -1, Y: 0}: true, {X: -1, Y: 1}: true, {X: 0, Y: 1}: true,
+	}
+	for x := -3; x < 3; x++ {
+		for y := -3; y < 3; y++ {
+			coord := Coordinate{X: x, Y: y}
+			axialCoord := ConvertOffsetToAxialCoordinate(&coord)
+			if *ConvertAxialToOffsetCoordinate(axialCoord) != coord {
+				t.Fatalf("Should convert %v back from axial coordinates.", coord)
+			}
+			for _, relativeCoord := range TopologyHexagonal.GetAdjacentRelativeCoordinates(&coord) {
+				adjAxialCoord := ConvertOffsetToAxialCoordinate(&Coordinate{X: x + relativeCoord.X, Y: y + relativeCoord.Y})
+				if !axialDirections[Coordinate{X: adjAxialCoord.X - axialCoord.X, Y: adjAxialCoord.Y - axialCoord.Y}] {
+					t.Fatalf("Adjacent unit of %v at %v is not adjacent in axial coordinates.", coord, relativeCoord)
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestConvertAxialCoordinate(t *testing.T) {
+	testConvertAxialCoordinateCaseOne(t)
+}
+
+func countUnitsWithinDistanceForTest(g Game[unitForTest], center *Coordinate, distance int) int {
+	count := 0
+	g.IterateUnitsWithinDistance(center, distance, func(coord *Coordinate, unit *unitForTest) {
+		count += 1
+	})
+	return count
+}
+
+func testIterateUnitsWithinDistanceCaseOne(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(20, 20, initialUnitForTest))
+	center := Coordinate{X: 10, Y: 10}
+	expectedCounts := map[Topology][]int{
+		TopologySquare:     {1, 9, 25},
+		TopologyHexagonal:  {1, 7, 19},
+		TopologyTriangular: {1, 13, 37},
+	}
+	for topology, counts := range expectedCounts {
+		g.SetTopology(topology)
+		for distance, expectedCount := range counts {
+			if count := countUnitsWithinDistanceForTest(g, &center, distance); count != expectedCount {
+				t.Fatalf("Topology %v should have %v units within distance %v, but got %v.", topology, expectedCount, distance, count)
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testIterateUnitsWithinDistanceCaseTwo(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(3, 3, initialUnitForTest))
+	corner := Coordinate{X: 0, Y: 0}
+
+	// Units are wrapped, so we can't get more units than the map has.
+	if count := countUnitsWithinDistanceForTest(g, &corner, 2); count != 9 {
+		t.Fatalf("Should get all 9 units when wrapping, but got %v.", count)
+	}
+
+	// Units outside the border are skipped.
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	if count := countUnitsWithinDistanceForTest(g, &corner, 1); count != 4 {
+		t.Fatalf("Should get 4 units at the corner with constant boundary, but got %v.", count)
+	}
+
+	if err := g.IterateUnitsWithinDistance(&corner, -1, func(coord *Coordinate, unit *unitForTest) {}); err == nil {
+		t.Fatalf("Should get error when distance is negative.")
+	}
+	t.Log("Passed")
+}
+
+func TestIterateUnitsWithinDistance(t *testing.T) {
+	testIterateUnitsWithinDistanceCaseOne(t)
+	testIterateUnitsWithinDistanceCaseTwo(t)
+}
+
+func testHexagonalGameCaseOne(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(6, 6, initialUnitForTest))
+	g.SetTopology(TopologyHexagonal)
+	topology := g.GetTopology()
+	// A unit becomes alive when any of its adjacent units is alive.
+	g.SetNextUnitGenerator(func(coord *Coordinate, unit *unitForTest, getAdjacentUnit AdjacentUnitGetter[unitForTest]) *unitForTest {
+		for _, relativeCoord := range topology.GetAdjacentRelativeCoordinates(coord) {
+			adjUnit, _ := getAdjacentUnit(coord, &relativeCoord)
+			if adjUnit.hasLiveCell {
+				return &liveUnitForTest
+			}
+		}
+		return unit
+	})
+	g.SetUnit(&Coordinate{X: 0, Y: 0}, &unitForTest{hasLiveCell: true})
+	g.GenerateNextUnits()
+
+	liveCoords := make(map[Coordinate]bool)
+	g.IterateUnits(func(coord *Coordinate, unit *unitForTest) {
+		if unit.hasLiveCell {
+			liveCoords[*coord] = true
+		}
+	})
+	expectedLiveCoords := []Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 5, Y: 0}, {X: 0, Y: 1}, {X: 5, Y: 1}, {X: 0, Y: 5}, {X: 5, Y: 5}}
+	if len(liveCoords) != len(expectedLiveCoords) {
+		t.Fatalf("Should have %v live cells, but got %v.", len(expectedLiveCoords), liveCoords)
+	}
+	for _, coord := range expectedLiveCoords {
+		if !liveCoords[coord] {
+			t.Fatalf("Unit at %v should be alive, but got %v.", coord, liveCoords)
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestHexagonalGame(t *testing.T) {
+	testHexagonalGameCaseOne(t)
+}
+
+func testSetTopologyCaseOne(t *testing.T) {
+	g, _ := NewGame(generateInitialUnitMatrixForTest(2, 2, initialUnitForTest))
+	if err := g.SetTopology(Topology(100)); err == nil {
+		t.Fatalf("Should get error when topology is invalid.")
+	}
+	if err := g.SetTopology(TopologyTriangular); err != nil || g.GetTopology() != TopologyTriangular {
+		t.Fatalf("Should set topology correctly.")
+	}
+	t.Log("Passed")
+}
+
+func testSetTopologyCaseTwo(t *testing.T) {
+	// Hexagonal maps need even height to wrap around, triangular maps need even width and height.
+	sizes := map[Topology][]Size{
+		TopologyHexagonal:  {{Width: 4, Height: 5}},
+		TopologyTriangular: {{Width: 5, Height: 4}, {Width: 4, Height: 5}},
+	}
+	for topology, oddSizes := range sizes {
+		for _, size := range oddSizes {
+			for _, policy := range []BoundaryPolicy{BoundaryPolicyWrap, BoundaryPolicyKleinBottle, BoundaryPolicyProjectivePlane} {
+				g, _ := NewGame(generateInitialUnitMatrixForTest(size.Width, size.Height, initialUnitForTest))
+				g.SetBoundaryPolicy(policy)
+				if _, ok := g.SetTopology(topology).(*ErrSizeIsNotSupported); !ok {
+					t.Fatalf("Should get ErrSizeIsNotSupported when setting topology %v to %vx%v map with policy %v.", topology, size.Width, size.Height, policy)
+				}
+				if g.GetTopology() != TopologySquare {
+					t.Fatalf("Topology should not be changed when the size is not supported.")
+				}
+
+				g.SetBoundaryPolicy(BoundaryPolicyMirror)
+				if err := g.SetTopology(topology); err != nil {
+					t.Fatalf("Should set topology %v to %vx%v map with the mirror policy, but got %v.", topology, size.Width, size.Height, err)
+				}
+				if _, ok := g.SetBoundaryPolicy(policy).(*ErrSizeIsNotSupported); !ok {
+					t.Fatalf("Should get ErrSizeIsNotSupported when setting policy %v to %vx%v map with topology %v.", policy, size.Width, size.Height, topology)
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func TestSetTopology(t *testing.T) {
+	testSetTopologyCaseOne(t)
+	testSetTopologyCaseTwo(t)
+}
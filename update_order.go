@@ -0,0 +1,87 @@
+package ggol
+
+// UpdateOrder tells the game in which order units are updated in a generation.
+type UpdateOrder int
+
+const (
+	// All units are generated from the units of the last generation at the same time, this is the default order.
+	UpdateOrderSynchronous UpdateOrder = iota
+	// Units are updated one by one, row by row from top to bottom and from left to right in every row,
+	// every unit sees units updated before it in the same generation.
+	UpdateOrderSequential
+	// Units are updated one by one in a random permutation that is shuffled in every generation,
+	// every unit sees units updated before it in the same generation.
+	UpdateOrderRandom
+	// Units are colored like a checkerboard, units with even "x + y" are generated at the same time first,
+	// then units with odd "x + y" are generated at the same time from them. It's also known as red-black update.
+	UpdateOrderCheckerboard
+	// Every unit has its own Poisson clock with rate 1, the unit is updated whenever its clock rings.
+	// So a unit can be updated many times or never in a generation, and units are updated once per generation on average.
+	UpdateOrderPoisson
+)
+
+func (o UpdateOrder) isValid() bool {
+	return o >= UpdateOrderSynchronous && o <= UpdateOrderPoisson
+}
+
+// Update the unit at (x, y) in place, so units updated afterwards can see it.
+func (g *gameInfo[T]) updateUnit(x int, y int, updateIndex int) {
+	(*g.units)[x][y] = *g.generateNextUnit(0, x, y, updateIndex)
+}
+
+// Update units one by one in place with the update order, units of the last generation are kept in nextUnits.
+func (g *gameInfo[T]) updateUnitsAsynchronously() {
+	for x := 0; x < g.size.Width; x++ {
+		copy((*g.nextUnits)[x], (*g.units)[x])
+	}
+
+	switch g.updateOrder {
+	case UpdateOrderSequential:
+		for y := 0; y < g.size.Height; y++ {
+			for x := 0; x < g.size.Width; x++ {
+				g.updateUnit(x, y, 0)
+			}
+		}
+	case UpdateOrderRandom:
+		if g.updateOrderCoords == nil {
+			g.updateOrderCoords = make([]Coordinate, 0, g.size.Width*g.size.Height)
+			for x := 0; x < g.size.Width; x++ {
+				for y := 0; y < g.size.Height; y++ {
+					g.updateOrderCoords = append(g.updateOrderCoords, Coordinate{X: x, Y: y})
+				}
+			}
+		}
+		g.random.Shuffle(len(g.updateOrderCoords), func(i int, j int) {
+			g.updateOrderCoords[i], g.updateOrderCoords[j] = g.updateOrderCoords[j], g.updateOrderCoords[i]
+		})
+		for _, coord := range g.updateOrderCoords {
+			g.updateUnit(coord.X, coord.Y, 0)
+		}
+	case UpdateOrderPoisson:
+		// Clocks of all units together ring as one Poisson clock with rate "unitsCount",
+		// and every ring belongs to a random unit.
+		// The count of rings is passed as the update index, so a unit updated twice gets different random numbers.
+		unitsCount := g.size.Width * g.size.Height
+		ringsCount := 0
+		for time := g.random.ExpFloat64() / float64(unitsCount); time < 1; time += g.random.ExpFloat64() / float64(unitsCount) {
+			index := g.random.Intn(unitsCount)
+			g.updateUnit(index/g.size.Height, index%g.size.Height, ringsCount)
+			ringsCount += 1
+		}
+	}
+}
+
+// Generate units of the given parity on the checkerboard from current units, then exchange them with the next units,
+// so current units become next units and next units keep units of the last generation.
+func (g *gameInfo[T]) generateNextUnitsOfParity(parity int) {
+	g.generateNextUnitsInStripes(parity)
+	for x := 0; x < g.size.Width; x++ {
+		for y := 0; y < g.size.Height; y++ {
+			if (x+y)%2 == parity {
+				(*g.units)[x][y], (*g.nextUnits)[x][y] = (*g.nextUnits)[x][y], (*g.units)[x][y]
+			} else if parity == 0 {
+				(*g.nextUnits)[x][y] = (*g.units)[x][y]
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package ggol
+
+import (
+	"testing"
+)
+
+// The next unit is the sum of the left unit and the top unit plus 1.
+func leftAndTopSumUnitForTestIterator(coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	leftUnit, _ := getAdjacentUnit(coord, &Coordinate{X: -1, Y: 0})
+	topUnit, _ := getAdjacentUnit(coord, &Coordinate{X: 0, Y: -1})
+	nextUnit := *leftUnit + *topUnit + 1
+	return &nextUnit
+}
+
+// The next unit is the sum of 4 adjacent units plus 1.
+func vonNeumannSumUnitForTestIterator(coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	nextUnit := 1
+	for _, relativeCoord := range []Coordinate{{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: 1}} {
+		adjacentUnit, _ := getAdjacentUnit(coord, &relativeCoord)
+		nextUnit += *adjacentUnit
+	}
+	return &nextUnit
+}
+
+// The next unit counts how many times the unit is updated.
+func countingUnitForTestIterator(coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	nextUnit := *unit + 1
+	return &nextUnit
+}
+
+func generateUnitsWithUpdateOrderForTest(width int, height int, order UpdateOrder, seed int64, generator NextUnitGenerator[int]) *[][]int {
+	g, _ := NewGame(generateCoordinateUnitsForTest(width, height))
+	g.IterateUnits(func(coord *Coordinate, unit *int) {
+		*unit = 0
+	})
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	g.SetNextUnitGenerator(generator)
+	g.SetUpdateOrder(order, seed)
+	return g.GenerateNextUnits()
+}
+
+func areIntUnitsEqualForTest(units *[][]int, anotherUnits *[][]int) bool {
+	for x := range *units {
+		for y := range (*units)[x] {
+			if (*units)[x][y] != (*anotherUnits)[x][y] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func testUpdateOrderSynchronous(t *testing.T) {
+	units := generateUnitsWithUpdateOrderForTest(3, 2, UpdateOrderSynchronous, 0, leftAndTopSumUnitForTestIterator)
+	expectedUnits := [][]int{{1, 1}, {1, 1}, {1, 1}}
+	if !areIntUnitsEqualForTest(units, &expectedUnits) {
+		t.Fatalf("Units should be %v, but got %v.", expectedUnits, *units)
+	}
+	t.Log("Passed")
+}
+
+func testUpdateOrderSequential(t *testing.T) {
+	units := generateUnitsWithUpdateOrderForTest(3, 2, UpdateOrderSequential, 0, leftAndTopSumUnitForTestIterator)
+	// Units are updated row by row, so every unit sees its updated left unit and top unit.
+	expectedUnits := [][]int{{1, 2}, {2, 5}, {3, 9}}
+	if !areIntUnitsEqualForTest(units, &expectedUnits) {
+		t.Fatalf("Units should be %v, but got %v.", expectedUnits, *units)
+	}
+	t.Log("Passed")
+}
+
+func testUpdateOrderRandom(t *testing.T) {
+	units := generateUnitsWithUpdateOrderForTest(10, 10, UpdateOrderRandom, 7, countingUnitForTestIterator)
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if (*units)[x][y] != 1 {
+				t.Fatalf("Every unit should be updated once, but unit at (%v, %v) is updated %v times.", x, y, (*units)[x][y])
+			}
+		}
+	}
+
+	units = generateUnitsWithUpdateOrderForTest(10, 10, UpdateOrderRandom, 7, leftAndTopSumUnitForTestIterator)
+	unitsWithSameSeed := generateUnitsWithUpdateOrderForTest(10, 10, UpdateOrderRandom, 7, leftAndTopSumUnitForTestIterator)
+	unitsWithAnotherSeed := generateUnitsWithUpdateOrderForTest(10, 10, UpdateOrderRandom, 8, leftAndTopSumUnitForTestIterator)
+	if !areIntUnitsEqualForTest(units, unitsWithSameSeed) {
+		t.Fatalf("Units should be the same with the same seed.")
+	}
+	if areIntUnitsEqualForTest(units, unitsWithAnotherSeed) {
+		t.Fatalf("Units should be different with another seed.")
+	}
+	t.Log("Passed")
+}
+
+func testUpdateOrderCheckerboard(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		g, _ := NewGame(&[][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}})
+		g.SetNextUnitGenerator(vonNeumannSumUnitForTestIterator)
+		g.SetConcurrency(concurrency)
+		g.SetUpdateOrder(UpdateOrderCheckerboard, 0)
+		units := g.GenerateNextUnits()
+
+		// Units with even "x + y" become 1 first, then units with odd "x + y" see 4 of them.
+		for x := 0; x < 4; x++ {
+			for y := 0; y < 4; y++ {
+				expectedUnit := 1
+				if (x+y)%2 == 1 {
+					expectedUnit = 5
+				}
+				if (*units)[x][y] != expectedUnit {
+					t.Fatalf("Unit at (%v, %v) should be %v with concurrency %v, but got %v.", x, y, expectedUnit, concurrency, (*units)[x][y])
+				}
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testUpdateOrderPoisson(t *testing.T) {
+	units := generateUnitsWithUpdateOrderForTest(100, 100, UpdateOrderPoisson, 7, countingUnitForTestIterator)
+	updatesCount := 0
+	for x := 0; x < 100; x++ {
+		for y := 0; y < 100; y++ {
+			updatesCount += (*units)[x][y]
+		}
+	}
+	// Updates count follows the Poisson distribution with mean 10000, so it's within 5 standard deviations.
+	if updatesCount < 9500 || updatesCount > 10500 {
+		t.Fatalf("Units should be updated about 10000 times, but got %v.", updatesCount)
+	}
+
+	unitsWithSameSeed := generateUnitsWithUpdateOrderForTest(100, 100, UpdateOrderPoisson, 7, countingUnitForTestIterator)
+	if !areIntUnitsEqualForTest(units, unitsWithSameSeed) {
+		t.Fatalf("Units should be the same with the same seed.")
+	}
+	t.Log("Passed")
+}
+
+func testUpdateOrderUntilStable(t *testing.T) {
+	units := [][]int{{5}, {0}, {0}, {0}}
+	g, _ := NewGame(&units)
+	g.SetBoundaryPolicy(BoundaryPolicyConstant)
+	g.SetNextUnitGenerator(decayingUnitForTestIterator)
+	g.SetUpdateOrder(UpdateOrderSequential, 0)
+
+	// The decay spreads through the whole row in one generation, because every unit sees its updated left unit.
+	report, err := g.GenerateNextUnitsUntilStable(10, AreUnitsEqual[int])
+	if err != nil || report.GenerationsCount != 1 || report.ChangedUnitsCount != 3 {
+		t.Fatalf("Should change 3 units in 1 generation, but got %v and error: %v.", report, err)
+	}
+	t.Log("Passed")
+}
+
+func TestSetUpdateOrder(t *testing.T) {
+	testUpdateOrderSynchronous(t)
+	testUpdateOrderSequential(t)
+	testUpdateOrderRandom(t)
+	testUpdateOrderCheckerboard(t)
+	testUpdateOrderPoisson(t)
+	testUpdateOrderUntilStable(t)
+}
+
+func testSetUpdateOrderWithInvalidOrder(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(3, 3))
+	if err := g.SetUpdateOrder(UpdateOrder(-1), 0); err == nil {
+		t.Fatalf("Should get error when update order is invalid.")
+	} else if _, ok := err.(*ErrUpdateOrderIsInvalid); !ok {
+		t.Fatalf("Should get ErrUpdateOrderIsInvalid, but got %v.", err)
+	}
+	t.Log("Passed")
+}
+
+func TestSetUpdateOrderWithInvalidOrder(t *testing.T) {
+	testSetUpdateOrderWithInvalidOrder(t)
+}
+
+func testSetUpdateOrderWithGenerationPreparer(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(3, 3))
+	preparer := func(area *Area, getAdjacentUnit AdjacentUnitGetter[int]) {}
+	g.SetGenerationPreparer(preparer)
+	// The preparer is called once a generation, but units it read change during other update orders.
+	if _, ok := g.SetUpdateOrder(UpdateOrderCheckerboard, 0).(*ErrUpdateOrderIsNotSupported); !ok {
+		t.Fatalf("Should get ErrUpdateOrderIsNotSupported when GenerationPreparer is set.")
+	}
+
+	g.SetGenerationPreparer(nil)
+	if err := g.SetUpdateOrder(UpdateOrderRandom, 0); err != nil {
+		t.Fatalf("Should be able to set update order after GenerationPreparer is removed, but got %v.", err)
+	}
+	if _, ok := g.SetGenerationPreparer(preparer).(*ErrUpdateOrderIsNotSupported); !ok {
+		t.Fatalf("Should get ErrUpdateOrderIsNotSupported when setting GenerationPreparer with UpdateOrderRandom.")
+	}
+	t.Log("Passed")
+}
+
+func TestSetUpdateOrderWithGenerationPreparer(t *testing.T) {
+	testSetUpdateOrderWithGenerationPreparer(t)
+}
@@ -0,0 +1,125 @@
+package ggol
+
+// Zone tells the game which units follow another NextUnitGenerator, like water or lava in a part of the map.
+// Set either Area or Mask to decide units in the zone.
+type Zone[T any] struct {
+	// Units in the area are in the zone.
+	Area *Area
+	// Units with true in the mask are in the zone, it's indexed like units and has the same size as the game.
+	Mask *[][]bool
+	// Units in the zone are generated with it instead of the generators you set to the game.
+	NextUnitGenerator NextUnitGenerator[T]
+}
+
+type zoneInfo[T any] struct {
+	name string
+	// The area of the zone, it's nil when the zone has a mask.
+	area *Area
+	// The copy of the mask, so changing the mask you passed in doesn't change the zone.
+	mask              [][]bool
+	nextUnitGenerator NextUnitGenerator[T]
+}
+
+func (z *zoneInfo[T]) hasUnit(x int, y int) bool {
+	if z.area != nil {
+		return x >= z.area.From.X && x <= z.area.To.X && y >= z.area.From.Y && y <= z.area.To.Y
+	}
+	return z.mask[x][y]
+}
+
+// Build the index of the zone of every unit, -1 means the unit is in no zone.
+// Zones added later are checked first, so they take precedence over zones added before.
+func (g *gameInfo[T]) buildZoneIndexes() {
+	if len(g.zones) == 0 {
+		g.zoneIndexes = nil
+		return
+	}
+	if g.zoneIndexes == nil {
+		g.zoneIndexes = make([][]int, g.size.Width)
+		for x := 0; x < g.size.Width; x++ {
+			g.zoneIndexes[x] = make([]int, g.size.Height)
+		}
+	}
+	for x := 0; x < g.size.Width; x++ {
+		for y := 0; y < g.size.Height; y++ {
+			g.zoneIndexes[x][y] = -1
+			for zoneIndex := len(g.zones) - 1; zoneIndex >= 0; zoneIndex-- {
+				if g.zones[zoneIndex].hasUnit(x, y) {
+					g.zoneIndexes[x][y] = zoneIndex
+					break
+				}
+			}
+		}
+	}
+}
+
+func (g *gameInfo[T]) findZone(name string) int {
+	for zoneIndex := range g.zones {
+		if g.zones[zoneIndex].name == name {
+			return zoneIndex
+		}
+	}
+	return -1
+}
+
+// Add the zone with the name.
+func (g *gameInfo[T]) AddZone(name string, zone *Zone[T]) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	if g.findZone(name) >= 0 {
+		return &ErrZoneAlreadyExists{name}
+	}
+	if zone.NextUnitGenerator == nil || (zone.Area == nil) == (zone.Mask == nil) {
+		return &ErrZoneIsInvalid{name}
+	}
+
+	newZone := zoneInfo[T]{name: name, nextUnitGenerator: zone.NextUnitGenerator}
+	if zone.Area != nil {
+		if g.isCoordinateInvalid(&zone.Area.From) {
+			return &ErrCoordinateIsInvalid{&zone.Area.From}
+		}
+		if g.isCoordinateInvalid(&zone.Area.To) {
+			return &ErrCoordinateIsInvalid{&zone.Area.To}
+		}
+		if g.isAreaInvalid(zone.Area) {
+			return &ErrAreaIsInvalid{zone.Area}
+		}
+		area := *zone.Area
+		newZone.area = &area
+	} else {
+		if len(*zone.Mask) != g.size.Width {
+			return &ErrZoneIsInvalid{name}
+		}
+		newZone.mask = make([][]bool, g.size.Width)
+		for x := 0; x < g.size.Width; x++ {
+			if len((*zone.Mask)[x]) != g.size.Height {
+				return &ErrZoneIsInvalid{name}
+			}
+			newZone.mask[x] = append([]bool{}, (*zone.Mask)[x]...)
+		}
+	}
+
+	g.zones = append(g.zones, newZone)
+	g.buildZoneIndexes()
+	return nil
+}
+
+// Remove the zone with the name.
+func (g *gameInfo[T]) RemoveZone(name string) error {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	g.activateAllUnits()
+
+	zoneIndex := g.findZone(name)
+	if zoneIndex < 0 {
+		return &ErrZoneIsNotFound{name}
+	}
+
+	g.zones = append(g.zones[:zoneIndex], g.zones[zoneIndex+1:]...)
+	g.buildZoneIndexes()
+	return nil
+}
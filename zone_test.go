@@ -0,0 +1,150 @@
+package ggol
+
+import (
+	"testing"
+)
+
+func addingUnitForTestZone(amount int) NextUnitGenerator[int] {
+	return func(coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+		nextUnit := *unit + amount
+		return &nextUnit
+	}
+}
+
+func leftUnitForTestZone(coord *Coordinate, unit *int, getAdjacentUnit AdjacentUnitGetter[int]) *int {
+	leftUnit, _ := getAdjacentUnit(coord, &Coordinate{X: -1, Y: 0})
+	return leftUnit
+}
+
+func testAddZoneCaseOne(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(4, 4))
+	g.SetNextUnitGenerator(countingUnitForTestIterator)
+	g.AddZone("a", &Zone[int]{Area: &Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: 1, Y: 1}}, NextUnitGenerator: addingUnitForTestZone(100)})
+	g.AddZone("b", &Zone[int]{Area: &Area{From: Coordinate{X: 1, Y: 1}, To: Coordinate{X: 2, Y: 2}}, NextUnitGenerator: addingUnitForTestZone(1000)})
+
+	units := g.GenerateNextUnits()
+	// Zone "b" is added later, so it takes precedence at (1, 1).
+	expectedUnits := map[Coordinate]int{{X: 0, Y: 0}: 100, {X: 1, Y: 0}: 110, {X: 1, Y: 1}: 1011, {X: 2, Y: 2}: 1022, {X: 3, Y: 3}: 34}
+	for coord, expectedUnit := range expectedUnits {
+		if (*units)[coord.X][coord.Y] != expectedUnit {
+			t.Fatalf("Unit at %v should be %v, but got %v.", coord, expectedUnit, (*units)[coord.X][coord.Y])
+		}
+	}
+	t.Log("Passed")
+}
+
+func testAddZoneCaseTwo(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		g, _ := NewGame(generateCoordinateUnitsForTest(3, 3))
+		g.SetConcurrency(concurrency)
+		g.SetNextUnitGenerator(countingUnitForTestIterator)
+		mask := [][]bool{{false, false, false}, {true, true, true}, {false, false, false}}
+		g.AddZone("mask", &Zone[int]{Mask: &mask, NextUnitGenerator: leftUnitForTestZone})
+		// Changing the mask after the zone is added changes nothing.
+		mask[2][0] = true
+
+		units := g.GenerateNextUnits()
+		for y := 0; y < 3; y++ {
+			// Units in the zone read units of the last generation, not units generated by the game in this generation.
+			if (*units)[1][y] != y {
+				t.Fatalf("Unit at (1, %v) should be %v with concurrency %v, but got %v.", y, y, concurrency, (*units)[1][y])
+			}
+			if (*units)[2][y] != 21+y {
+				t.Fatalf("Unit at (2, %v) should be %v with concurrency %v, but got %v.", y, 21+y, concurrency, (*units)[2][y])
+			}
+		}
+	}
+	t.Log("Passed")
+}
+
+func testAddZoneCaseThree(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(2, 2))
+	g.SetNextUnitGeneratorPipeline(addingUnitForTestPhase, addingUnitForTestPhase)
+	g.AddZone("a", &Zone[int]{Area: &Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: 0, Y: 1}}, NextUnitGenerator: addingUnitForTestZone(100)})
+
+	// Units in the zone are generated in the first phase only.
+	units := g.GenerateNextUnits()
+	if (*units)[0][1] != 101 || (*units)[1][1] != 13 {
+		t.Fatalf("Units should be 101 and 13, but got %v and %v.", (*units)[0][1], (*units)[1][1])
+	}
+	t.Log("Passed")
+}
+
+func testAddZoneCaseFour(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(2, 2))
+	generator := addingUnitForTestZone(1)
+	mask := [][]bool{{true, true}, {true, true}}
+	wrongMask := [][]bool{{true, true}}
+	area := &Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: 1, Y: 1}}
+
+	invalidZones := []*Zone[int]{
+		{Area: area},
+		{NextUnitGenerator: generator},
+		{Area: area, Mask: &mask, NextUnitGenerator: generator},
+		{Mask: &wrongMask, NextUnitGenerator: generator},
+	}
+	for i, zone := range invalidZones {
+		if _, ok := g.AddZone("a", zone).(*ErrZoneIsInvalid); !ok {
+			t.Fatalf("Zone %v should be invalid.", i)
+		}
+	}
+	err := g.AddZone("a", &Zone[int]{Area: &Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: 2, Y: 0}}, NextUnitGenerator: generator})
+	if _, ok := err.(*ErrCoordinateIsInvalid); !ok {
+		t.Fatalf("Should get ErrCoordinateIsInvalid, but got %v.", err)
+	}
+	err = g.AddZone("a", &Zone[int]{Area: &Area{From: Coordinate{X: 1, Y: 0}, To: Coordinate{X: 0, Y: 0}}, NextUnitGenerator: generator})
+	if _, ok := err.(*ErrAreaIsInvalid); !ok {
+		t.Fatalf("Should get ErrAreaIsInvalid, but got %v.", err)
+	}
+
+	g.AddZone("a", &Zone[int]{Area: area, NextUnitGenerator: generator})
+	if _, ok := g.AddZone("a", &Zone[int]{Mask: &mask, NextUnitGenerator: generator}).(*ErrZoneAlreadyExists); !ok {
+		t.Fatalf("Should get ErrZoneAlreadyExists.")
+	}
+	if _, ok := g.RemoveZone("b").(*ErrZoneIsNotFound); !ok {
+		t.Fatalf("Should get ErrZoneIsNotFound.")
+	}
+	t.Log("Passed")
+}
+
+func TestAddZone(t *testing.T) {
+	testAddZoneCaseOne(t)
+	testAddZoneCaseTwo(t)
+	testAddZoneCaseThree(t)
+	testAddZoneCaseFour(t)
+}
+
+func testRemoveZoneCaseOne(t *testing.T) {
+	g, _ := NewGame(generateCoordinateUnitsForTest(2, 2))
+	g.SetNextUnitGenerator(countingUnitForTestIterator)
+	area := &Area{From: Coordinate{X: 0, Y: 0}, To: Coordinate{X: 1, Y: 1}}
+	g.AddZone("a", &Zone[int]{Area: area, NextUnitGenerator: addingUnitForTestZone(100)})
+	g.AddZone("b", &Zone[int]{Area: area, NextUnitGenerator: addingUnitForTestZone(1000)})
+	if unit := (*g.GenerateNextUnits())[1][1]; unit != 1011 {
+		t.Fatalf("Unit should be 1011 with zone \"b\", but got %v.", unit)
+	}
+
+	g.RemoveZone("b")
+	if unit := (*g.GenerateNextUnits())[1][1]; unit != 1111 {
+		t.Fatalf("Unit should be 1111 with zone \"a\", but got %v.", unit)
+	}
+
+	g.RemoveZone("a")
+	if unit := (*g.GenerateNextUnits())[1][1]; unit != 1112 {
+		t.Fatalf("Unit should be 1112 without zones, but got %v.", unit)
+	}
+
+	// A zone added again takes precedence over zones added before it.
+	g.AddZone("a", &Zone[int]{Area: area, NextUnitGenerator: addingUnitForTestZone(100)})
+	g.AddZone("b", &Zone[int]{Area: area, NextUnitGenerator: addingUnitForTestZone(1000)})
+	g.RemoveZone("a")
+	g.AddZone("a", &Zone[int]{Area: area, NextUnitGenerator: addingUnitForTestZone(100)})
+	if unit := (*g.GenerateNextUnits())[1][1]; unit != 1212 {
+		t.Fatalf("Unit should be 1212 with zone \"a\" added again, but got %v.", unit)
+	}
+	t.Log("Passed")
+}
+
+func TestRemoveZone(t *testing.T) {
+	testRemoveZoneCaseOne(t)
+}